@@ -1,8 +1,11 @@
 package backend
 
 import (
+	"bytes"
+	"cmp"
 	"math"
 	"errors"
+	"slices"
 	"encoding/binary"
 )
 
@@ -207,9 +210,10 @@ func readByteSlice(bs []byte) ([]byte, int, error) {
 	l, n, err := ReadVarUint64(bs)
 	if err != nil { return nil, 0, err }
 
+	// Note: compared this way so that a huge length can't overflow
+	if l > uint64(len(bs) - n) { return nil, 0, ErrTruncatedData }
 	startRead := n
 	endRead := n + int(l)
-	if len(bs) < endRead { return nil, 0, ErrTruncatedData }
 
 	ret := bs[startRead:endRead]
 	return ret, endRead, nil
@@ -249,6 +253,46 @@ func ReadBool(bs []byte) (bool, int, error) {
 	return ret, n, nil
 }
 
+// Packed Bools: A uvarint length followed by one bit per bool, packed into ceil(length/8) bytes
+func WritePackedBools[T ~bool](bs []byte, v []T) []byte {
+	bs = WriteVarUint64(bs, uint64(len(v)))
+
+	var bits uint8
+	for i := range v {
+		if v[i] {
+			bits |= 1 << (i % 8)
+		}
+		if i % 8 == 7 {
+			bs = append(bs, bits)
+			bits = 0
+		}
+	}
+	if len(v) % 8 != 0 {
+		bs = append(bs, bits)
+	}
+	return bs
+}
+
+// Reads the packed bools into v, reusing its capacity
+func ReadPackedBools[T ~bool](bs []byte, v []T, lim *Limiter) ([]T, int, error) {
+	l, n, err := ReadVarUint64(bs)
+	if err != nil { return v, 0, err }
+	err = LimitSlice[T](lim, l)
+	if err != nil { return v, 0, err }
+
+	// Note: compared this way so that a huge length can't overflow
+	remaining := uint64(len(bs) - n)
+	if l > remaining * 8 { return v, 0, ErrTruncatedData }
+	byteLen := int((l + 7) / 8)
+
+	v = slices.Grow(v[:0], int(l))
+	for i := 0; i < int(l); i++ {
+		bit := bs[n + (i / 8)] & (1 << (i % 8))
+		v = append(v, T(bit != 0))
+	}
+	return v, n + byteLen, nil
+}
+
 // Floats
 func WriteFloat32(bs []byte, v float32) []byte {
 	return WriteUint32(bs, math.Float32bits(v))
@@ -271,3 +315,116 @@ func ReadFloat64(bs []byte) (float64, int, error) {
 	ret := math.Float64frombits(v)
 	return ret, n, nil
 }
+
+//--------------------------------------------------------------------------------
+// Size - Returns the number of bytes that the matching Write function appends
+//--------------------------------------------------------------------------------
+func sizeUvarint(v uint64) int {
+	n := 1
+	for v >= 0x80 {
+		v >>= 7
+		n++
+	}
+	return n
+}
+
+func sizeVarint(v int64) int {
+	// Matches the zigzag encoding used by binary.AppendVarint
+	ux := uint64(v) << 1
+	if v < 0 {
+		ux = ^ux
+	}
+	return sizeUvarint(ux)
+}
+
+func SizeUint(v uint) int {
+	return sizeUvarint(uint64(v))
+}
+func SizeInt(v int) int {
+	return sizeVarint(int64(v))
+}
+
+// Fixed Width
+func SizeUint8(v uint8) int { return sizeUint8 }
+func SizeUint16(v uint16) int { return sizeUint16 }
+func SizeUint32(v uint32) int { return sizeUint32 }
+func SizeUint64(v uint64) int { return sizeUint64 }
+
+func SizeInt8(v int8) int { return sizeInt8 }
+func SizeInt16(v int16) int { return sizeInt16 }
+func SizeInt32(v int32) int { return sizeInt32 }
+func SizeInt64(v int64) int { return sizeInt64 }
+
+// Variable Width
+func SizeVarUint16(v uint16) int { return sizeUvarint(uint64(v)) }
+func SizeVarUint32(v uint32) int { return sizeUvarint(uint64(v)) }
+func SizeVarUint64(v uint64) int { return sizeUvarint(v) }
+
+func SizeVarInt16(v int16) int { return sizeVarint(int64(v)) }
+func SizeVarInt32(v int32) int { return sizeVarint(int64(v)) }
+func SizeVarInt64(v int64) int { return sizeVarint(v) }
+
+// Complex types
+func SizeString(v string) int {
+	return sizeUvarint(uint64(len(v))) + len(v)
+}
+
+func SizeBool(v bool) int { return sizeUint8 }
+func SizePackedBools[T ~bool](v []T) int {
+	return sizeUvarint(uint64(len(v))) + (len(v) + 7) / 8
+}
+func SizeFloat32(v float32) int { return sizeUint32 }
+func SizeFloat64(v float64) int { return sizeUint64 }
+
+//--------------------------------------------------------------------------------
+// Helpers
+//--------------------------------------------------------------------------------
+
+// Returns the number of elements to preallocate when decoding a slice or map of the given length.
+// The length comes from the data, so it is capped by the number of remaining bytes to prevent huge allocations
+func PreallocLen(length uint64, remaining int) int {
+	if length > uint64(remaining) { return remaining }
+	return int(length)
+}
+
+// Resets a slice to zero length so that it can be decoded into, reusing its capacity
+func ResetSlice[T any](v []T, length uint64, remaining int) []T {
+	return slices.Grow(v[:0], PreallocLen(length, remaining))
+}
+
+// Adds one element to the end of a slice that is being decoded into. If the slice has capacity, then the element that was already there is kept so that its slices and maps can be reused
+func ExtendSlice[T any](v []T) []T {
+	if len(v) < cap(v) { return v[:len(v)+1] }
+	var zero T
+	return append(v, zero)
+}
+
+// Returns the keys of a map in sorted order. Used to encode maps deterministically
+func SortedKeys[M ~map[K]V, K cmp.Ordered, V any](m M) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Encodes the entries of a map in the order of their encoded bytes. Used to encode maps deterministically when the keys can't be ordered (ie structs).
+// The key encodings don't prefix each other, so entries are ordered by their keys
+func AppendSortedEntries[M ~map[K]V, K comparable, V any](bs []byte, m M, encode func([]byte, K, V) []byte) []byte {
+	entries := make([][]byte, 0, len(m))
+	for k, v := range m {
+		entries = append(entries, encode(nil, k, v))
+	}
+	slices.SortFunc(entries, bytes.Compare)
+	for _, e := range entries {
+		bs = append(bs, e...)
+	}
+	return bs
+}
+
+// Compares two floats so that NaN equals NaN and -0 doesn't equal 0. This keeps a decoded float equal to itself
+func EqualFloat[T ~float32 | ~float64](a, b T) bool {
+	if a != a { return b != b }
+	return a == b && math.Signbit(float64(a)) == math.Signbit(float64(b))
+}
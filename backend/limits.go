@@ -0,0 +1,106 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+var ErrLimitExceeded = errors.New("cod: decode limit exceeded")
+
+// Caps the resources that a decode can use. Used to decode untrusted data. A limit of zero means no limit
+type DecodeLimits struct {
+	MaxSliceLen int // The max number of elements in a single slice
+	MaxMapLen int // The max number of entries in a single map
+	MaxStringLen int // The max number of bytes in a single string
+	MaxDepth int // The max number of nested types
+	MaxAlloc int // The max total number of bytes allocated for slices, maps and strings
+}
+
+// A Limiter tracks the decode limits as data is decoded. A nil Limiter has no limits.
+// The allocation total is shared by every decode that uses the limiter, so use a new limiter (or call Reset) for each message
+type Limiter struct {
+	limits DecodeLimits
+	depth int
+	alloc uint64
+}
+
+func NewLimiter(limits DecodeLimits) *Limiter {
+	return &Limiter{
+		limits: limits,
+	}
+}
+
+func (l *Limiter) Reset() {
+	l.depth = 0
+	l.alloc = 0
+}
+
+// Called when a decode function starts. Every call must be followed by a call to Exit
+func (l *Limiter) Enter() error {
+	if l == nil { return nil }
+	l.depth++
+	if l.limits.MaxDepth > 0 && l.depth > l.limits.MaxDepth {
+		return fmt.Errorf("%w: depth is more than %d", ErrLimitExceeded, l.limits.MaxDepth)
+	}
+	return nil
+}
+
+// Called when a decode function returns
+func (l *Limiter) Exit() {
+	if l == nil { return }
+	l.depth--
+}
+
+func (l *Limiter) allocate(length uint64, size uintptr) error {
+	if l.limits.MaxAlloc <= 0 { return nil }
+
+	// Note: compared this way so that a huge length can't overflow
+	remaining := uint64(0)
+	if l.alloc < uint64(l.limits.MaxAlloc) {
+		remaining = uint64(l.limits.MaxAlloc) - l.alloc
+	}
+	if size > 0 && length > remaining / uint64(size) {
+		return fmt.Errorf("%w: allocation is more than %d bytes", ErrLimitExceeded, l.limits.MaxAlloc)
+	}
+	l.alloc += length * uint64(size)
+	return nil
+}
+
+// Checks the length of a slice of T before it is decoded
+func LimitSlice[T any](l *Limiter, length uint64) error {
+	if l == nil { return nil }
+	if l.limits.MaxSliceLen > 0 && length > uint64(l.limits.MaxSliceLen) {
+		return fmt.Errorf("%w: slice length %d is more than %d", ErrLimitExceeded, length, l.limits.MaxSliceLen)
+	}
+	var v T
+	return l.allocate(length, unsafe.Sizeof(v))
+}
+
+// Checks the length of a map of K to V before it is decoded
+func LimitMap[K comparable, V any](l *Limiter, length uint64) error {
+	if l == nil { return nil }
+	if l.limits.MaxMapLen > 0 && length > uint64(l.limits.MaxMapLen) {
+		return fmt.Errorf("%w: map length %d is more than %d", ErrLimitExceeded, length, l.limits.MaxMapLen)
+	}
+	var k K
+	var v V
+	return l.allocate(length, unsafe.Sizeof(k) + unsafe.Sizeof(v))
+}
+
+func (l *Limiter) limitString(length uint64) error {
+	if l == nil { return nil }
+	if l.limits.MaxStringLen > 0 && length > uint64(l.limits.MaxStringLen) {
+		return fmt.Errorf("%w: string length %d is more than %d", ErrLimitExceeded, length, l.limits.MaxStringLen)
+	}
+	return l.allocate(length, 1)
+}
+
+// Reads a string, checking its length against the limits before it is allocated
+func ReadStringLimited(bs []byte, l *Limiter) (string, int, error) {
+	length, _, err := ReadVarUint64(bs)
+	if err != nil { return "", 0, err }
+	err = l.limitString(length)
+	if err != nil { return "", 0, err }
+	return ReadString(bs)
+}
@@ -0,0 +1,117 @@
+package backend
+
+import (
+	"errors"
+	"io"
+)
+
+const defaultStreamSize = 4096
+
+// A Writer buffers encoded data before writing it to an io.Writer, similar to a bufio.Writer.
+// Flush must be called after the last write
+type Writer struct {
+	w io.Writer
+	buf []byte
+	size int // The buffered size that triggers a flush
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return NewWriterSize(w, defaultStreamSize)
+}
+
+func NewWriterSize(w io.Writer, size int) *Writer {
+	return &Writer{
+		w: w,
+		buf: make([]byte, 0, size),
+		size: size,
+	}
+}
+
+// Appends the data from the encode func to the buffer. Used by the generated EncodeCodTo functions
+func (w *Writer) Encode(encode func([]byte) []byte) error {
+	w.buf = encode(w.buf)
+	if len(w.buf) < w.size { return nil }
+	return w.Flush()
+}
+
+// Writes all of the buffered data to the underlying io.Writer
+func (w *Writer) Flush() error {
+	if len(w.buf) == 0 { return nil }
+	_, err := w.w.Write(w.buf)
+	w.buf = w.buf[:0]
+	return err
+}
+
+// A Reader buffers data from an io.Reader so that values can be decoded one at a time, similar to a bufio.Reader.
+// Only the value that is currently being decoded needs to fit in the buffer, which grows as needed
+type Reader struct {
+	r io.Reader
+	buf []byte
+	start, end int // The unread data is buf[start:end]
+	err error // The error returned by the last read of the io.Reader
+}
+
+func NewReader(r io.Reader) *Reader {
+	return NewReaderSize(r, defaultStreamSize)
+}
+
+func NewReaderSize(r io.Reader, size int) *Reader {
+	return &Reader{
+		r: r,
+		buf: make([]byte, size),
+	}
+}
+
+// Decodes the next value with the decode func. Used by the generated DecodeCodFrom functions.
+// If the buffered data is truncated, then more data is read and the decode is retried from the start of the value.
+// The buffered data is at least doubled before each retry, so that decoding a large value only costs a few retries.
+// Returns io.EOF if the stream ended cleanly before the value, or io.ErrUnexpectedEOF if it ended in the middle of the value
+func (r *Reader) Decode(decode func([]byte) (int, error)) error {
+	for {
+		n, err := decode(r.buf[r.start:r.end])
+		if err == nil {
+			r.start += n
+			return nil
+		}
+		if !errors.Is(err, ErrTruncatedData) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return err
+		}
+
+		if r.err != nil {
+			if r.err != io.EOF { return r.err }
+			if r.start == r.end { return io.EOF }
+			return io.ErrUnexpectedEOF
+		}
+
+		want := 2 * (r.end - r.start) + 1
+		for r.err == nil && r.end - r.start < want {
+			r.fill()
+		}
+	}
+}
+
+// Reads more data into the buffer, growing the buffer if it is full
+func (r *Reader) fill() {
+	if r.start > 0 {
+		copy(r.buf, r.buf[r.start:r.end])
+		r.end -= r.start
+		r.start = 0
+	}
+	if r.end == len(r.buf) {
+		newBuf := make([]byte, 2 * len(r.buf) + 1)
+		copy(newBuf, r.buf[:r.end])
+		r.buf = newBuf
+	}
+
+	// Note: Retry empty reads so that each fill makes progress
+	for i := 0; i < 100; i++ {
+		n, err := r.r.Read(r.buf[r.end:])
+		r.end += n
+		if err != nil {
+			r.err = err
+			return
+		}
+		if n > 0 { return }
+	}
+	r.err = io.ErrNoProgress
+}
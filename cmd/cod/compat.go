@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/unitoftime/cod/gen"
+)
+
+// The layout of a package's tagged types when it was last recorded with cod compat -record
+const snapshotFileName = "cod_layout.json"
+
+// Runs the compat subcommand, and returns the exit code
+func runCompat(args []string) int {
+	flags := flag.NewFlagSet("compat", flag.ExitOnError)
+	record := flags.Bool("record", false, "record the current layout of each package to "+snapshotFileName+" instead of comparing")
+	old := flags.String("old", "", "compare against a checked out copy of the old sources in this directory, instead of the recorded layout. Packages are found at the same relative paths")
+	skip := flags.String("skip", ".git,.github", "directories to match and skip in ... patterns")
+	tags := flags.String("tags", "", "comma separated list of build tags to use when selecting files")
+	strict := flags.Bool("strict", false, "also fail if any change is forward-only, which means that the old version can fail to read data written by the new version")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: cod compat [flags] [packages]")
+		fmt.Fprintln(flags.Output(), "Compares the wire layout of each package's tagged types against the layout recorded in "+snapshotFileName+" (or the old sources), and fails if any change is breaking (or forward-only, with -strict)")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+	cfg := gen.Config{
+		Tags: splitList(*tags),
+	}
+
+	dirs, diags := expandPatterns(patterns, skipSet(*skip))
+	failLevel := gen.Breaking
+	if *strict {
+		failLevel = gen.ForwardOnly
+	}
+
+	breaking := false
+	for _, dir := range dirs {
+		schema, pkgDiags, err := gen.LoadSchema(dir, cfg)
+		if err != nil {
+			diags = append(diags, gen.Diagnostic{Pos: token.Position{Filename: dir}, Severity: gen.SeverityError, Msg: err.Error()})
+			continue
+		}
+		diags = append(diags, pkgDiags...)
+		if gen.HasErrors(pkgDiags) { continue }
+
+		snapshotFile := filepath.Join(dir, snapshotFileName)
+		if *record {
+			if len(schema.Types) == 0 { continue } // Skip: nothing to record
+			err := writeSnapshot(snapshotFile, schema)
+			if err != nil {
+				diags = append(diags, gen.Diagnostic{Pos: token.Position{Filename: snapshotFile}, Severity: gen.SeverityError, Msg: err.Error()})
+			}
+			continue
+		}
+
+		var oldSchema gen.Schema
+		if *old != "" {
+			oldSchema, pkgDiags, err = gen.LoadSchema(filepath.Join(*old, dir), cfg)
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				diags = append(diags, gen.Diagnostic{Pos: token.Position{Filename: filepath.Join(*old, dir)}, Severity: gen.SeverityError, Msg: err.Error()})
+				continue
+			}
+			diags = append(diags, pkgDiags...)
+			if gen.HasErrors(pkgDiags) { continue }
+		} else {
+			if len(schema.Types) == 0 { continue } // Skip: no tagged types
+			oldSchema, err = readSnapshot(snapshotFile)
+			if errors.Is(err, fs.ErrNotExist) {
+				diags = append(diags, gen.Diagnostic{Pos: token.Position{Filename: snapshotFile}, Severity: gen.SeverityWarning, Msg: "no recorded layout to compare against, record one with cod compat -record"})
+				continue
+			}
+			if err != nil {
+				diags = append(diags, gen.Diagnostic{Pos: token.Position{Filename: snapshotFile}, Severity: gen.SeverityError, Msg: err.Error()})
+				continue
+			}
+		}
+
+		changes := gen.CompareSchemas(oldSchema, schema)
+		for _, c := range changes {
+			fmt.Printf("%s: %s\n", dir, c)
+		}
+		if gen.HasChanges(changes, failLevel) {
+			breaking = true
+		}
+	}
+
+	printDiagnostics(os.Stderr, diags)
+	if breaking || gen.HasErrors(diags) {
+		return 1
+	}
+	return 0
+}
+
+func writeSnapshot(filename string, schema gen.Schema) error {
+	file, err := json.MarshalIndent(schema, "", "\t")
+	if err != nil { return err }
+	return os.WriteFile(filename, append(file, '\n'), fs.ModePerm)
+}
+
+func readSnapshot(filename string) (gen.Schema, error) {
+	var schema gen.Schema
+	file, err := os.ReadFile(filename)
+	if err != nil { return schema, err }
+	err = json.Unmarshal(file, &schema)
+	if err != nil { return schema, fmt.Errorf("invalid layout: %w", err) }
+	return schema, nil
+}
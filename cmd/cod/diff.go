@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Line diffs for the -check and -diff modes. Uses the Myers algorithm, and prints unified diffs
+
+const diffContext = 3 // The number of unchanged lines shown around each change
+const maxDiffEdits = 1000 // Past this many edits, the rest of the changed lines are shown as one replacement
+
+type diffLine struct {
+	Op byte // ' ' for unchanged, '-' for removed, '+' for added
+	Text string
+}
+
+func splitLines(bs []byte) []string {
+	if len(bs) == 0 { return nil }
+	lines := strings.SplitAfter(string(bs), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func diffLines(a, b []string) []diffLine {
+	// Only the middle of the file needs to be diffed, so trim the unchanged start and end
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+
+	lines := make([]diffLine, 0, len(a)+len(b))
+	for _, l := range a[:pre] {
+		lines = append(lines, diffLine{' ', l})
+	}
+	lines = append(lines, myersDiff(a[pre:len(a)-suf], b[pre:len(b)-suf])...)
+	for _, l := range a[len(a)-suf:] {
+		lines = append(lines, diffLine{' ', l})
+	}
+	return lines
+}
+
+// Finds the shortest edit script from a to b. Each step d stores the furthest x reached on every diagonal k = x - y in [-d-1, d+1]
+func myersDiff(a, b []string) []diffLine {
+	n, m := len(a), len(b)
+	get := func(v []int, d, k int) int { return v[k+d+1] }
+
+	trace := make([][]int, 0)
+	v := make([]int, 3) // The diagonals of step 0
+	for d := 0; d <= n+m; d++ {
+		if d > maxDiffEdits {
+			return replaceLines(a, b)
+		}
+		trace = append(trace, v)
+
+		next := make([]int, 2*(d+1)+1)
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && get(v, d, k-1) < get(v, d, k+1)) {
+				x = get(v, d, k+1) // Down: insert from b
+			} else {
+				x = get(v, d, k-1) + 1 // Right: remove from a
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			next[k+d+2] = x
+
+			if x >= n && y >= m {
+				return backtrackDiff(a, b, trace, x, y)
+			}
+		}
+		v = next
+	}
+	return replaceLines(a, b) // Unreachable: n+m edits always reach the end
+}
+
+func backtrackDiff(a, b []string, trace [][]int, x, y int) []diffLine {
+	get := func(v []int, d, k int) int { return v[k+d+1] }
+
+	reversed := make([]diffLine, 0, len(a)+len(b))
+	for d := len(trace)-1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		prevK := k - 1
+		if k == -d || (k != d && get(v, d, k-1) < get(v, d, k+1)) {
+			prevK = k + 1
+		}
+		prevX := get(v, d, prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			reversed = append(reversed, diffLine{' ', a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				reversed = append(reversed, diffLine{'+', b[y-1]})
+			} else {
+				reversed = append(reversed, diffLine{'-', a[x-1]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	lines := make([]diffLine, len(reversed))
+	for i := range reversed {
+		lines[i] = reversed[len(reversed)-1-i]
+	}
+	return lines
+}
+
+func replaceLines(a, b []string) []diffLine {
+	lines := make([]diffLine, 0, len(a)+len(b))
+	for _, l := range a {
+		lines = append(lines, diffLine{'-', l})
+	}
+	for _, l := range b {
+		lines = append(lines, diffLine{'+', l})
+	}
+	return lines
+}
+
+// Writes a unified diff from the old file to the new file. Nothing is written if they match
+func writeUnifiedDiff(w io.Writer, oldName, newName string, oldFile, newFile []byte) {
+	lines := diffLines(splitLines(oldFile), splitLines(newFile))
+
+	// The line number in each file before each diff line
+	aLine := make([]int, len(lines)+1)
+	bLine := make([]int, len(lines)+1)
+	changes := make([]int, 0)
+	for i, l := range lines {
+		aLine[i+1], bLine[i+1] = aLine[i], bLine[i]
+		if l.Op != '+' { aLine[i+1]++ }
+		if l.Op != '-' { bLine[i+1]++ }
+		if l.Op != ' ' { changes = append(changes, i) }
+	}
+	if len(changes) == 0 { return }
+
+	fmt.Fprintf(w, "--- %s\n+++ %s\n", oldName, newName)
+	for i := 0; i < len(changes); {
+		// Changes that are close together share a hunk
+		last := i
+		for last+1 < len(changes) && changes[last+1]-changes[last] <= 2*diffContext {
+			last++
+		}
+		start := max(changes[i]-diffContext, 0)
+		end := min(changes[last]+diffContext+1, len(lines))
+
+		fmt.Fprintf(w, "@@ -%s +%s @@\n", hunkRange(aLine[start], aLine[end]), hunkRange(bLine[start], bLine[end]))
+		for _, l := range lines[start:end] {
+			text := l.Text
+			if !strings.HasSuffix(text, "\n") {
+				text += "\n\\ No newline at end of file\n"
+			}
+			fmt.Fprintf(w, "%c%s", l.Op, text)
+		}
+		i = last + 1
+	}
+}
+
+// Formats the lines from start to end (exclusive, 0 indexed) as a unified diff range
+func hunkRange(start, end int) string {
+	if start == end {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if end-start == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, end-start)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteUnifiedDiff(t *testing.T) {
+	tests := []struct{
+		name string
+		old, new string
+		expected string
+	}{
+		{"empty", "", "", ""},
+		{"unchanged", "a\nb\n", "a\nb\n", ""},
+		{"insert only", "", "a\nb\n", "--- old\n+++ new\n@@ -0,0 +1,2 @@\n+a\n+b\n"},
+		{"delete only", "a\nb\n", "", "--- old\n+++ new\n@@ -1,2 +0,0 @@\n-a\n-b\n"},
+		{"insert in the middle", "a\nb\nc\n", "a\nb\nx\nc\n", "--- old\n+++ new\n@@ -1,3 +1,4 @@\n a\n b\n+x\n c\n"},
+		{"replace", "a\nb\nc\n", "a\nx\nc\n", "--- old\n+++ new\n@@ -1,3 +1,3 @@\n a\n-b\n+x\n c\n"},
+		{"no trailing newline", "a\nb", "a\nb\n", "--- old\n+++ new\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n"},
+		{
+			"separate hunks",
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n",
+			"0\n1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n",
+			"--- old\n+++ new\n@@ -1,3 +1,4 @@\n+0\n 1\n 2\n 3\n@@ -8,3 +9,4 @@\n 8\n 9\n 10\n+11\n",
+		},
+	}
+
+	for _, test := range tests {
+		buf := new(bytes.Buffer)
+		writeUnifiedDiff(buf, "old", "new", []byte(test.old), []byte(test.new))
+		if buf.String() != test.expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", test.name, test.expected, buf.String())
+		}
+	}
+}
+
+func TestDiffLinesIsMinimal(t *testing.T) {
+	a := splitLines([]byte("a\nb\nc\na\nb\nb\na\n"))
+	b := splitLines([]byte("c\nb\na\nb\na\nc\n"))
+
+	edits := 0
+	for _, l := range diffLines(a, b) {
+		if l.Op != ' ' { edits++ }
+	}
+	// The shortest edit script from the Myers paper
+	if edits != 5 {
+		t.Errorf("expected 5 edits, got %d", edits)
+	}
+}
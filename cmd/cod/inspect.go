@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/unitoftime/cod/gen"
+)
+
+// Runs the inspect subcommand, and returns the exit code
+func runInspect(args []string) int {
+	flags := flag.NewFlagSet("inspect", flag.ExitOnError)
+	typeName := flags.String("type", "", "the type to decode as pkg.Type, where pkg is a package directory (ie ./net) or the name of a package under the current directory. Types in the current directory don't need a package")
+	format := flags.String("format", "auto", "the format of the input: raw, hex, base64, or auto to detect hex and base64 text that has whitespace or a trailing newline")
+	tags := flags.String("tags", "", "comma separated list of build tags to use when selecting files")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: cod inspect -type pkg.Type [flags] [file]")
+		fmt.Fprintln(flags.Output(), "Decodes the file (or stdin) as the type, and prints the offset, bytes, path and value of every field")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if *typeName == "" || flags.NArg() > 1 {
+		flags.Usage()
+		return 2
+	}
+
+	var input []byte
+	var err error
+	if flags.NArg() == 0 || flags.Arg(0) == "-" {
+		input, err = io.ReadAll(os.Stdin)
+	} else {
+		input, err = os.ReadFile(flags.Arg(0))
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	data, err := decodeInput(input, *format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	dir, name, err := findTypePackage(*typeName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	lines, n, err := gen.Inspect(dir, name, data, gen.Config{Tags: splitList(*tags)})
+	printInspectLines(os.Stdout, lines)
+
+	var inspectErr *gen.InspectError
+	if errors.As(err, &inspectErr) {
+		fmt.Printf("%04x  error: %s: %v\n", inspectErr.Offset, inspectErr.Path, inspectErr.Err)
+		fmt.Printf("      remaining bytes: %s\n", formatBytes(data[inspectErr.Offset:], 16))
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if n < len(data) {
+		fmt.Printf("%04x  %d trailing bytes: %s\n", n, len(data) - n, formatBytes(data[n:], 16))
+	}
+	return 0
+}
+
+// Decodes hex or base64 input. In auto mode, only input that is clearly text (all printable, with whitespace or a trailing newline) is decoded.
+// Raw data can be all printable too (ie a string field that only holds hex digits), so text without whitespace must be decoded with -format
+func decodeInput(input []byte, format string) ([]byte, error) {
+	text := strings.Join(strings.Fields(string(input)), "")
+	switch format {
+	case "raw":
+		return input, nil
+	case "hex":
+		return hex.DecodeString(text)
+	case "base64":
+		return decodeBase64(text)
+	case "auto":
+		if !isText(input) || !bytes.ContainsAny(input, " \t\r\n") { return input, nil }
+		if data, err := hex.DecodeString(text); err == nil { return data, nil }
+		if data, err := decodeBase64(text); err == nil { return data, nil }
+		return input, nil
+	}
+	return nil, fmt.Errorf("unknown format %s: it must be raw, hex, base64 or auto", format)
+}
+
+func decodeBase64(text string) ([]byte, error) {
+	var err error
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		var data []byte
+		data, err = enc.DecodeString(text)
+		if err == nil { return data, nil }
+	}
+	return nil, err
+}
+
+func isText(input []byte) bool {
+	if len(bytes.TrimSpace(input)) == 0 { return false }
+	for _, b := range input {
+		if (b < ' ' || b > '~') && b != '\n' && b != '\r' && b != '\t' { return false }
+	}
+	return true
+}
+
+// Returns the directory of the package and the type name within it. The package is either a directory, or the name of a package under the current directory
+func findTypePackage(typeName string) (string, string, error) {
+	// Note: A generic type can have qualified type arguments (ie pkg.Pool[pkg.Vec])
+	base, _, _ := strings.Cut(typeName, "[")
+	dot := strings.LastIndex(base, ".")
+	if dot < 0 {
+		return ".", typeName, nil
+	}
+	pkgName, name := typeName[:dot], typeName[dot+1:]
+
+	info, err := os.Stat(pkgName)
+	if err == nil && info.IsDir() {
+		return pkgName, name, nil
+	}
+
+	dirs, _ := expandPatterns([]string{"./..."}, skipSet(".git,.github"))
+	matches := make([]string, 0)
+	for _, dir := range dirs {
+		packages, err := parser.ParseDir(token.NewFileSet(), dir, func(fi fs.FileInfo) bool {
+			return !strings.HasSuffix(fi.Name(), "_test.go")
+		}, parser.PackageClauseOnly)
+		if err != nil { continue }
+		if _, ok := packages[pkgName]; ok {
+			matches = append(matches, dir)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", "", fmt.Errorf("couldn't find package %s under the current directory", pkgName)
+	case 1:
+		return matches[0], name, nil
+	}
+	return "", "", fmt.Errorf("package %s is ambiguous, pass its directory instead: %s", pkgName, strings.Join(matches, ", "))
+}
+
+func printInspectLines(w io.Writer, lines []gen.InspectLine) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "offset\tbytes\tpath\ttype\tvalue")
+	for _, l := range lines {
+		fmt.Fprintf(tw, "%04x\t%s\t%s%s\t%s\t%s\n", l.Offset, formatBytes(l.Bytes, 8), strings.Repeat("  ", l.Depth), l.Path, formatType(l), l.Value)
+	}
+	tw.Flush()
+}
+
+// Shows the encoding next to the Go type if they don't have the same name (ie Tick as varuint32)
+func formatType(l gen.InspectLine) string {
+	switch {
+	case l.Type == "":
+		return l.Kind
+	case l.Type == l.Kind:
+		return l.Type
+	}
+	switch l.Kind {
+	case "struct", "evolvable", "union", "slice", "array", "map", "pointer", "packedbools":
+		return l.Type
+	}
+	return l.Type + " as " + l.Kind
+}
+
+func formatBytes(bs []byte, max int) string {
+	more := ""
+	if len(bs) > max {
+		bs = bs[:max]
+		more = " ..."
+	}
+	parts := make([]string, 0, len(bs))
+	for _, b := range bs {
+		parts = append(parts, fmt.Sprintf("%02x", b))
+	}
+	return strings.Join(parts, " ") + more
+}
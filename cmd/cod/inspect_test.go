@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDecodeInput(t *testing.T) {
+	tests := []struct{
+		name string
+		input string
+		format string
+		want []byte
+	}{
+		{"hex line", "0a0b0c\n", "auto", []byte{10, 11, 12}},
+		{"hex with spaces", "0a 0b 0c", "auto", []byte{10, 11, 12}},
+		{"base64 line", "AQID\n", "auto", []byte{1, 2, 3}},
+		// Raw data that only holds hex digits (ie an encoded string field) isn't clearly text
+		{"raw hex digits", "\x04beef", "auto", []byte("\x04beef")},
+		{"raw printable", "cafe", "auto", []byte("cafe")},
+		{"raw bytes", "\x00\x01\n", "auto", []byte("\x00\x01\n")},
+		{"not encoded", "hello, world!\n", "auto", []byte("hello, world!\n")},
+		{"explicit hex", "cafe", "hex", []byte{0xca, 0xfe}},
+		{"explicit base64", "AQID", "base64", []byte{1, 2, 3}},
+		{"explicit raw", "0a0b\n", "raw", []byte("0a0b\n")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := decodeInput([]byte(test.input), test.format)
+			if err != nil { t.Fatal(err) }
+			if !bytes.Equal(got, test.want) {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+
+	_, err := decodeInput([]byte("xyz"), "hex")
+	if err == nil {
+		t.Error("expected an error for invalid hex")
+	}
+	_, err = decodeInput(nil, "binary")
+	if err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/token"
+	"hash/crc32"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/unitoftime/cod/gen"
+)
+
+var skip = flag.String("skip", ".git,.github", "directories to match and skip in ... patterns")
+var tags = flag.String("tags", "", "comma separated list of build tags to use when selecting files")
+var verbose = flag.Bool("v", false, "print more output")
+var fuzz = flag.Bool("fuzz", false, "also generate a fuzz test for every codec type")
+var schema = flag.Bool("schema", false, "also write the wire layout of every tagged type to cod_schema.json")
+var check = flag.Bool("check", false, "don't write anything, print a diff of every generated file that is out of date and fail if there are any")
+var diff = flag.Bool("diff", false, "don't write anything, just print a diff of every generated file that would change (a dry run)")
+
+func main() {
+	now := time.Now()
+
+	// Subcommands. A package directory with the same name must be passed as ./compat or ./inspect
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "compat":
+			os.Exit(runCompat(os.Args[2:]))
+		case "inspect":
+			os.Exit(runInspect(os.Args[2:]))
+		}
+	}
+
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: cod [flags] [packages]")
+		fmt.Fprintln(flag.CommandLine.Output(), "       cod compat [flags] [packages]")
+		fmt.Fprintln(flag.CommandLine.Output(), "       cod inspect -type pkg.Type [flags] [file]")
+		fmt.Fprintln(flag.CommandLine.Output(), "Packages are directories (ie . or ./net), or directories followed by /... to include all subdirectories. The default is the current directory")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	cfg := gen.Config{
+		Fuzz: *fuzz,
+		Schema: *schema,
+		Tags: splitList(*tags),
+	}
+
+	diags := generateAll(patterns, skipSet(*skip), cfg)
+	printDuration("cod generate time", now)
+
+	printDiagnostics(os.Stderr, diags)
+	if gen.HasErrors(diags) {
+		os.Exit(1)
+	}
+}
+
+// Generates every package matched by the patterns. Packages with errors are reported, and the rest are still generated
+func generateAll(patterns []string, skipMap map[string]struct{}, cfg gen.Config) []gen.Diagnostic {
+	dirs, diags := expandPatterns(patterns, skipMap)
+	for _, dir := range dirs {
+		if *verbose {
+			fmt.Println("Parsing Directory:", dir)
+		}
+
+		files, pkgDiags, err := gen.Generate(dir, cfg)
+		if err != nil {
+			diags = append(diags, gen.Diagnostic{Pos: token.Position{Filename: dir}, Severity: gen.SeverityError, Msg: err.Error()})
+			continue
+		}
+		diags = append(diags, pkgDiags...)
+
+		filenames := make([]string, 0, len(files))
+		for filename := range files {
+			filenames = append(filenames, filename)
+		}
+		sort.Strings(filenames)
+		for _, filename := range filenames {
+			diags = append(diags, outputFile(filename, files[filename])...)
+		}
+
+		// Note: A package with errors doesn't return any files, so its files aren't stale
+		if gen.HasErrors(pkgDiags) { continue }
+		for _, filename := range gen.GeneratedFiles(dir, cfg) {
+			if _, ok := files[filename]; ok { continue }
+			diags = append(diags, removeStaleFile(filename)...)
+		}
+	}
+	return diags
+}
+
+func outputFile(filename string, formatted []byte) []gen.Diagnostic {
+	// Check to see if the file will change
+	oldFile, err := os.ReadFile(filename)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		fmt.Println("Error reading last cod file:", err)
+	}
+
+	oldSum := crc32.ChecksumIEEE(oldFile)
+	newSum := crc32.ChecksumIEEE(formatted)
+	if oldSum == newSum {
+		// fmt.Println("Skipping Write: Files match")
+		return nil
+	}
+
+	// In check and diff modes, nothing is written
+	if *check || *diff {
+		oldName := filename
+		if oldFile == nil {
+			oldName = "/dev/null" // The file is missing
+		}
+		writeUnifiedDiff(os.Stdout, oldName, filename, oldFile, formatted)
+		if *check {
+			return []gen.Diagnostic{{Pos: token.Position{Filename: filename}, Severity: gen.SeverityError, Msg: "generated file is out of date, run cod to update it"}}
+		}
+		return nil
+	}
+
+	err = os.WriteFile(filename, formatted, fs.ModePerm)
+	if err != nil {
+		return []gen.Diagnostic{{Pos: token.Position{Filename: filename}, Severity: gen.SeverityError, Msg: err.Error()}}
+	}
+	return nil
+}
+
+// Removes a generated file that the package no longer needs. In check and diff modes, the removal is printed as a diff instead.
+// Go files that don't have the generated header weren't written by cod, so they are left alone
+func removeStaleFile(filename string) []gen.Diagnostic {
+	oldFile, err := os.ReadFile(filename)
+	if err != nil { return nil } // Skip: there is nothing to remove
+	if strings.HasSuffix(filename, ".go") && !bytes.HasPrefix(oldFile, []byte(gen.GeneratedHeader)) {
+		return nil
+	}
+
+	if *check || *diff {
+		writeUnifiedDiff(os.Stdout, filename, "/dev/null", oldFile, nil)
+		if *check {
+			return []gen.Diagnostic{{Pos: token.Position{Filename: filename}, Severity: gen.SeverityError, Msg: "generated file is stale, run cod to remove it"}}
+		}
+		return nil
+	}
+
+	err = os.Remove(filename)
+	if err != nil {
+		return []gen.Diagnostic{{Pos: token.Position{Filename: filename}, Severity: gen.SeverityError, Msg: err.Error()}}
+	}
+	return nil
+}
+
+// Splits a comma separated flag value
+func splitList(list string) []string {
+	if list == "" { return nil }
+	ret := make([]string, 0)
+	for _, item := range strings.Split(list, ",") {
+		ret = append(ret, strings.TrimSpace(item))
+	}
+	return ret
+}
+
+func skipSet(list string) map[string]struct{} {
+	skipMap := make(map[string]struct{})
+	for _, name := range splitList(list) {
+		skipMap[name] = struct{}{}
+	}
+	return skipMap
+}
+
+func printDiagnostics(w io.Writer, diags []gen.Diagnostic) {
+	sort.SliceStable(diags, func(i, j int) bool {
+		a, b := diags[i].Pos, diags[j].Pos
+		if a.Filename != b.Filename { return a.Filename < b.Filename }
+		if a.Line != b.Line { return a.Line < b.Line }
+		return a.Column < b.Column
+	})
+	for _, d := range diags {
+		fmt.Fprintln(w, d)
+	}
+}
+
+func printDuration(name string, t time.Time) {
+	fmt.Printf("%s: %v\n", name, time.Since(t))
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/unitoftime/cod/gen"
+)
+
+func TestStaleGeneratedFile(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "plain.go"), []byte("package plain\n\ntype Plain struct{}\n"), 0644)
+	if err != nil { panic(err) }
+
+	// The package used to have tagged types, but doesn't anymore
+	stale := filepath.Join(dir, "cod_gen.go")
+	err = os.WriteFile(stale, []byte(gen.GeneratedHeader + "\npackage plain\n\nfunc (t Plain) EncodeCod(bs []byte) []byte { return bs }\n"), 0644)
+	if err != nil { panic(err) }
+
+	*check = true
+	diags := generateAll([]string{dir}, nil, gen.Config{})
+	*check = false
+	if len(diags) != 1 || diags[0].Pos.Filename != stale {
+		t.Fatalf("expected the stale file to be reported, got %v", diags)
+	}
+	if _, err := os.Stat(stale); err != nil {
+		t.Fatalf("check mode must not remove the stale file: %v", err)
+	}
+
+	diags = generateAll([]string{dir}, nil, gen.Config{})
+	if len(diags) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("expected the stale file to be removed, got %v", err)
+	}
+}
+
+func TestHandWrittenFileIsNotStale(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "cod_gen.go")
+	err := os.WriteFile(file, []byte("package plain\n"), 0644)
+	if err != nil { panic(err) }
+
+	*check = true
+	diags := generateAll([]string{dir}, nil, gen.Config{})
+	*check = false
+	if len(diags) != 0 {
+		t.Errorf("a file without the generated header must be left alone, got %v", diags)
+	}
+}
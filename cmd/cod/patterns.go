@@ -0,0 +1,61 @@
+package main
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/unitoftime/cod/gen"
+)
+
+// Expands go style package patterns to the directories to generate. A pattern is a directory (ie . or ./net), or a directory followed by /... to include all of its subdirectories.
+// Like the go command, testdata, vendor and directories starting with . or _ are left out of /... patterns
+func expandPatterns(patterns []string, skipMap map[string]struct{}) ([]string, []gen.Diagnostic) {
+	dirs := make([]string, 0)
+	seen := make(map[string]bool)
+	add := func(dir string) {
+		dir = filepath.Clean(dir)
+		if seen[dir] { return }
+		seen[dir] = true
+		dirs = append(dirs, dir)
+	}
+
+	diags := make([]gen.Diagnostic, 0)
+	for _, pattern := range patterns {
+		root, recursive := strings.CutSuffix(pattern, "...")
+		if recursive {
+			root = strings.TrimSuffix(root, "/")
+			if root == "" { root = "." }
+		}
+		if strings.Contains(root, "...") {
+			diags = append(diags, gen.Diagnostic{Severity: gen.SeverityError, Msg: "unsupported package pattern: " + pattern + ": ... is only supported at the end"})
+			continue
+		}
+
+		info, err := os.Stat(root)
+		if err != nil || !info.IsDir() {
+			diags = append(diags, gen.Diagnostic{Severity: gen.SeverityError, Msg: "couldn't find directory for package pattern: " + pattern})
+			continue
+		}
+
+		if !recursive {
+			add(root)
+			continue
+		}
+
+		filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || !d.IsDir() { return nil }
+			if path != root && skipDir(d.Name(), skipMap) { return filepath.SkipDir }
+			add(path)
+			return nil
+		})
+	}
+	return dirs, diags
+}
+
+func skipDir(name string, skipMap map[string]struct{}) bool {
+	if _, skip := skipMap[name]; skip { return true }
+	if name == "testdata" || name == "vendor" { return true }
+	return strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
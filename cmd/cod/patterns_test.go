@@ -0,0 +1,104 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Creates the directories under a temp root, and returns the root
+func makeTree(t *testing.T, dirs ...string) string {
+	root := t.TempDir()
+	for _, dir := range dirs {
+		err := os.MkdirAll(filepath.Join(root, dir), 0755)
+		if err != nil { panic(err) }
+	}
+	return root
+}
+
+func TestExpandPatterns(t *testing.T) {
+	root := makeTree(t,
+		"a/b",
+		"c",
+		"testdata/x",
+		"vendor/x",
+		"_ignored/x",
+		".hidden/x",
+		"a/testdata",
+		"a/generated",
+	)
+	join := func(dirs ...string) []string {
+		out := make([]string, 0, len(dirs))
+		for _, dir := range dirs {
+			out = append(out, filepath.Join(root, dir))
+		}
+		return out
+	}
+
+	tests := []struct{
+		name string
+		patterns []string
+		skip map[string]struct{}
+		want []string
+	}{
+		{"dir", []string{root + "/c"}, nil, join("c")},
+		{"recursive", []string{root + "/..."}, nil, join(".", "a", "a/b", "a/generated", "c")},
+		{"recursive subdir", []string{root + "/a/..."}, nil, join("a", "a/b", "a/generated")},
+		{"skip map", []string{root + "/..."}, skipSet("generated,c"), join(".", "a", "a/b")},
+		{"duplicates", []string{root + "/a", root + "/a/...", root + "/a/"}, nil, join("a", "a/b", "a/generated")},
+		// An explicit pattern is used even if ... would skip it
+		{"explicit skipped dir", []string{root + "/testdata/..."}, nil, join("testdata", "testdata/x")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dirs, diags := expandPatterns(test.patterns, test.skip)
+			if len(diags) != 0 {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if !reflect.DeepEqual(dirs, test.want) {
+				t.Errorf("expected %v, got %v", test.want, dirs)
+			}
+		})
+	}
+}
+
+func TestExpandPatternsDotDotDot(t *testing.T) {
+	root := makeTree(t, "a/b", "vendor")
+	wd, err := os.Getwd()
+	if err != nil { panic(err) }
+	err = os.Chdir(root)
+	if err != nil { panic(err) }
+	defer os.Chdir(wd)
+
+	dirs, diags := expandPatterns([]string{"./..."}, nil)
+	if len(diags) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	want := []string{".", "a", filepath.Join("a", "b")}
+	if !reflect.DeepEqual(dirs, want) {
+		t.Errorf("expected %v, got %v", want, dirs)
+	}
+}
+
+func TestExpandPatternsErrors(t *testing.T) {
+	root := makeTree(t, "a")
+	err := os.WriteFile(filepath.Join(root, "file.go"), []byte("package a\n"), 0644)
+	if err != nil { panic(err) }
+
+	patterns := []string{
+		root + "/missing",
+		root + "/missing/...",
+		root + "/file.go",
+		root + "/.../a",
+		root + "/a",
+	}
+	dirs, diags := expandPatterns(patterns, nil)
+	if len(diags) != 4 {
+		t.Errorf("expected 4 diagnostics, got %v", diags)
+	}
+	want := []string{filepath.Join(root, "a")}
+	if !reflect.DeepEqual(dirs, want) {
+		t.Errorf("expected %v, got %v", want, dirs)
+	}
+}
@@ -1,12 +1,31 @@
 package cod
 
+import (
+	"github.com/unitoftime/cod/backend"
+)
+
 const (
 	UnionEmpty uint8 = 0
 )
 
 type EncoderDecoder interface {
 	EncodeCod([]byte) []byte
-	// DecodeCod([]byte) (int, error) // Doesn't fit b/c its a pointer receiver
+	// DecodeCod([]byte) (int, error) // Doesn't fit b/c its a pointer receiver. See Decoder and Unmarshal
+}
+
+// The methods that are generated for a tagged type. The type parameters of a tagged generic type must be constrained to it (ie Option[T cod.Codec[T]]), so that its generated methods can call them
+type Codec[T any] interface {
+	EncoderDecoder
+	CodEquals(T) bool
+	CodSize() int
+	CodClone() T
+}
+
+// The decode methods that are generated for a pointer to a tagged type. Each type parameter of a tagged generic type needs a pointer type parameter constrained to it (ie Option[T cod.Codec[T], PT cod.CodecPtr[T]]), so that its generated methods can decode in place
+type CodecPtr[T any] interface {
+	*T
+	Decoder
+	DecodeCodWithLimits([]byte, *backend.Limiter) (int, error)
 }
 
 type Union struct {
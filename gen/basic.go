@@ -1,17 +1,17 @@
-package main
+package gen
 
 import (
 	"text/template"
 )
 
-var BasicTemp *template.Template
+var basicTemp *template.Template
 
 func addTemplate(name string, dat string) {
-	template.Must(BasicTemp.New(name).Parse(dat))
+	template.Must(basicTemp.New(name).Parse(dat))
 }
 
 func init() {
-	BasicTemp = template.New("BasicTemp")
+	basicTemp = template.New("basicTemp")
 
 	// --- Component
 	addTemplate("ecs_component", `
@@ -48,7 +48,11 @@ func (t {{.Name}})CodEquals(tt {{.Name}}) bool {
 `)
 
 	addTemplate("basic_equality", `
+{{- if or (eq .ApiName "Float32") (eq .ApiName "Float64")}}
+   if !backend.EqualFloat({{.Name}}, {{.Name2}}) { return false }
+{{- else}}
    if {{.Name}} != {{.Name2}} { return false }
+{{- end}}
 `)
 	addTemplate("struct_equality", `
 if !{{.Name}}.CodEquals({{.Name2}}) { return false }
@@ -91,6 +95,111 @@ for {{.KeyIdx}}, {{.ValIdx}} := range {{.Name}} {
    {{.InnerCode}}
 }`)
 
+	// --- Clone
+	addTemplate("clone_func", `
+func (t {{.Name}})CodClone() {{.Name}} {
+   var ct {{.Name}}
+{{.InnerCode}}
+   return ct
+}
+`)
+	addTemplate("blank_clone_func", `
+func (t {{.Name}})CodClone() {{.Name}} {
+   return t
+}
+`)
+
+	addTemplate("basic_clone", `
+{{.Name2}} = {{.Name}}`)
+	addTemplate("struct_clone", `
+{{.Name2}} = {{.Name}}.CodClone()`)
+	addTemplate("array_clone", `
+for {{.Index}} := range {{.Name}} {
+   {{.InnerCode}}
+}`)
+	addTemplate("slice_clone", `
+if {{.Name}} != nil {
+   {{.Name2}} = make([]{{.Type}}, len({{.Name}}))
+   for {{.Index}} := range {{.Name}} {
+      {{.InnerCode}}
+   }
+}`)
+	addTemplate("map_clone", `
+if {{.Name}} != nil {
+   {{.Name2}} = make({{.Type}}, len({{.Name}}))
+   for {{.KeyIdx}}, {{.ValIdx}} := range {{.Name}} {
+      var c{{.ValIdx}} {{.ValType}}
+      {{.InnerCode}}
+      {{.Name2}}[{{.KeyIdx}}] = c{{.ValIdx}}
+   }
+}`)
+	addTemplate("pointer_clone", `
+if {{.Name}} != nil {
+   {{.ValName}} := *{{.Name}}
+   var c{{.ValName}} {{.ValType}}
+   {{.InnerCode}}
+   {{.Name2}} = &c{{.ValName}}
+}`)
+	addTemplate("alias_clone", `
+{
+   {{.ValName}} := {{.Type}}({{.Name}})
+   var c{{.ValName}} {{.Type}}
+   {{.InnerCode}}
+   {{.Name2}} = {{.AliasType}}(c{{.ValName}})
+}`)
+
+	// --- Size
+	addTemplate("size_func", `
+func (t {{.Name}})CodSize() int {
+   n := 0
+{{.InnerCode}}
+   return n
+}
+`)
+	addTemplate("blank_size_func", `
+func (t {{.Name}})CodSize() int {
+   return 0
+}
+`)
+
+	addTemplate("basic_size", `
+n += backend.Size{{.ApiName}}({{.Cast}}({{.Name}}))`)
+	addTemplate("struct_size", `
+n += {{.Name}}.CodSize()`)
+	addTemplate("encoded_size", `
+n += len({{.Name}}.EncodeCod(nil))`)
+	addTemplate("array_size", `
+for {{.Index}} := range {{.Name}} {
+   {{.InnerCode}}
+}`)
+	addTemplate("slice_size", `
+{
+n += backend.SizeVarUint64(uint64(len({{.Name}})))
+for {{.Index}} := range {{.Name}} {
+   {{.InnerCode}}
+}
+}`)
+	addTemplate("map_size", `
+{
+n += backend.SizeVarUint64(uint64(len({{.Name}})))
+for {{.KeyIdx}}, {{.ValIdx}} := range {{.Name}} {
+   {{.InnerCode}}
+}
+}`)
+	addTemplate("pointer_size", `
+{
+   n += backend.SizeUint8(0) // The nil tag
+   if {{.Name}} != nil {
+      {{.ValName}} := *{{.Name}}
+      {{.InnerCode}}
+   }
+}`)
+	addTemplate("alias_size", `
+{
+   {{.ValName}} := {{.Type}}({{.Name}})
+   {{.InnerCode}}
+}`)
+
 	//--------------------------------------------------------------------------------
 	// Marshal/Unmarshal Functions
 	addTemplate("marshal_func", `
@@ -102,9 +211,18 @@ return bs
 
 	addTemplate("unmarshal_func", `
 func (t *{{.Name}})DecodeCod(bs []byte) (int, error) {
+   return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *{{.Name}})DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
 var err error
 var n int
 var nOff int
+_ = nOff // Unused if every field is in the bitfield
+
+err = lim.Enter()
+if err != nil { return 0, err }
+defer lim.Exit()
 
 {{.MarshalCode}}
 
@@ -124,6 +242,275 @@ return bs
 func (t *{{.Name}})DecodeCod(bs []byte) (n int, err error) {
 return
 }
+
+func (t *{{.Name}})DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (n int, err error) {
+return
+}
+`)
+
+	// Streaming Functions
+	addTemplate("stream_funcs", `
+func (t {{.Name}})EncodeCodTo(w *backend.Writer) error {
+   return w.Encode(t.EncodeCod)
+}
+
+func (t *{{.Name}})DecodeCodFrom(r *backend.Reader) error {
+   // Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+   return r.Decode(t.DecodeCod)
+}
+`)
+
+	// Fuzz Tests
+	addTemplate("fuzz_test", `
+func Fuzz{{.Name}}Decode(f *testing.F) {
+   f.Add({{.Name}}{}.EncodeCod(nil))
+   f.Fuzz(func(t *testing.T, bs []byte) {
+      var v {{.Name}}
+      n, err := v.DecodeCod(bs)
+      if err != nil { return }
+      if n < 0 || n > len(bs) {
+         t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+      }
+
+      // Anything that decodes must survive a roundtrip
+      reencoded := v.EncodeCod(nil)
+      var v2 {{.Name}}
+      n, err = v2.DecodeCod(reencoded)
+      if err != nil {
+         t.Fatalf("failed to decode re-encoded value: %v", err)
+      }
+      if n != len(reencoded) {
+         t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+      }
+      if !v.CodEquals(v2) {
+         t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+      }
+   })
+}
+`)
+
+	// Delta Functions
+	addTemplate("delta_marshal_func", `
+func (t {{.Name}})EncodeCodDelta(bs []byte, tt {{.Name}}) []byte {
+   // Each bit of the mask is set if that field changed from the base
+   maskStart := len(bs)
+   bs = append(bs, make([]byte, {{.MaskSize}})...)
+{{.MarshalCode}}
+   return bs
+}
+`)
+
+	addTemplate("delta_field_marshal", `
+{{- if .AlwaysChanged}}
+{
+{{- else}}
+if !func() bool {
+   {{.EqualityCode}}
+   return true
+}() {
+{{- end}}
+   bs[maskStart+{{.Byte}}] |= 1 << {{.Bit}}
+   {{.MarshalCode}}
+}
+`)
+
+	addTemplate("delta_unmarshal_func", `
+func (t *{{.Name}})DecodeCodDelta(bs []byte, tt {{.Name}}) (int, error) {
+var err error
+var n int
+var nOff int
+_ = nOff
+var lim *backend.Limiter // Deltas are decoded without limits
+_ = lim
+
+if len(bs) < {{.MaskSize}} { return 0, backend.ErrTruncatedData }
+mask := bs[:{{.MaskSize}}]
+n += {{.MaskSize}}
+
+{{.MarshalCode}}
+
+return n, err
+}
+`)
+
+	addTemplate("delta_field_unmarshal", `
+if mask[{{.Byte}}] & (1 << {{.Bit}}) != 0 {
+   {{.UnmarshalCode}}
+}{{if .CloneCode}} else {
+   // Unchanged, so copy the field from the base
+   ct := t
+   t := &tt
+   {{.CloneCode}}
+}{{end}}
+`)
+
+	addTemplate("struct_delta_marshal", `
+bs = {{.Name}}.EncodeCodDelta(bs, {{.Name2}})`)
+
+	addTemplate("struct_delta_unmarshal", `
+nOff, err = {{.Name}}.DecodeCodDelta(bs[n:], {{.Name2}})
+if err != nil { return 0, err }
+n += nOff
+`)
+
+	addTemplate("full_delta_funcs", `
+func (t {{.Name}})EncodeCodDelta(bs []byte, tt {{.Name}}) []byte {
+   return t.EncodeCod(bs)
+}
+
+func (t *{{.Name}})DecodeCodDelta(bs []byte, tt {{.Name}}) (int, error) {
+   return t.DecodeCod(bs)
+}
+`)
+
+	addTemplate("union_delta_funcs", `
+func (t {{.Name}})EncodeCodDelta(bs []byte, tt {{.Name}}) []byte {
+   tag := t.Tag()
+   if tag == 0 || tag != tt.Tag() {
+      // The type changed, so write the full value
+      return t.EncodeCod(bs)
+   }
+
+   bs = backend.Write{{.TagApi}}(bs, tag)
+   switch sv := t.Get().(type) {
+{{- range .Fields}}
+   case {{.GetType}}:
+{{- if .HasDelta}}
+      bs = sv.EncodeCodDelta(bs, tt.Get().({{.GetType}}))
+{{- else}}
+      bs = sv.EncodeCod(bs)
+{{- end}}
+{{- end}}
+   }
+   return bs
+}
+
+func (t *{{.Name}})DecodeCodDelta(bs []byte, tt {{.Name}}) (int, error) {
+   tagVal, n, err := backend.Read{{.TagApi}}(bs)
+   if err != nil { return 0, err }
+   if tagVal == 0 || tagVal != tt.Tag() {
+      return t.DecodeCod(bs)
+   }
+
+   var nOff int
+   switch tagVal {
+{{- range .Fields}}
+   case {{.UnionTag}}:
+      var decoded {{.GetType}}
+{{- if .HasDelta}}
+      nOff, err = decoded.DecodeCodDelta(bs[n:], tt.Get().({{.GetType}}))
+{{- else}}
+      nOff, err = decoded.DecodeCod(bs[n:])
+{{- end}}
+      if err != nil { return 0, err }
+      t.Set{{.Variant}}(decoded)
+{{- end}}
+   }
+   return n + nOff, nil
+}
+`)
+
+	// Evolvable Marshal/Unmarshal Functions
+	addTemplate("evolvable_marshal_func", `
+func (t {{.Name}})EncodeCod(bs []byte) []byte {
+{{.MarshalCode}}
+bs = backend.WriteVarUint64(bs, 0) // Zero marks the end of the struct
+return bs
+}
+`)
+
+	addTemplate("evolvable_field_marshal", `
+{
+   n := 0
+   {{.SizeCode}}
+   bs = backend.WriteVarUint64(bs, {{.Number}})
+   bs = backend.WriteVarUint64(bs, uint64(n))
+   {{.MarshalCode}}
+}
+`)
+
+	addTemplate("evolvable_unmarshal_func", `
+func (t *{{.Name}})DecodeCod(bs []byte) (int, error) {
+   return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *{{.Name}})DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+var err error
+var n int
+var nOff int
+_ = nOff // Unused if every field is in the bitfield
+
+err = lim.Enter()
+if err != nil { return 0, err }
+defer lim.Exit()
+
+{{- if .ResetCode}}
+// Fields that are missing from the data are reset after the loop. The other fields are decoded in place, so that they reuse their slices and maps
+var seen [{{.NumFields}}]bool
+{{- end}}
+for {
+   var fieldNum uint64
+   fieldNum, nOff, err = backend.ReadVarUint64(bs[n:])
+   if err != nil { return 0, err }
+   n += nOff
+   if fieldNum == 0 {
+      break // Zero marks the end of the struct
+   }
+
+   var length uint64
+   length, nOff, err = backend.ReadVarUint64(bs[n:])
+   if err != nil { return 0, err }
+   n += nOff
+   if length > uint64(len(bs) - n) { return 0, backend.ErrTruncatedData }
+   end := n + int(length)
+
+   switch fieldNum {
+{{.MarshalCode}}
+   }
+   n = end // Skips unknown fields and any unread field data
+}
+{{.ResetCode}}
+
+return n, err
+}
+`)
+
+	addTemplate("evolvable_field_unmarshal", `
+   case {{.Number}}:
+      seen[{{.Index}}] = true
+      bs := bs[:end]
+      {{.MarshalCode}}
+`)
+
+	// Slices and maps are emptied instead of set to nil, so that they keep their memory
+	addTemplate("evolvable_field_reset", `
+if !seen[{{.Index}}] {
+{{- if eq .Kind "slice"}}
+   {{.Name}} = {{.Name}}[:0]
+{{- else if eq .Kind "map"}}
+   clear({{.Name}})
+{{- else}}
+   var zero {{.Type}}
+   {{.Name}} = zero
+{{- end}}
+}`)
+
+	addTemplate("evolvable_size_func", `
+func (t {{.Name}})CodSize() int {
+   n := 0
+{{.InnerCode}}
+   n += backend.SizeVarUint64(0)
+   return n
+}
+`)
+
+	addTemplate("evolvable_field_size", `
+{
+   start := n
+   {{.SizeCode}}
+   fieldSize := n - start
+   n += backend.SizeVarUint64({{.Number}}) + backend.SizeVarUint64(uint64(fieldSize))
+}
 `)
 
 	// Standard Types
@@ -137,7 +524,11 @@ bs = backend.Write{{.ApiName}}(bs, {{.Cast}}({{.Name}}))
 	addTemplate("basic_unmarshal", `
 {
 var decoded {{.Type}}
+{{- if eq .ApiName "String"}}
+decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+{{- else}}
 decoded, nOff, err = backend.Read{{.ApiName}}(bs[n:])
+{{- end}}
 if err != nil { return 0, err }
 n += nOff
 {{.Name}} = {{.Cast}}(decoded)
@@ -145,18 +536,64 @@ n += nOff
 `)
 
 
+	// Bitpacked bools
+	addTemplate("bitfield_marshal", `
+{
+   var bits [{{.Size}}]byte
+{{- range .Bools}}
+   if {{.Name}} { bits[{{.Byte}}] |= 1 << {{.Bit}} }
+{{- end}}
+   bs = append(bs, bits[:]...)
+}
+`)
+
+	addTemplate("bitfield_unmarshal", `
+{
+   if len(bs[n:]) < {{.Size}} { return 0, backend.ErrTruncatedData }
+   bits := bs[n:n+{{.Size}}]
+{{- range .Bools}}
+   {{.Name}} = {{.Type}}(bits[{{.Byte}}] & (1 << {{.Bit}}) != 0)
+{{- end}}
+   n += {{.Size}}
+}
+`)
+
+	addTemplate("bitfield_size", `
+n += {{.Size}}`)
+
+	addTemplate("packed_bools_marshal", `
+bs = backend.WritePackedBools(bs, {{.Name}})
+`)
+
+	addTemplate("packed_bools_unmarshal", `
+{{.Name}}, nOff, err = backend.ReadPackedBools(bs[n:], {{.Name}}, lim)
+if err != nil { return 0, err }
+n += nOff
+`)
+
+	addTemplate("packed_bools_size", `
+n += backend.SizePackedBools({{.Name}})`)
+
 	// Struct
 	addTemplate("struct_marshal", `
 bs = {{.Name}}.EncodeCod(bs)`)
 
+	// Note: Decodes in place so that the slices and maps of the field are reused
 	addTemplate("struct_unmarshal", `
-{
-var decoded {{.Type}}
-nOff, err = decoded.DecodeCod(bs[n:])
+{{- if .Limited}}
+nOff, err = {{.Name}}.DecodeCodWithLimits(bs[n:], lim)
+{{- else}}
+nOff, err = {{.Name}}.DecodeCod(bs[n:])
+{{- end}}
+if err != nil { return 0, err }
+n += nOff
+`)
+
+	// Type parameters of generic structs
+	addTemplate("type_param_unmarshal", `
+nOff, err = {{.Ptr}}(&{{.Name}}).DecodeCodWithLimits(bs[n:], lim)
 if err != nil { return 0, err }
 n += nOff
-{{.Name}} = decoded
-}
 `)
 
 	// TODO: could also unroll the loop here?
@@ -190,15 +627,16 @@ for {{.Index}} := range {{.Name}} {
 	length, nOff, err = backend.ReadVarUint64(bs[n:])
 	if err != nil { return 0, err }
   n += nOff
+	err = backend.LimitSlice[{{.Type}}](lim, length)
+	if err != nil { return 0, err }
+	{{.Name}} = backend.ResetSlice({{.Name}}, length, len(bs[n:]))
 
 for {{.Index}} := 0; {{.Index}} < int(length); {{.Index}}++ {
-   var {{.VarName}} {{.Type}}
+   {{.Name}} = backend.ExtendSlice({{.Name}})
    {{.InnerCode}}
    if err != nil {
       return 0, err
    }
-
-   {{.Name}} = append({{.Name}}, {{.VarName}})
 }
 }`)
 
@@ -212,6 +650,28 @@ for {{.KeyIdx}}, {{.ValIdx}} := range {{.Name}} {
    {{.InnerCode}}
 }
 
+}`)
+
+	addTemplate("map_marshal_sorted", `
+{
+bs = backend.WriteVarUint64(bs, uint64(len({{.Name}})))
+
+for _, {{.KeyIdx}} := range backend.SortedKeys({{.Name}}) {
+   {{.ValIdx}} := {{.Name}}[{{.KeyIdx}}]
+   {{.InnerCode}}
+}
+
+}`)
+
+	addTemplate("map_marshal_encoded", `
+{
+bs = backend.WriteVarUint64(bs, uint64(len({{.Name}})))
+
+bs = backend.AppendSortedEntries(bs, {{.Name}}, func(bs []byte, {{.KeyIdx}} {{.KeyType}}, {{.ValIdx}} {{.ValType}}) []byte {
+   {{.InnerCode}}
+   return bs
+})
+
 }`)
 
 	addTemplate("map_unmarshal", `
@@ -220,9 +680,13 @@ for {{.KeyIdx}}, {{.ValIdx}} := range {{.Name}} {
 	length, nOff, err = backend.ReadVarUint64(bs[n:])
 	if err != nil { return 0, err }
   n += nOff
+	err = backend.LimitMap[{{.KeyType}}, {{.ValType}}](lim, length)
+	if err != nil { return 0, err }
 
 if {{.Name}} == nil {
-{{.Name}} = make({{.Type}})
+{{.Name}} = make({{.Type}}, backend.PreallocLen(length, len(bs[n:])))
+} else {
+clear({{.Name}})
 }
 
 for {{.Index}} := 0; {{.Index}} < int(length); {{.Index}}++ {
@@ -249,7 +713,7 @@ for {{.Index}} := 0; {{.Index}} < int(length); {{.Index}}++ {
 
 	addTemplate("alias_unmarshal", `
 {
-   var {{.ValName}} {{.Type}}
+   {{.ValName}} := {{.Type}}(*{{.Name}})
    {{.InnerCode}}
    *{{.Name}} = {{.AliasType}}({{.ValName}})
 }`)
@@ -257,9 +721,8 @@ for {{.Index}} := 0; {{.Index}} < int(length); {{.Index}}++ {
 	// --------------------------------------------------------------------------------
 	// Union
 	// --------------------------------------------------------------------------------
-	// TODO: Should I do uvarints for tags? 255 is an absolutely massive union...
 	addTemplate("union_get_tag_func", `
-func (t {{.Name}})Tag() uint8 {
+func (t {{.Name}})Tag() {{.TagType}} {
    rawVal := t.Get()
    if rawVal == nil {
       // Zero tag indicates nil
@@ -292,24 +755,65 @@ func (t {{.Name}})CodEquals(tt {{.Name}}) bool {
 
    rawVal := t.Get()
    switch sv := rawVal.(type) {
+   case nil:
+      return true
 {{.InnerCode}}
    default:
       panic(fmt.Sprintf("unknown type placed in union: %T", rawVal))
    }
+}
+`)
+	addTemplate("union_size_func", `
+func (t {{.Name}})CodSize() int {
+   n := backend.Size{{.TagApi}}(t.Tag())
 
-   return true
+   rawVal := t.Get()
+   switch sv := rawVal.(type) {
+{{.InnerCode}}
+   }
+   return n
 }
 `)
+	addTemplate("union_case_size", `
+   case {{.Type}}:
+{{- if .NoSize}}
+      n += len(sv.EncodeCod(nil))
+{{- else}}
+      n += sv.CodSize()
+{{- end}}
+`)
+	addTemplate("union_clone_func", `
+func (t {{.Name}})CodClone() {{.Name}} {
+   var ct {{.Name}}
+
+   rawVal := t.Get()
+   switch sv := rawVal.(type) {
+   case nil:
+{{.InnerCode}}
+   default:
+      panic(fmt.Sprintf("unknown type placed in union: %T", rawVal))
+   }
+   return ct
+}
+`)
+	addTemplate("union_case_clone", `
+   case {{.Type}}:
+{{- if .NoClone}}
+      ct.Set{{.Variant}}(sv)
+{{- else}}
+      ct.Set{{.Variant}}(sv.CodClone())
+{{- end}}
+`)
+
 	addTemplate("union_case_equality", `
    case {{.Type}}:
       sv2 := tt.Get().({{.Type}})
       return sv.CodEquals(sv2)
 `)
 
-	// TODO: Should I do uvarints for tags? 255 is an absolutely massive union...
 	addTemplate("union_marshal", `
    tag := t.Tag()
-   bs = backend.WriteUint8(bs, tag)
+   bs = backend.Write{{.TagApi}}(bs, tag)
    if tag == 0 {
       // Zero tag indicates nil, so write nothing else
       return bs
@@ -317,8 +821,6 @@ func (t {{.Name}})CodEquals(tt {{.Name}}) bool {
 
    rawVal := t.Get()
    bs = rawVal.EncodeCod(bs)
-
-   return bs
 `)
 // Old marshal code. just keeping it around in case
 /*
@@ -340,15 +842,16 @@ func (t {{.Name}})CodEquals(tt {{.Name}}) bool {
 
 
 	addTemplate("union_unmarshal", `
-   var tagVal uint8
+   var tagVal {{.TagType}}
 
-   tagVal, nOff, err = backend.ReadUint8(bs[n:])
+   tagVal, nOff, err = backend.Read{{.TagApi}}(bs[n:])
    if err != nil { return 0, err }
    n += nOff
 
    switch tagVal {
    case 0: // Zero tag indicates nil
-      return 0, nil
+      *t = {{.Name}}{}
+      return n, nil
 
    {{.InnerCode}}
    default:
@@ -366,11 +869,15 @@ func (t {{.Name}})CodEquals(tt {{.Name}}) bool {
 	addTemplate("union_case_unmarshal", `
    case {{.Tag}}:
       var decoded {{.Type}}
+{{- if .Limited}}
+      nOff, err = decoded.DecodeCodWithLimits(bs[n:], lim)
+{{- else}}
       nOff, err = decoded.DecodeCod(bs[n:])
+{{- end}}
       if err != nil { return 0, err }
       n += nOff
 
-      t.Set(decoded)
+      t.Set{{.Variant}}(decoded)
 `)
 
 	// Union getters and setters
@@ -389,29 +896,57 @@ func (t {{.Name}}) Get() cod.EncoderDecoder {
 `)
 
 	addTemplate("union_constructor", `
-func New{{.Name}}(v cod.EncoderDecoder) {{.Name}} {
+func New{{.Name}}(v cod.EncoderDecoder) {{if .SetError}}({{.Name}}, error){{else}}{{.Name}}{{end}} {
    var ret {{.Name}}
+{{- if .SetError}}
+   err := ret.Set(v)
+   return ret, err
+{{- else}}
    ret.Set(v)
    return ret
+{{- end}}
 }
 `)
 
-// TODO: You could theoretically check and panic if the user passes in an incorrect value
+	// Note: Set rejects anything that isn't a member of the union, so misuse is caught where the value is set instead of when it is encoded
 	addTemplate("union_setter", `
-func (t *{{.Name}}) Set(v cod.EncoderDecoder) {
+func (t *{{.Name}}) Set(v cod.EncoderDecoder) {{if .SetError}}error {{end}}{
+   switch v.(type) {
+   case nil{{range .Types}}, {{.}}{{end}}:
+   default:
+{{- if .SetError}}
+      return fmt.Errorf("%w: %T is not a member of {{.Name}}", backend.ErrUnknownUnionType, v)
+{{- else}}
+      panic(fmt.Sprintf("%T is not a member of union {{.Name}}", v))
+{{- end}}
+   }
+
    codUnion := cod.Union(*t)
    codUnion.PutRawValue(v)
    *t = {{.Name}}(codUnion)
+{{- if .SetError}}
+   return nil
+{{- end}}
+}
+`)
 
-   // switch tagVal {
-   // case 0: // Zero tag indicates nil
-   //    return nil
+	// Type-safe constructors, getters and setters for each union type
+	addTemplate("union_variant", `
+func New{{.Name}}From{{.Variant}}(v {{.Type}}) {{.Name}} {
+   var ret {{.Name}}
+   ret.Set{{.Variant}}(v)
+   return ret
+}
 
-   // {{.InnerCode}}
-   // default:
-   //    panic("unknown type placed in union")
-   // }
-   // return err
+func (t {{.Name}}) Get{{.Variant}}() ({{.Type}}, bool) {
+   v, ok := t.Get().({{.Type}})
+   return v, ok
+}
+
+func (t *{{.Name}}) Set{{.Variant}}(v {{.Type}}) {
+   codUnion := cod.Union(*t)
+   codUnion.PutRawValue(v)
+   *t = {{.Name}}(codUnion)
 }
 `)
 
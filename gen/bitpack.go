@@ -0,0 +1,144 @@
+package gen
+
+import (
+	"bytes"
+)
+
+// Bitpacked structs write all of their bool fields as a single bitfield of ceil(n/8) bytes, at the position of the first bool field.
+// Their []bool fields are written as a length followed by the packed bits.
+
+// Returns the bool field if this field is a bool that should be packed
+func getBoolField(f Field) (*BasicField, bool) {
+	basic, ok := f.(*BasicField)
+	if !ok { return nil, false }
+	if shouldSkipSerdes(basic.Tag) { return nil, false }
+	apiName, _, supported := basic.getApi()
+	return basic, supported && apiName == "Bool"
+}
+
+// Replaces the bool fields of a struct with a bitfield and the []bool fields with packed slices
+func bitpackFields(fields []Field) []Field {
+	bitfield := &BitfieldField{}
+	ret := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		boolField, ok := getBoolField(f)
+		if ok {
+			if len(bitfield.Bools) == 0 {
+				ret = append(ret, bitfield)
+			}
+			bitfield.Bools = append(bitfield.Bools, boolField)
+			continue
+		}
+
+		slice, ok := f.(*SliceField)
+		if ok && !shouldSkipSerdes(slice.Tag) {
+			_, isBool := getBoolField(slice.Field)
+			if isBool {
+				ret = append(ret, &PackedBoolsField{SliceField: slice})
+				continue
+			}
+		}
+
+		ret = append(ret, f)
+	}
+	return ret
+}
+
+type BitfieldField struct {
+	Bools []*BasicField
+}
+
+func (f *BitfieldField) GetName() string {
+	return f.Bools[0].GetName()
+}
+func (f *BitfieldField) SetName(name string) {
+	// Noop: The bitfield always writes the names of its bool fields
+}
+func (f *BitfieldField) SetTag(tag string) {
+}
+func (f *BitfieldField) GetTag() string {
+	return ""
+}
+func (f *BitfieldField) GetType() string {
+	return "bool"
+}
+
+// Returns the number of bytes in the bitfield
+func (f *BitfieldField) Size() int {
+	return (len(f.Bools) + 7) / 8
+}
+
+type bitfieldBool struct {
+	Name string
+	Type string
+	Byte int
+	Bit int
+}
+
+func (f *BitfieldField) templateData() map[string]any {
+	bools := make([]bitfieldBool, 0, len(f.Bools))
+	for i, b := range f.Bools {
+		bools = append(bools, bitfieldBool{
+			Name: b.Name,
+			Type: b.Type,
+			Byte: i / 8,
+			Bit: i % 8,
+		})
+	}
+	return map[string]any{
+		"Size": f.Size(),
+		"Bools": bools,
+	}
+}
+
+func (f BitfieldField) WriteEquality(buf *bytes.Buffer) {
+	for _, b := range f.Bools {
+		b.WriteEquality(buf)
+	}
+}
+
+func (f BitfieldField) WriteClone(buf *bytes.Buffer) {
+	for _, b := range f.Bools {
+		b.WriteClone(buf)
+	}
+}
+
+func (f BitfieldField) WriteMarshal(buf *bytes.Buffer) {
+	err := basicTemp.ExecuteTemplate(buf, "bitfield_marshal", f.templateData())
+	if err != nil { panic(err) }
+}
+
+func (f BitfieldField) WriteUnmarshal(buf *bytes.Buffer) {
+	err := basicTemp.ExecuteTemplate(buf, "bitfield_unmarshal", f.templateData())
+	if err != nil { panic(err) }
+}
+
+func (f BitfieldField) WriteSize(buf *bytes.Buffer) {
+	err := basicTemp.ExecuteTemplate(buf, "bitfield_size", f.templateData())
+	if err != nil { panic(err) }
+}
+
+type PackedBoolsField struct {
+	*SliceField
+}
+
+func (f PackedBoolsField) WriteMarshal(buf *bytes.Buffer) {
+	err := basicTemp.ExecuteTemplate(buf, "packed_bools_marshal", map[string]any{
+		"Name": f.Name,
+	})
+	if err != nil { panic(err) }
+}
+
+func (f PackedBoolsField) WriteUnmarshal(buf *bytes.Buffer) {
+	err := basicTemp.ExecuteTemplate(buf, "packed_bools_unmarshal", map[string]any{
+		"Name": f.Name,
+	})
+	if err != nil { panic(err) }
+}
+
+func (f PackedBoolsField) WriteSize(buf *bytes.Buffer) {
+	err := basicTemp.ExecuteTemplate(buf, "packed_bools_size", map[string]any{
+		"Name": f.Name,
+	})
+	if err != nil { panic(err) }
+}
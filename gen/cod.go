@@ -0,0 +1,599 @@
+// Package gen generates the cod encoders and decoders for the tagged types of a package. It is used by the cod command
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+
+	"go/token"
+	"go/types"
+
+	"path/filepath"
+)
+
+const (
+	RequestTypeComponent RequestType = iota
+	RequestTypeEcsEvent
+	RequestTypeSerdes
+	RequestTypeUnion
+	RequestTypeUnionDef
+)
+var directiveSearch = []requestConfig{
+	{"//cod:component", RequestTypeComponent, []string{"ecs"}, false},
+	{"//cod:event", RequestTypeEcsEvent, []string{"ecs"}, false},
+	{"//cod:struct", RequestTypeSerdes, []string{"backend"}, true},
+	{"//cod:union", RequestTypeUnion, []string{"fmt"}, true},
+	{"//cod:def", RequestTypeUnionDef, []string{}, true},
+
+	// TODO: Ideally also, these would contain the function that is used to generate the code, so you can more easily add new directives
+
+	// TODO: Maybe generate enums/Tagged unions in the future
+	// {"//cod:enum", RequestTypeEnum},
+	// {"//cod:safe-enum", RequestTypeEnumSafe},
+}
+
+
+// Controls what Generate produces
+type Config struct {
+	Fuzz bool // If true, also generate a fuzz test for every codec type
+	Schema bool // If true, also write the wire layout of every tagged type to cod_schema.json
+	Tags []string // Extra build tags used to select files
+	GOOS, GOARCH string // The target used to select files. Defaults to the current target
+}
+
+// Generates the code for the package in dir, and returns the contents of each generated file by its path. Nothing is written to disk.
+// Problems in the package are returned as diagnostics, and a package with errors doesn't return any files. The error is only set if the package couldn't be read
+// Imported packages are cached between calls (see ResetCache), and calls are run one at a time
+func Generate(dir string, cfg Config) (map[string][]byte, []Diagnostic, error) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	ctx := cfg.buildContext()
+
+	fset := token.NewFileSet()
+	packages, err := parser.ParseDir(fset, dir, fileFilter(ctx, dir), parser.ParseComments)
+	if err != nil {
+		if _, ok := err.(scanner.ErrorList); !ok { return nil, nil, err }
+		return nil, parseDiagnostics(err), nil
+	}
+
+	files := make(map[string][]byte)
+	diags := make([]Diagnostic, 0)
+
+	for _, pkg := range packages {
+		// fmt.Println("Parsing Package:", pkg.Name)
+		typesPkg, info, typeErrors := typeCheck(fset, pkg)
+		bv := &Visitor{
+			pkg: pkg,
+			fset: fset,
+			typesPkg: typesPkg,
+			info: info,
+			typeErrors: typeErrors,
+			reportedTypeErrors: make(map[token.Pos]bool),
+			typeParamPtrs: make(map[*types.TypeParam]string),
+			requests: make(map[string][]GenRequest),
+
+			structs: make(map[string]StructData),
+			imports: make(map[string]string),
+			usedImports: make(map[string]token.Pos),
+			generatedImports: make(map[string]bool),
+			directives: make(map[token.Pos]bool),
+		}
+
+		// Register some common imports in case they are needed
+		bv.imports["backend"] = "\"github.com/unitoftime/cod/backend\""
+		bv.imports["fmt"] = "\"fmt\""
+
+
+		bv.config = bv.getPackageConfig()
+
+		// We start walking our Visitor `bv` through the AST in a depth-first way.
+		ast.Walk(bv, pkg)
+		bv.checkUnresolved()
+		bv.checkTypeErrors()
+		bv.checkDirectives()
+		if HasErrors(bv.diags) {
+			diags = append(diags, bv.diags...)
+			continue // Skip: the generated code would be wrong
+		}
+
+		file := bv.Output()
+		if file != nil {
+			files[filepath.Join(dir, generatedFileName)] = file
+		}
+		if cfg.Fuzz {
+			file := bv.OutputFuzz()
+			if file != nil {
+				files[filepath.Join(dir, fuzzFileName)] = file
+			}
+		}
+		if cfg.Schema {
+			file := bv.OutputSchema()
+			if file != nil {
+				files[filepath.Join(dir, schemaFileName)] = file
+			}
+		}
+		diags = append(diags, bv.diags...)
+	}
+
+	if HasErrors(diags) {
+		return nil, diags, nil // Skip: the generated files wouldn't compile
+	}
+	return files, diags, nil
+}
+
+// Returns the paths of every file that Generate can write for dir with the config, even if the package has nothing to generate.
+// If the package has no errors, then a file that exists but isn't returned by Generate is stale (ie the package no longer has tagged types)
+func GeneratedFiles(dir string, cfg Config) []string {
+	files := []string{filepath.Join(dir, generatedFileName)}
+	if cfg.Fuzz {
+		files = append(files, filepath.Join(dir, fuzzFileName))
+	}
+	if cfg.Schema {
+		files = append(files, filepath.Join(dir, schemaFileName))
+	}
+	return files
+}
+
+func (v *Visitor) formatGen(decl ast.GenDecl) (StructData, bool) {
+	structData := StructData{}
+
+	// Skip everything that isn't a type. we can only generate for types
+	if decl.Tok != token.TYPE {
+		decl.Doc = nil // nil the Doc field so that we don't print it
+		return structData, false
+	}
+
+	fields := make([]Field, 0)
+
+	for _, spec := range decl.Specs {
+		switch s := spec.(type) {
+		case *ast.TypeSpec:
+			add, trackImports := v.getRequests(s.Name.Name, decl)
+
+			if !add {
+				return structData, false // Skip because it wasn't marked
+			}
+
+			if trackImports {
+				v.tagged = append(v.tagged, s)
+			}
+
+			debugPrintln("TypeSpec: ", s.Name.Name)
+			debugPrintf("TypeSpec: %T\n", s.Type)
+			structData.Name = s.Name.Name
+			structData.Pos = s.Name.Pos()
+			structData.TypeParams = v.getTypeParams(s, trackImports)
+
+			// debugPrintf("Struct Type: %T\n", s.Type)
+			sType, ok := s.Type.(*ast.StructType)
+			if !ok {
+				// Not a struct, then its an alias. So handle that if we can
+				name := "t"
+				idxDepth := 0
+				field := v.generateField(name, idxDepth+1, s.Type, trackImports)
+				fields = append(fields, &AliasField{
+					Name: name,
+					AliasType: structData.withTypeParams().Name,
+					Field: field,
+					IndexDepth: idxDepth,
+				})
+				continue
+			}
+
+			debugPrintln("Fields: ", sType.Fields.List)
+
+			for _, f := range sType.Fields.List {
+				if f.Names == nil {
+					debugPrintln("UnnamedField: ", f.Type, f.Tag)
+
+					// TODO: probably come up with a better way of getting the name
+					name := ""
+					fIdent, ok := f.Type.(*ast.Ident)
+					if ok {
+						name = fIdent.Name
+					}
+					sel, ok := f.Type.(*ast.SelectorExpr)
+					if ok {
+						x := sel.X.(*ast.Ident)
+						name = x.Name + "." + sel.Sel.Name
+					}
+					// An embedded generic type is named after the type without its type arguments
+					switch idx := f.Type.(type) {
+					case *ast.IndexExpr:
+						if x, ok := idx.X.(*ast.Ident); ok { name = x.Name }
+					case *ast.IndexListExpr:
+						if x, ok := idx.X.(*ast.Ident); ok { name = x.Name }
+					}
+
+					fields = append(fields, v.generateStructField("t." + name, f, trackImports))
+				} else {
+					for _, n := range f.Names {
+						debugPrintln("Field: ", n.Name, f.Type, f.Tag)
+						debugPrintf("%T\n", f.Type)
+
+						fields = append(fields, v.generateStructField("t." + n.Name, f, trackImports))
+					}
+				}
+			}
+		}
+	}
+	structData.Fields = fields
+
+	return structData, true
+}
+
+// Generates a field of a struct with its tag. Fields that are skipped by both serdes and equality are never used by the generated code, so their type doesn't need to be supported
+func (v *Visitor) generateStructField(name string, f *ast.Field, trackImports bool) Field {
+	tag := ""
+	if f.Tag != nil {
+		tag = f.Tag.Value
+	}
+	if shouldSkipSerdes(tag) && shouldSkipEquality(tagSearchSkip(tag)) {
+		trackImports = false
+	}
+
+	idxDepth := 0
+	field := v.generateField(name, idxDepth+1, f.Type, trackImports)
+	if f.Tag != nil {
+		field.SetTag(tag)
+	}
+	return field
+}
+
+func (v *Visitor) generateField(name string, idxDepth int, node ast.Node, trackImports bool) Field {
+	debugPrintf("generateField: %T\n", node)
+
+	switch expr := node.(type) {
+	case *ast.Ident:
+		debugPrintln("Ident: ", expr.Name)
+		if tp, ok := v.typeParam(expr); ok {
+			if _, isPtr := pointerTypeParam(tp); isPtr && trackImports {
+				v.errorf(expr.Pos(), "unsupported field type %s: pointer type parameters can't be encoded", expr.Name)
+			}
+			return &TypeParamField{
+				Name: name,
+				Type: expr.Name,
+				Ptr: v.typeParamPtrs[tp],
+			}
+		}
+		field := &BasicField{
+			Name: name,
+			Type: expr.Name,
+		}
+		v.resolveBasicField(field, expr, trackImports)
+
+		return field
+
+	case *ast.StarExpr:
+		// debugPrintln("StarExpr: ", expr.Name)
+		debugPrintf("STAR %T\n", expr.X)
+		field := v.generateField(name, idxDepth+1, expr.X, trackImports)
+		return &PointerField{
+			Name: name,
+			Field: field,
+			IndexDepth: idxDepth,
+		}
+
+	case *ast.ArrayType:
+		// debugPrintf("ARRAY %T %T\n", expr.Len, expr.Elt)
+
+		if expr.Len == nil {
+			idxString := fmt.Sprintf("[i%d]", idxDepth)
+			field := v.generateField(name + idxString, idxDepth + 1, expr.Elt, trackImports)
+			return &SliceField{
+				Name: name,
+				// Type: field.GetType(),
+				Field: field,
+				IndexDepth: idxDepth,
+			}
+		} else {
+			idxString := fmt.Sprintf("[i%d]", idxDepth)
+			field := v.generateField(name + idxString, idxDepth + 1, expr.Elt, trackImports)
+
+			lengthIdentName := ""
+			switch lenT := expr.Len.(type) {
+			case *ast.Ident:
+				lengthIdentName = lenT.Name
+			case *ast.BasicLit:
+				lengthIdentName = lenT.Value
+			default:
+				// panic(fmt.Sprintf("unhandled array length type: %T", expr.Len))
+				lengthIdentName = "???"
+			}
+
+			return &ArrayField{
+				Name: name,
+				Len: lengthIdentName,
+				Field: field,
+				IndexDepth: idxDepth,
+			}
+		}
+
+	case *ast.MapType:
+		// debugPrintf("MAP %T %T\n", expr.Key, expr.Value)
+		keyString := fmt.Sprintf("[k%d]", idxDepth)
+		valString := fmt.Sprintf("[v%d]", idxDepth)
+		key := v.generateField(name + keyString, idxDepth + 1, expr.Key, trackImports)
+		val := v.generateField(name + valString, idxDepth + 1, expr.Value, trackImports)
+		return &MapField{
+			Name: name,
+			Key: key,
+			Val: val,
+			IndexDepth: idxDepth,
+			Deterministic: v.config.DeterministicMaps,
+		}
+	case *ast.SelectorExpr:
+		// Note: a selector expression (ie phy.Position) is resolved by the type checker. If it isn't a basic type, then it must implement the required struct interface
+		debugPrintf("SELECTOREXPR: %T %T\n", expr.X, expr.Sel)
+		x := expr.X.(*ast.Ident)
+		debugPrintln("SELECTOREXPR:", x.Name, expr.Sel.Name)
+		field := &BasicField{
+			Name: name,
+			// Type: "UNKNOWN_SELECTOR_EXPR", // This will force it to resolve to the struct marshaller
+			Type: x.Name + "." + expr.Sel.Name, // This will force it to resolve to the struct marshaller
+		}
+
+		if trackImports {
+			v.useImport(x.Name, x.Pos()) // Store the import name so we can pull it later
+		}
+		v.resolveBasicField(field, expr, trackImports)
+
+		return field
+
+		// Note: These can't be encoded, but ecs components can hold them, so they are only errors for types that get encoders
+	case *ast.FuncType:
+		if trackImports {
+			v.errorf(expr.Pos(), "unsupported field type %s: funcs can't be encoded", types.ExprString(expr))
+		}
+		return &BasicField{} // Invalid
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		// An instantiated generic type (ie Option[Vec]). It is encoded the same way as any other named type
+		field := &BasicField{
+			Name: name,
+			Type: types.ExprString(expr.(ast.Expr)),
+		}
+		if trackImports {
+			v.trackExprImports(expr.(ast.Expr))
+		}
+		v.resolveBasicField(field, expr.(ast.Expr), trackImports)
+
+		return field
+	case *ast.StructType:
+		if trackImports {
+			v.errorf(expr.Pos(), "unsupported field type %s: anonymous structs can't be encoded, use a type tagged with //cod:struct", types.ExprString(expr))
+		}
+		return &BasicField{} // Invalid
+	case *ast.ChanType:
+		if trackImports {
+			v.errorf(expr.Pos(), "unsupported field type %s: channels can't be encoded", types.ExprString(expr))
+		}
+		return &BasicField{} // Invalid
+	default:
+		v.errorf(node.Pos(), "unsupported field type %s", types.ExprString(node.(ast.Expr)))
+		return &BasicField{} // Invalid
+	}
+}
+
+type Visitor struct {
+	pkg *ast.Package  // The package that we are processing
+	fset *token.FileSet // The fileset of the package we are processing
+	typesPkg *types.Package // The type checked package (Can be nil if type checking failed)
+	info *types.Info // The type information of the package we are processing
+	config packageConfig // The package wide options
+	file *ast.File // The file we are currently processing (Can be nil if we haven't started processing a file yet!)
+	cmap ast.CommentMap // The comment map of the file we are processing
+
+	requests map[string][]GenRequest
+
+	structs map[string]StructData
+
+	imports map[string]string // Maps a selector source to a package path
+	usedImports map[string]token.Pos // List of encoded selector expressions, and where they were first used
+	generatedImports map[string]bool // The package names that the generated code refers to
+	directives map[token.Pos]bool // The directive comments that were attached to a type
+
+	unresolved []unresolvedType // Types in this package that must be tagged for the generated code to compile
+	tagged []ast.Node // The type specs that code is generated for
+	typeParamPtrs map[*types.TypeParam]string // The pointer type parameter of each type parameter of a tagged generic type
+	typeErrors []types.Error // The errors found by the type checker
+	reportedTypeErrors map[token.Pos]bool // The type errors that were already reported
+
+	diags []Diagnostic // The errors and warnings found in this package
+}
+
+// Marks an import as used by the generated code. The earliest position is kept so that errors are reported at the first use
+func (v *Visitor) useImport(name string, pos token.Pos) {
+	old, ok := v.usedImports[name]
+	if ok && (!pos.IsValid() || (old.IsValid() && old < pos)) { return }
+	v.usedImports[name] = pos
+}
+
+func (v *Visitor) Visit(node ast.Node) ast.Visitor {
+	if node == nil { return nil }
+
+	// debugPrintf("Node: %T\n", node)
+
+	// If we are a package, then just keep searching
+	_, ok := node.(*ast.Package)
+	if ok { return v }
+
+	// If we are a file, then store some data in the visitor so we can use it later
+	file, ok := node.(*ast.File)
+	if ok {
+		v.file = file
+		v.cmap = ast.NewCommentMap(v.fset, file, file.Comments)
+
+		for _, importSpec := range file.Imports {
+			debugPrintln("IMPORT SPEC: ", importSpec)
+			path := importSpec.Path.Value
+
+			name := strings.TrimSuffix(filepath.Base(path), `"`)
+
+			// If there was a custom name, use that. The name is kept with the path so that the generated file imports it with the same name
+			nameIdent := importSpec.Name
+			if nameIdent != nil {
+				name = nameIdent.Name
+				path = name + " " + path
+			}
+			debugPrintln("IMPORT: ", name, path)
+			v.imports[name] = path
+		}
+		return v
+	}
+
+	// If we are a function, do the function formatting
+	_, ok = node.(*ast.FuncDecl)
+	if ok {
+		return nil // Skip: we don't handle funcs
+	}
+
+	gen, ok := node.(*ast.GenDecl)
+	if ok {
+		sd, ok := v.formatGen(*gen)
+		if ok {
+			v.structs[sd.Name] = sd
+		}
+		return nil
+	}
+
+	// If all else fails, then keep looking
+	return v
+}
+
+type StructData struct {
+	Name string
+	Pos token.Pos // The position of the type name
+	TypeParams []string // The type parameter names, if the type is generic
+	Fields []Field
+	// TODO: Hold pointer to original type/field?
+}
+
+// Returns the struct data with the type parameters added to the name, so that it can be used as a method receiver (ie Pair[K, V])
+func (sd StructData) withTypeParams() StructData {
+	if len(sd.TypeParams) == 0 { return sd }
+	sd.Name = sd.Name + "[" + strings.Join(sd.TypeParams, ", ") + "]"
+	return sd
+}
+
+// Returns the formatted generated file, or nil if there is nothing to generate
+func (v *Visitor) Output() []byte {
+	if len(v.requests) == 0 && len(v.structs) == 0 {
+		return nil // Skip: no tagged structs
+	}
+
+	buf := new(bytes.Buffer)
+	toSort := make([]string, 0)
+	for k := range v.structs {
+		toSort = append(toSort, k)
+	}
+	sort.Strings(toSort)
+
+	// Generate all of the requests
+	for _, k := range toSort {
+		v.generateType(v.structs[k], buf)
+	}
+
+	fileBuf := new(bytes.Buffer)
+	fileBuf.WriteString(GeneratedHeader + "\n")
+	fileBuf.WriteString("package " + v.pkg.Name)
+	v.WriteImports(fileBuf)
+	fileBuf.Write(buf.Bytes())
+
+	formatted, err := formatFile(fileBuf)
+	if err != nil {
+		v.errorf(token.NoPos, "package %s: %v", v.pkg.Name, err)
+		return nil
+	}
+	return formatted
+}
+
+// Generates the requests of one type. If generating fails, then the error is reported at the type and none of its code is written
+func (v *Visitor) generateType(sd StructData, buf *bytes.Buffer) {
+	defer v.recoverType(sd)
+
+	typeBuf := new(bytes.Buffer)
+	for _, req := range v.requests[sd.Name] {
+		switch req.Type {
+		case RequestTypeComponent:
+			err := basicTemp.ExecuteTemplate(typeBuf, "ecs_component", map[string]any{
+				"Name": sd.Name,
+			})
+			if err != nil { panic(err) }
+		case RequestTypeEcsEvent:
+			err := basicTemp.ExecuteTemplate(typeBuf, "ecs_event", map[string]any{
+				"Name": sd.Name,
+			})
+			if err != nil { panic(err) }
+
+		case RequestTypeSerdes:
+			GenerateSerdesData(sd.withTypeParams(), getStructConfig(req.CSV, v.config), typeBuf)
+			WriteStreamFuncs(sd.withTypeParams(), typeBuf)
+		case 	RequestTypeUnion:
+			if len(sd.TypeParams) > 0 {
+				panic(fmt.Sprintf("union %s: unions can't have type parameters", sd.Name))
+			}
+			GenerateUnionData(sd, req.CSV, v.structs, typeBuf)
+			WriteStreamFuncs(sd, typeBuf)
+		case RequestTypeUnionDef:
+			// Noop: We only have a request for this one because we need to look it up from from actual union code gen
+		}
+	}
+	v.trackGeneratedImports(typeBuf.Bytes())
+	buf.Write(typeBuf.Bytes())
+}
+
+// Writes the imports that the generated code uses. The imports tracked from the field types are broad (ie a field type's package isn't needed if only its methods are called), so only the ones that the generated code refers to are written
+func (v *Visitor) WriteImports(buf *bytes.Buffer) {
+	toSort := make([]string, 0)
+	for k := range v.usedImports {
+		if v.generatedImports[k] {
+			toSort = append(toSort, k)
+		}
+	}
+
+	if len(toSort) > 0 {
+		buf.WriteString(`
+import (
+`)
+
+		sort.Strings(toSort)
+
+		for _, k := range toSort {
+			debugPrintln("Used Import: ", k)
+			path, ok := v.imports[k]
+			debugPrintln("Used Import: ", k, path, ok)
+			if !ok {
+				v.errorf(v.usedImports[k], "couldn't find import: %s", k)
+				continue
+			}
+			buf.WriteString("\n"+path+"\n")
+		}
+		buf.WriteString(`
+)`)
+	}
+}
+
+// Marks the packages that the code generated for a type refers to (ie backend in backend.WriteUint8).
+// The parser resolves every identifier that is declared in the code, so locals, receivers and fields (ie t.fmt) are never mistaken for packages.
+// If the code can't be parsed, then nothing is marked and the syntax error is reported when the file is formatted
+func (v *Visitor) trackGeneratedImports(code []byte) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", append([]byte("package "+v.pkg.Name+"\n"), code...), 0)
+	if err != nil { return }
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		sel, ok := node.(*ast.SelectorExpr)
+		if !ok { return true }
+		x, ok := sel.X.(*ast.Ident)
+		if ok && x.Obj == nil {
+			v.generatedImports[x.Name] = true
+		}
+		return true
+	})
+}
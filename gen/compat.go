@@ -0,0 +1,346 @@
+package gen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// How a change affects data written by the other version of a schema
+type Compatibility int
+
+const (
+	Compatible Compatibility = iota // Each version can read the data written by the other
+	ForwardOnly // The new version can read old data, but the old version can fail to read new data (ie a value that doesn't fit in a narrower int)
+	Breaking // The new version can fail to read old data
+)
+
+func (c Compatibility) String() string {
+	switch c {
+	case Compatible:
+		return "compatible"
+	case ForwardOnly:
+		return "forward-only"
+	case Breaking:
+		return "breaking"
+	}
+	return fmt.Sprintf("Compatibility(%d)", int(c))
+}
+
+// A difference between two versions of a schema
+type Change struct {
+	Type string // The type that changed
+	Compat Compatibility
+	Msg string
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s: %s", c.Compat, c.Type, c.Msg)
+}
+
+// Returns true if any of the changes are at least as bad as the compatibility level
+func HasChanges(changes []Change, level Compatibility) bool {
+	for _, c := range changes {
+		if c.Compat >= level { return true }
+	}
+	return false
+}
+
+func HasBreakingChanges(changes []Change) bool {
+	return HasChanges(changes, Breaking)
+}
+
+// The number of bits that can be held by the integer encodings that have the same wire format
+var uvarintBits = map[string]int{"varuint16": 16, "varuint32": 32, "varuint64": 64, "uint": 64}
+var varintBits = map[string]int{"varint16": 16, "varint32": 32, "varint64": 64, "int": 64}
+
+// Returns the compatibility of changing one integer encoding to another with the same wire format. Widening is forward only, because the old version can't hold the larger values
+func widenCompat(oldKind, newKind string) Compatibility {
+	for _, bits := range []map[string]int{uvarintBits, varintBits} {
+		oldBits, ok1 := bits[oldKind]
+		newBits, ok2 := bits[newKind]
+		if !ok1 || !ok2 { continue }
+		if newBits == oldBits { return Compatible }
+		if newBits > oldBits { return ForwardOnly }
+	}
+	return Breaking
+}
+
+type compatChecker struct {
+	changes []Change
+}
+
+func (c *compatChecker) add(typeName string, compat Compatibility, format string, args ...any) {
+	c.changes = append(c.changes, Change{
+		Type: typeName,
+		Compat: compat,
+		Msg: fmt.Sprintf(format, args...),
+	})
+}
+
+// Compares two versions of a package's schema, and returns the changes to the wire format of each type.
+// Defs are only compared through the unions that use them
+func CompareSchemas(old, new Schema) []Change {
+	c := &compatChecker{}
+
+	oldTypes := make(map[string]TypeSchema)
+	for _, ts := range old.Types {
+		if ts.Kind == "def" { continue }
+		oldTypes[ts.Name] = ts
+	}
+	newTypes := make(map[string]TypeSchema)
+	for _, ts := range new.Types {
+		if ts.Kind == "def" { continue }
+		newTypes[ts.Name] = ts
+	}
+
+	for _, ot := range old.Types {
+		if ot.Kind == "def" { continue }
+		nt, ok := newTypes[ot.Name]
+		if !ok {
+			c.add(ot.Name, Breaking, "%s was removed", ot.Kind)
+			continue
+		}
+		c.compareType(ot, nt)
+	}
+	for _, nt := range new.Types {
+		if nt.Kind == "def" { continue }
+		if _, ok := oldTypes[nt.Name]; !ok {
+			c.add(nt.Name, Compatible, "%s was added", nt.Kind)
+		}
+	}
+
+	sort.SliceStable(c.changes, func(i, j int) bool {
+		return c.changes[i].Type < c.changes[j].Type
+	})
+	return c.changes
+}
+
+func (c *compatChecker) compareType(old, new TypeSchema) {
+	if old.Kind != new.Kind {
+		c.add(old.Name, Breaking, "changed from a %s to a %s", old.Kind, new.Kind)
+		return
+	}
+	if old.Kind == "union" {
+		c.compareUnion(old, new)
+		return
+	}
+
+	// Types that aren't structs are described by a single encoding
+	if old.Encoding != nil || new.Encoding != nil {
+		if old.Encoding == nil || new.Encoding == nil {
+			c.add(old.Name, Breaking, "changed from %s to %s", describeType(old), describeType(new))
+			return
+		}
+		c.compareEncoding(old.Name, "type", *old.Encoding, *new.Encoding)
+		return
+	}
+
+	if old.Evolvable != new.Evolvable {
+		c.add(old.Name, Breaking, "changed from %s to %s", describeType(old), describeType(new))
+		return
+	}
+	if old.Evolvable {
+		c.compareEvolvable(old, new)
+		return
+	}
+	c.compareCompact(old, new)
+}
+
+func describeType(ts TypeSchema) string {
+	if ts.Encoding != nil { return ts.Encoding.String() }
+	if ts.Evolvable { return "an evolvable struct" }
+	return "a compact struct"
+}
+
+// Returns the fields that are written, and their names. A bitfield holds multiple fields, so it is named after them
+func wireFields(fields []FieldSchema) ([]FieldSchema, []string) {
+	ret := make([]FieldSchema, 0, len(fields))
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if strings.Contains(f.Skip, "serdes") { continue }
+		ret = append(ret, f)
+		if f.Encoding.Kind == "bitfield" {
+			names = append(names, "bitfield")
+		} else {
+			names = append(names, f.Name)
+		}
+	}
+	return ret, names
+}
+
+func indexNames(names []string) map[string]int {
+	ret := make(map[string]int, len(names))
+	for i, name := range names {
+		ret[name] = i
+	}
+	return ret
+}
+
+// Compact structs are a list of their fields, so a field can't be added, removed or moved. Only the names are free to change
+func (c *compatChecker) compareCompact(old, new TypeSchema) {
+	oldFields, oldNames := wireFields(old.Fields)
+	newFields, newNames := wireFields(new.Fields)
+	oldPos := indexNames(oldNames)
+	newPos := indexNames(newNames)
+
+	renamed := make(map[string]bool)
+	for i := 0; i < min(len(oldFields), len(newFields)); i++ {
+		oldName, newName := oldNames[i], newNames[i]
+		if oldName != newName {
+			j, moved := newPos[oldName]
+			if moved {
+				c.add(old.Name, Breaking, "field %s moved from position %d to %d", oldName, i+1, j+1)
+				continue
+			}
+			if _, ok := oldPos[newName]; ok {
+				continue // The old field was removed, and is reported below
+			}
+			renamed[oldName] = true
+			renamed[newName] = true
+			c.add(old.Name, Compatible, "field %s was renamed to %s", oldName, newName)
+		}
+		c.compareEncoding(old.Name, "field " + newName, oldFields[i].Encoding, newFields[i].Encoding)
+	}
+
+	for _, name := range oldNames {
+		if _, ok := newPos[name]; ok || renamed[name] { continue }
+		c.add(old.Name, Breaking, "field %s was removed (fields can only be removed from evolvable structs)", name)
+	}
+	for _, name := range newNames {
+		if _, ok := oldPos[name]; ok || renamed[name] { continue }
+		c.add(old.Name, Breaking, "field %s was added (fields can only be added to evolvable structs)", name)
+	}
+}
+
+// Evolvable structs match their fields by number, so fields can be freely added and removed
+func (c *compatChecker) compareEvolvable(old, new TypeSchema) {
+	oldFields, _ := wireFields(old.Fields)
+	newFields, _ := wireFields(new.Fields)
+	newByNumber := make(map[int]FieldSchema)
+	for _, f := range newFields {
+		newByNumber[f.Number] = f
+	}
+	oldByNumber := make(map[int]FieldSchema)
+	for _, f := range oldFields {
+		oldByNumber[f.Number] = f
+	}
+
+	for _, of := range oldFields {
+		nf, ok := newByNumber[of.Number]
+		if !ok {
+			c.add(old.Name, Compatible, "field %s (number %d) was removed, its number must not be reused", of.Name, of.Number)
+			continue
+		}
+		if of.Name != nf.Name {
+			c.add(old.Name, Compatible, "field %s (number %d) was renamed to %s", of.Name, of.Number, nf.Name)
+		}
+		c.compareEncoding(old.Name, "field " + nf.Name, of.Encoding, nf.Encoding)
+	}
+	for _, nf := range newFields {
+		if _, ok := oldByNumber[nf.Number]; ok { continue }
+		c.add(old.Name, Compatible, "field %s (number %d) was added", nf.Name, nf.Number)
+	}
+}
+
+func (c *compatChecker) compareEncoding(typeName, name string, old, new EncodingSchema) {
+	if old.Kind == "bitfield" && new.Kind == "bitfield" {
+		c.compareBits(typeName, old.Bits, new.Bits)
+		return
+	}
+
+	changed, compat := diffEncoding(old, new)
+	if !changed { return }
+	c.add(typeName, compat, "%s changed from %s to %s", name, old, new)
+}
+
+// Returns true if the encodings are different, and how the difference affects the wire format
+func diffEncoding(old, new EncodingSchema) (bool, Compatibility) {
+	if old.Kind != new.Kind {
+		return true, widenCompat(old.Kind, new.Kind)
+	}
+
+	switch old.Kind {
+	case "codec":
+		if old.Type != new.Type { return true, Breaking }
+	case "array":
+		if old.Len != new.Len { return true, Breaking }
+		return diffEncoding(*old.Elem, *new.Elem)
+	case "slice", "pointer", "packedbools":
+		return diffEncoding(*old.Elem, *new.Elem)
+	case "map":
+		keyChanged, keyCompat := diffEncoding(*old.Key, *new.Key)
+		elemChanged, elemCompat := diffEncoding(*old.Elem, *new.Elem)
+		changed := keyChanged || elemChanged || old.Sorted != new.Sorted // Decoders accept the entries in any order
+		return changed, max(keyCompat, elemCompat)
+	}
+	return false, Compatible
+}
+
+// Bools are matched by their bit, so only the names of bits can change. Bits can be added and removed at the end, as long as the number of bytes stays the same
+func (c *compatChecker) compareBits(typeName string, old, new []string) {
+	oldBytes, newBytes := (len(old)+7)/8, (len(new)+7)/8
+	if oldBytes != newBytes {
+		c.add(typeName, Breaking, "bitfield changed from %d to %d bytes", oldBytes, newBytes)
+		return
+	}
+
+	oldPos := indexNames(old)
+	newPos := indexNames(new)
+	renamed := make(map[string]bool)
+	for i := 0; i < min(len(old), len(new)); i++ {
+		if old[i] == new[i] { continue }
+		j, moved := newPos[old[i]]
+		if moved {
+			c.add(typeName, Breaking, "field %s moved from bit %d to %d", old[i], i, j)
+			continue
+		}
+		if _, ok := oldPos[new[i]]; ok { continue } // The old field was removed, and is reported below
+		renamed[old[i]] = true
+		renamed[new[i]] = true
+		c.add(typeName, Compatible, "field %s (bit %d) was renamed to %s", old[i], i, new[i])
+	}
+	for i, name := range old {
+		if _, ok := newPos[name]; ok || renamed[name] { continue }
+		c.add(typeName, Compatible, "field %s (bit %d) was removed", name, i)
+	}
+	for i, name := range new {
+		if _, ok := oldPos[name]; ok || renamed[name] { continue }
+		c.add(typeName, Compatible, "field %s (bit %d) was added", name, i)
+	}
+}
+
+// Union variants are matched by their tag
+func (c *compatChecker) compareUnion(old, new TypeSchema) {
+	if old.TagEncoding != new.TagEncoding {
+		c.add(old.Name, Breaking, "tag changed from %s to %s", old.TagEncoding, new.TagEncoding)
+		return
+	}
+
+	newByType := make(map[string]int)
+	for _, v := range new.Variants {
+		newByType[v.Type] = v.Tag
+	}
+	oldByTag := make(map[int]VariantSchema)
+	oldByType := make(map[string]int)
+	for _, v := range old.Variants {
+		oldByTag[v.Tag] = v
+		oldByType[v.Type] = v.Tag
+	}
+
+	for _, ov := range old.Variants {
+		if tag, ok := newByType[ov.Type]; !ok {
+			c.add(old.Name, Breaking, "variant %s (tag %d) was removed", ov.Type, ov.Tag)
+		} else if tag != ov.Tag {
+			c.add(old.Name, Breaking, "variant %s moved from tag %d to %d", ov.Type, ov.Tag, tag)
+		}
+	}
+	for _, nv := range new.Variants {
+		if _, moved := oldByType[nv.Type]; moved { continue } // Reported above
+		if ov, ok := oldByTag[nv.Tag]; ok {
+			c.add(old.Name, Breaking, "variant %s reuses tag %d, which was used by %s", nv.Type, nv.Tag, ov.Type)
+			continue
+		}
+		c.add(old.Name, ForwardOnly, "variant %s was added with tag %d", nv.Type, nv.Tag) // The old version can't decode the new variant
+	}
+}
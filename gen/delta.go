@@ -0,0 +1,130 @@
+package gen
+
+import (
+	"bytes"
+)
+
+// Delta encoding writes a bitmask with one bit per field, set if the field changed from the base value, followed by only the changed fields.
+// Nested structs and unions are encoded as deltas against the matching field of the base.
+// Unchanged fields are deep copied from the base when decoding, so the decoded value never shares memory with the base.
+
+type deltaField struct {
+	Byte int
+	Bit int
+	Field Field
+}
+
+// Returns the fields that are included in the delta mask. Fields skipped by serdes are never written
+func getDeltaFields(sd StructData) []deltaField {
+	fields := make([]deltaField, 0, len(sd.Fields))
+	for _, f := range sd.Fields {
+		if shouldSkipSerdes(f.GetTag()) { continue }
+		i := len(fields)
+		fields = append(fields, deltaField{
+			Byte: i / 8,
+			Bit: i % 8,
+			Field: f,
+		})
+	}
+	return fields
+}
+
+// Returns true if the field is a struct or union that has its own delta functions. Hand written types may not
+func hasDeltaFuncs(f Field) bool {
+	basic, ok := f.(*BasicField)
+	if !ok { return false }
+	_, _, supported := basic.getApi()
+	return !supported && !basic.NoDelta
+}
+
+func WriteStructDelta(sd StructData, buf *bytes.Buffer) {
+	fields := getDeltaFields(sd)
+
+	// Aliases are a single value, so they are always written in full
+	_, isAlias := sd.Fields[0].(*AliasField)
+	if isAlias || len(fields) == 0 {
+		WriteFullDelta(sd, buf)
+		return
+	}
+
+	marshBuf := new(bytes.Buffer)
+	unmarshBuf := new(bytes.Buffer)
+	for _, f := range fields {
+		// Fields that skip equality can't be compared, so they are always written
+		alwaysChanged := shouldSkipEquality(tagSearchSkip(f.Field.GetTag()))
+		equalityBuf := new(bytes.Buffer)
+		cloneBuf := new(bytes.Buffer)
+		if !alwaysChanged {
+			f.Field.WriteEquality(equalityBuf)
+			f.Field.WriteClone(cloneBuf)
+		}
+
+		fieldMarshBuf := new(bytes.Buffer)
+		fieldUnmarshBuf := new(bytes.Buffer)
+		if hasDeltaFuncs(f.Field) {
+			data := map[string]any{
+				"Name": f.Field.GetName(),
+				"Name2": "t"+f.Field.GetName(),
+			}
+			err := basicTemp.ExecuteTemplate(fieldMarshBuf, "struct_delta_marshal", data)
+			if err != nil { panic(err) }
+			err = basicTemp.ExecuteTemplate(fieldUnmarshBuf, "struct_delta_unmarshal", data)
+			if err != nil { panic(err) }
+		} else {
+			f.Field.WriteMarshal(fieldMarshBuf)
+			f.Field.WriteUnmarshal(fieldUnmarshBuf)
+		}
+
+		err := basicTemp.ExecuteTemplate(marshBuf, "delta_field_marshal", map[string]any{
+			"Byte": f.Byte,
+			"Bit": f.Bit,
+			"AlwaysChanged": alwaysChanged,
+			"EqualityCode": equalityBuf.String(),
+			"MarshalCode": fieldMarshBuf.String(),
+		})
+		if err != nil { panic(err) }
+
+		err = basicTemp.ExecuteTemplate(unmarshBuf, "delta_field_unmarshal", map[string]any{
+			"Byte": f.Byte,
+			"Bit": f.Bit,
+			"UnmarshalCode": fieldUnmarshBuf.String(),
+			"CloneCode": cloneBuf.String(),
+		})
+		if err != nil { panic(err) }
+	}
+
+	maskSize := (len(fields) + 7) / 8
+	err := basicTemp.ExecuteTemplate(buf, "delta_marshal_func", map[string]any{
+		"Name": sd.Name,
+		"MaskSize": maskSize,
+		"MarshalCode": marshBuf.String(),
+	})
+	if err != nil { panic(err) }
+
+	err = basicTemp.ExecuteTemplate(buf, "delta_unmarshal_func", map[string]any{
+		"Name": sd.Name,
+		"MaskSize": maskSize,
+		"MarshalCode": unmarshBuf.String(),
+	})
+	if err != nil { panic(err) }
+}
+
+// Writes delta functions that always encode the full value. Used for types that can't be split into fields
+func WriteFullDelta(sd StructData, buf *bytes.Buffer) {
+	err := basicTemp.ExecuteTemplate(buf, "full_delta_funcs", map[string]any{
+		"Name": sd.Name,
+	})
+	if err != nil { panic(err) }
+}
+
+func WriteUnionDeltaCode(sd StructData, csv []string, structs map[string]StructData, buf *bytes.Buffer) {
+	unionFields := getUnionFields(csv, structs)
+	config := getUnionConfig(csv)
+
+	err := basicTemp.ExecuteTemplate(buf, "union_delta_funcs", map[string]any{
+		"Name": sd.Name,
+		"Fields": unionFields,
+		"TagApi": config.TagApi,
+	})
+	if err != nil { panic(err) }
+}
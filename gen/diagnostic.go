@@ -0,0 +1,79 @@
+package gen
+
+import (
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"runtime"
+)
+
+// Problems are collected as diagnostics instead of panicking, so that one bad type doesn't hide the others.
+// They are printed like compiler errors: file:line:col: error: message
+
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "error"
+	}
+}
+
+type Diagnostic struct {
+	Pos token.Position
+	Severity Severity
+	Msg string
+}
+
+func (d Diagnostic) String() string {
+	pos := d.Pos.String()
+	if pos == "-" {
+		return fmt.Sprintf("%s: %s", d.Severity, d.Msg)
+	}
+	return fmt.Sprintf("%s: %s: %s", pos, d.Severity, d.Msg)
+}
+
+func (v *Visitor) errorf(pos token.Pos, format string, a ...any) {
+	v.diags = append(v.diags, Diagnostic{v.fset.Position(pos), SeverityError, fmt.Sprintf(format, a...)})
+}
+
+func (v *Visitor) warnf(pos token.Pos, format string, a ...any) {
+	v.diags = append(v.diags, Diagnostic{v.fset.Position(pos), SeverityWarning, fmt.Sprintf(format, a...)})
+}
+
+// Deferred while generating a type. Reports a panic as an error at the type. Runtime errors are bugs in cod, so they still crash
+func (v *Visitor) recoverType(sd StructData) {
+	r := recover()
+	if r == nil { return }
+	if _, ok := r.(runtime.Error); ok { panic(r) }
+	v.errorf(sd.Pos, "%v", r)
+}
+
+// Returns true if any of the diagnostics are errors
+func HasErrors(diags []Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == SeverityError { return true }
+	}
+	return false
+}
+
+// Converts the errors returned by the parser
+func parseDiagnostics(err error) []Diagnostic {
+	list, ok := err.(scanner.ErrorList)
+	if !ok {
+		return []Diagnostic{{Severity: SeverityError, Msg: err.Error()}}
+	}
+
+	diags := make([]Diagnostic, 0, len(list))
+	for _, e := range list {
+		diags = append(diags, Diagnostic{e.Pos, SeverityError, e.Msg})
+	}
+	return diags
+}
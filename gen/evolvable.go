@@ -0,0 +1,126 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// Evolvable structs write each field as: uvarint field number, uvarint length, field data.
+// The struct ends with a zero field number. Decoders skip field numbers they don't know, and leave missing fields as their zero value.
+
+type numberedField struct {
+	Number int
+	Field Field
+}
+
+// Assigns a field number to each field. Fields tagged with `cod.tag:"N"` are pinned to N, all others use their position in the struct (starting at 1)
+func getFieldNumbers(sd StructData) []numberedField {
+	fields := make([]numberedField, 0, len(sd.Fields))
+	usedNumbers := make(map[int]string)
+	for i, f := range sd.Fields {
+		number := i+1
+		tagStr := tagSearchUnionTag(f.GetTag())
+		if tagStr != "" {
+			var err error
+			number, err = strconv.Atoi(tagStr)
+			if err != nil {
+				panic(fmt.Sprintf("struct %s: invalid field number for %s: %s", sd.Name, f.GetName(), tagStr))
+			}
+		}
+
+		if number <= 0 {
+			panic(fmt.Sprintf("struct %s: field number for %s must be greater than 0, got %d", sd.Name, f.GetName(), number))
+		}
+		otherName, used := usedNumbers[number]
+		if used {
+			panic(fmt.Sprintf("struct %s: field number %d is used by both %s and %s", sd.Name, number, otherName, f.GetName()))
+		}
+		usedNumbers[number] = f.GetName()
+
+		fields = append(fields, numberedField{number, f})
+	}
+	return fields
+}
+
+func GenerateEvolvableSerdesData(sd StructData, buf *bytes.Buffer) {
+	fields := getFieldNumbers(sd)
+
+	marshBuf := new(bytes.Buffer)
+	unmarshBuf := new(bytes.Buffer)
+	resetBuf := new(bytes.Buffer)
+	sizeBuf := new(bytes.Buffer)
+	numDecoded := 0
+	for _, f := range fields {
+		fieldMarshBuf := new(bytes.Buffer)
+		f.Field.WriteMarshal(fieldMarshBuf)
+		if fieldMarshBuf.Len() == 0 {
+			continue // Skipped field
+		}
+
+		fieldSizeBuf := new(bytes.Buffer)
+		f.Field.WriteSize(fieldSizeBuf)
+
+		fieldUnmarshBuf := new(bytes.Buffer)
+		f.Field.WriteUnmarshal(fieldUnmarshBuf)
+
+		err := basicTemp.ExecuteTemplate(marshBuf, "evolvable_field_marshal", map[string]any{
+			"Number": f.Number,
+			"SizeCode": fieldSizeBuf.String(),
+			"MarshalCode": fieldMarshBuf.String(),
+		})
+		if err != nil { panic(err) }
+
+		err = basicTemp.ExecuteTemplate(unmarshBuf, "evolvable_field_unmarshal", map[string]any{
+			"Number": f.Number,
+			"Index": numDecoded,
+			"MarshalCode": fieldUnmarshBuf.String(),
+		})
+		if err != nil { panic(err) }
+
+		kind := ""
+		switch f.Field.(type) {
+		case *SliceField:
+			kind = "slice"
+		case *MapField:
+			kind = "map"
+		}
+		err = basicTemp.ExecuteTemplate(resetBuf, "evolvable_field_reset", map[string]any{
+			"Index": numDecoded,
+			"Name": f.Field.GetName(),
+			"Type": f.Field.GetType(),
+			"Kind": kind,
+		})
+		if err != nil { panic(err) }
+		numDecoded++
+
+		err = basicTemp.ExecuteTemplate(sizeBuf, "evolvable_field_size", map[string]any{
+			"Number": f.Number,
+			"SizeCode": fieldSizeBuf.String(),
+		})
+		if err != nil { panic(err) }
+	}
+
+	err := basicTemp.ExecuteTemplate(buf, "evolvable_marshal_func", map[string]any{
+		"Name": sd.Name,
+		"MarshalCode": marshBuf.String(),
+	})
+	if err != nil { panic(err) }
+
+	err = basicTemp.ExecuteTemplate(buf, "evolvable_unmarshal_func", map[string]any{
+		"Name": sd.Name,
+		"MarshalCode": unmarshBuf.String(),
+		"ResetCode": resetBuf.String(),
+		"NumFields": numDecoded,
+	})
+	if err != nil { panic(err) }
+
+	WriteStructEquality(sd, buf)
+	WriteStructClone(sd, buf)
+
+	err = basicTemp.ExecuteTemplate(buf, "evolvable_size_func", map[string]any{
+		"Name": sd.Name,
+		"InnerCode": sizeBuf.String(),
+	})
+	if err != nil { panic(err) }
+}
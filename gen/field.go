@@ -0,0 +1,1022 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// TODO: Maybe?
+// type FieldType uint16
+// const (
+// 	FieldNone FieldType = iota
+// 	FieldUint8
+// 	FieldUint16
+// 	FieldUint32
+// 	FieldUint64
+// 	FieldInt8
+// 	FieldInt16
+// 	FieldInt32
+// 	FieldInt64
+
+// 	FieldString
+// 	FieldStruct
+// )
+
+
+type Field interface {
+	SetTag(string)
+	GetTag() string
+	GetName() string
+	SetName(string)
+	GetType() string
+
+	// TODO: Remove these
+	WriteEquality(*bytes.Buffer)
+	WriteClone(*bytes.Buffer)
+	WriteMarshal(*bytes.Buffer)
+	WriteUnmarshal(*bytes.Buffer)
+	WriteSize(*bytes.Buffer)
+}
+
+type BasicField struct {
+	Name string
+	Type string
+	Underlying string // The basic type that Type resolves to, if Type is a named type
+	Limited bool // If true, the type has a DecodeCodWithLimits function that the decode limits can be passed to
+	NoSize bool // If true, the type has hand written encoders without CodSize, so its size is the length of its encoding
+	NoClone bool // If true, the type has hand written encoders without CodClone, so it is copied by assignment
+	NoDelta bool // If true, the type has hand written encoders without EncodeCodDelta and DecodeCodDelta, so it is always written in full
+	Tag string
+}
+
+func (f *BasicField) GetName() string {
+	return f.Name
+}
+func (f *BasicField) SetName(name string) {
+	f.Name = name
+}
+
+func (f *BasicField) SetTag(tag string) {
+	f.Tag = tag
+}
+func (f *BasicField) GetTag() string {
+	return f.Tag
+}
+func (f *BasicField) GetType() string {
+	return f.Type
+}
+
+// Returns the backend api name and the type that needs to be passed to it.
+// The cast type is empty if the field type can be passed directly
+func (f BasicField) getApi() (string, string, bool) {
+	cast := tagSearchCast(f.Tag)
+	debugPrintln("Cast: ", cast)
+	if cast == "" {
+		cast = f.Underlying
+	}
+
+	apiType := f.Type
+	if cast != "" {
+		apiType = cast
+	}
+
+	if shouldUseFixed(f.Tag) {
+		fixed, ok := fixedApis[apiType]
+		if ok {
+			if fixed.Type != apiType {
+				cast = fixed.Type
+			}
+			return fixed.ApiName, cast, true
+		}
+	}
+
+	apiName, supported := supportedApis[apiType]
+	return apiName, cast, supported
+}
+
+func (f BasicField) WriteEquality(buf *bytes.Buffer) {
+	skip := tagSearchSkip(f.Tag)
+	debugPrintln("Skip: ", skip)
+
+	// Don't add if this is set to skip
+	if shouldSkipEquality(skip) {
+		return
+	}
+
+	apiName, _, supported := f.getApi()
+	if supported {
+		err := basicTemp.ExecuteTemplate(buf, "basic_equality", map[string]any{
+			"Name": f.Name,
+			"Name2": "t"+f.Name,
+			"ApiName": apiName,
+		})
+		if err != nil { panic(err) }
+	} else {
+		// debugPrintln("Found Struct: ", f.Name)
+		err := basicTemp.ExecuteTemplate(buf, "struct_equality", map[string]any{
+			"Name": f.Name,
+			"Name2": "t"+f.Name,
+		})
+		if err != nil { panic(err) }
+	}
+}
+
+func (f BasicField) WriteClone(buf *bytes.Buffer) {
+	if shouldSkipEquality(tagSearchSkip(f.Tag)) { return }
+
+	_, _, supported := f.getApi()
+	templateName := "struct_clone"
+	if supported || f.NoClone {
+		templateName = "basic_clone"
+	}
+	err := basicTemp.ExecuteTemplate(buf, templateName, map[string]any{
+		"Name": f.Name,
+		"Name2": "c"+f.Name,
+	})
+	if err != nil { panic(err) }
+}
+
+func (f BasicField) WriteMarshal(buf *bytes.Buffer) {
+	// Don't add if this is set to skip
+	if shouldSkipSerdes(f.Tag) {
+		return
+	}
+
+	apiName, cast, supported := f.getApi()
+	if supported {
+		err := basicTemp.ExecuteTemplate(buf, "basic_marshal", map[string]any{
+			"Name": f.Name,
+			"ApiName": apiName,
+			"Cast": cast,
+		})
+		if err != nil { panic(err) }
+	} else {
+		// debugPrintln("Found Struct: ", f.Name)
+		err := basicTemp.ExecuteTemplate(buf, "struct_marshal", map[string]any{
+			"Name": f.Name,
+		})
+		if err != nil { panic(err) }
+	}
+}
+
+func (f BasicField) WriteUnmarshal(buf *bytes.Buffer) {
+	if shouldSkipSerdes(f.Tag) { return }
+
+	apiName, cast, supported := f.getApi()
+	apiType := f.Type
+	if cast != "" {
+		// For unmarshal, we reverse the cast with the underlying type, because we need to decode the casted type then cast it to the underlying type
+		apiType = cast
+		cast = f.Type
+	}
+
+	if supported {
+		err := basicTemp.ExecuteTemplate(buf, "basic_unmarshal", map[string]any{
+			"Name": f.Name,
+			"ApiName": apiName,
+			"Type": apiType,
+			"Cast": cast,
+			// "Type": f.GetType(),
+			// "Cast": cast,
+		})
+		if err != nil { panic(err) }
+	} else {
+		// debugPrintln("Found Struct: ", f.Name)
+		err := basicTemp.ExecuteTemplate(buf, "struct_unmarshal", map[string]any{
+			"Name": f.Name,
+			"Type": f.GetType(),
+			"Limited": f.Limited,
+		})
+		if err != nil { panic(err) }
+	}
+
+
+	// pointerStar := "reg"
+	// if f.Pointer { pointerStar = "ptr" }
+
+	// templateName := fmt.Sprintf("%s_%s_unmarshal", pointerStar, f.Type)
+	// err := basicTemp.ExecuteTemplate(buf, templateName, map[string]any{
+	// 	"Name": f.Name,
+	// })
+	// if err != nil {
+	// 	debugPrintln("Couldn't find type, assuming its a struct: ", f.Name)
+	// 	templateName := fmt.Sprintf("%s_%s_unmarshal", pointerStar, "struct")
+	// 	err := basicTemp.ExecuteTemplate(buf, templateName, map[string]any{
+	// 		"Name": f.Name,
+	// 	})
+	// 	if err != nil { panic(err) }
+	// }
+}
+
+func (f BasicField) WriteSize(buf *bytes.Buffer) {
+	if shouldSkipSerdes(f.Tag) { return }
+
+	apiName, cast, supported := f.getApi()
+	if supported {
+		err := basicTemp.ExecuteTemplate(buf, "basic_size", map[string]any{
+			"Name": f.Name,
+			"ApiName": apiName,
+			"Cast": cast,
+		})
+		if err != nil { panic(err) }
+	} else {
+		templateName := "struct_size"
+		if f.NoSize {
+			templateName = "encoded_size"
+		}
+		err := basicTemp.ExecuteTemplate(buf, templateName, map[string]any{
+			"Name": f.Name,
+		})
+		if err != nil { panic(err) }
+	}
+}
+
+// A field whose type is a type parameter of a generic struct.
+// The type parameter is constrained to cod.Codec, so its methods are called directly. It is decoded in place through its pointer type parameter, which is constrained to cod.CodecPtr
+type TypeParamField struct {
+	Name string
+	Type string
+	Ptr string // The pointer type parameter of Type
+	Tag string
+}
+
+func (f *TypeParamField) GetName() string {
+	return f.Name
+}
+func (f *TypeParamField) SetName(name string) {
+	f.Name = name
+}
+func (f *TypeParamField) SetTag(tag string) {
+	f.Tag = tag
+}
+func (f *TypeParamField) GetTag() string {
+	return f.Tag
+}
+func (f *TypeParamField) GetType() string {
+	return f.Type
+}
+
+func (f TypeParamField) WriteEquality(buf *bytes.Buffer) {
+	if shouldSkipEquality(tagSearchSkip(f.Tag)) { return }
+
+	err := basicTemp.ExecuteTemplate(buf, "struct_equality", map[string]any{
+		"Name": f.Name,
+		"Name2": "t"+f.Name,
+	})
+	if err != nil { panic(err) }
+}
+
+func (f TypeParamField) WriteClone(buf *bytes.Buffer) {
+	if shouldSkipEquality(tagSearchSkip(f.Tag)) { return }
+
+	err := basicTemp.ExecuteTemplate(buf, "struct_clone", map[string]any{
+		"Name": f.Name,
+		"Name2": "c"+f.Name,
+	})
+	if err != nil { panic(err) }
+}
+
+func (f TypeParamField) WriteMarshal(buf *bytes.Buffer) {
+	if shouldSkipSerdes(f.Tag) { return }
+
+	err := basicTemp.ExecuteTemplate(buf, "struct_marshal", map[string]any{
+		"Name": f.Name,
+	})
+	if err != nil { panic(err) }
+}
+
+func (f TypeParamField) WriteUnmarshal(buf *bytes.Buffer) {
+	if shouldSkipSerdes(f.Tag) { return }
+
+	err := basicTemp.ExecuteTemplate(buf, "type_param_unmarshal", map[string]any{
+		"Name": f.Name,
+		"Ptr": f.Ptr,
+	})
+	if err != nil { panic(err) }
+}
+
+func (f TypeParamField) WriteSize(buf *bytes.Buffer) {
+	if shouldSkipSerdes(f.Tag) { return }
+
+	err := basicTemp.ExecuteTemplate(buf, "struct_size", map[string]any{
+		"Name": f.Name,
+	})
+	if err != nil { panic(err) }
+}
+
+type ArrayField struct {
+	Name string
+	Field Field
+	Len string
+	Tag string
+	IndexDepth int
+}
+
+func (f *ArrayField) GetName() string {
+	return f.Name
+}
+func (f *ArrayField) SetName(name string) {
+	f.Name = name
+}
+func (f *ArrayField) SetTag(tag string) {
+	f.Tag = tag
+	f.Field.SetTag(tag)
+}
+func (f *ArrayField) GetTag() string {
+	return f.Tag
+}
+func (f *ArrayField) GetType() string {
+	return fmt.Sprintf("[%s]%s", f.Len, f.Field.GetType())
+}
+
+func (f ArrayField) WriteEquality(buf *bytes.Buffer) {
+	if shouldSkipEquality(tagSearchSkip(f.Tag)) { return }
+
+	innerBuf := new(bytes.Buffer)
+	f.Field.WriteEquality(innerBuf)
+
+
+	err := basicTemp.ExecuteTemplate(buf, "array_equality", map[string]any{
+		"Name": f.Name,
+		"Name2": "t"+f.Name,
+		"Index": fmt.Sprintf("i%d", f.IndexDepth),
+		"InnerCode": string(innerBuf.Bytes()),
+	})
+	if err != nil { panic(err) }
+}
+
+func (f ArrayField) WriteClone(buf *bytes.Buffer) {
+	if shouldSkipEquality(tagSearchSkip(f.Tag)) { return }
+
+	innerBuf := new(bytes.Buffer)
+	f.Field.WriteClone(innerBuf)
+
+	err := basicTemp.ExecuteTemplate(buf, "array_clone", map[string]any{
+		"Name": f.Name,
+		"Index": fmt.Sprintf("i%d", f.IndexDepth),
+		"InnerCode": string(innerBuf.Bytes()),
+	})
+	if err != nil { panic(err) }
+}
+
+func (f ArrayField) WriteMarshal(buf *bytes.Buffer) {
+	if shouldSkipSerdes(f.Tag) { return }
+
+	innerBuf := new(bytes.Buffer)
+	f.Field.WriteMarshal(innerBuf)
+
+
+	err := basicTemp.ExecuteTemplate(buf, "array_marshal", map[string]any{
+		"Name": f.Name,
+		"Index": fmt.Sprintf("i%d", f.IndexDepth),
+		"InnerCode": string(innerBuf.Bytes()),
+	})
+	if err != nil { panic(err) }
+}
+
+
+func (f ArrayField) WriteUnmarshal(buf *bytes.Buffer) {
+	if shouldSkipSerdes(f.Tag) { return }
+
+	innerBuf := new(bytes.Buffer)
+	f.Field.WriteUnmarshal(innerBuf)
+
+	err := basicTemp.ExecuteTemplate(buf, "array_unmarshal", map[string]any{
+		"Name": f.Name,
+		"Index": fmt.Sprintf("i%d", f.IndexDepth),
+		"InnerCode": string(innerBuf.Bytes()),
+	})
+	if err != nil { panic(err) }
+
+}
+
+func (f ArrayField) WriteSize(buf *bytes.Buffer) {
+	if shouldSkipSerdes(f.Tag) { return }
+
+	innerBuf := new(bytes.Buffer)
+	f.Field.WriteSize(innerBuf)
+
+	err := basicTemp.ExecuteTemplate(buf, "array_size", map[string]any{
+		"Name": f.Name,
+		"Index": fmt.Sprintf("i%d", f.IndexDepth),
+		"InnerCode": innerBuf.String(),
+	})
+	if err != nil { panic(err) }
+}
+
+type SliceField struct {
+	Name string
+	// Type string
+	Field Field
+	Tag string
+	IndexDepth int
+}
+
+func (f *SliceField) GetName() string {
+	return f.Name
+}
+func (f *SliceField) SetName(name string) {
+	f.Name = name
+}
+func (f *SliceField) SetTag(tag string) {
+	f.Tag = tag
+	f.Field.SetTag(tag)
+}
+func (f *SliceField) GetTag() string {
+	return f.Tag
+}
+func (f *SliceField) GetType() string {
+	return fmt.Sprintf("[]%s", f.Field.GetType())
+}
+
+func (f SliceField) WriteEquality(buf *bytes.Buffer) {
+	if shouldSkipEquality(tagSearchSkip(f.Tag)) { return }
+
+	innerBuf := new(bytes.Buffer)
+	idxVar := fmt.Sprintf("i%d", f.IndexDepth)
+	f.Field.SetName(fmt.Sprintf("%s[%s]", f.Name, idxVar))
+	f.Field.WriteEquality(innerBuf)
+
+	err := basicTemp.ExecuteTemplate(buf, "slice_equality", map[string]any{
+		"Name": f.Name,
+		"Name2": "t"+f.Name,
+		"Type": f.Field.GetType(),
+		"Index": idxVar,
+		"InnerCode": string(innerBuf.Bytes()),
+	})
+	if err != nil { panic(err) }
+}
+
+func (f SliceField) WriteClone(buf *bytes.Buffer) {
+	if shouldSkipEquality(tagSearchSkip(f.Tag)) { return }
+
+	innerBuf := new(bytes.Buffer)
+	idxVar := fmt.Sprintf("i%d", f.IndexDepth)
+	f.Field.SetName(fmt.Sprintf("%s[%s]", f.Name, idxVar))
+	f.Field.WriteClone(innerBuf)
+
+	err := basicTemp.ExecuteTemplate(buf, "slice_clone", map[string]any{
+		"Name": f.Name,
+		"Name2": "c"+f.Name,
+		"Type": f.Field.GetType(),
+		"Index": idxVar,
+		"InnerCode": string(innerBuf.Bytes()),
+	})
+	if err != nil { panic(err) }
+}
+
+func (f SliceField) WriteMarshal(buf *bytes.Buffer) {
+	if shouldSkipSerdes(f.Tag) { return }
+
+	innerBuf := new(bytes.Buffer)
+	idxVar := fmt.Sprintf("i%d", f.IndexDepth)
+	f.Field.SetName(fmt.Sprintf("%s[%s]", f.Name, idxVar))
+	f.Field.WriteMarshal(innerBuf)
+
+	err := basicTemp.ExecuteTemplate(buf, "slice_marshal", map[string]any{
+		"Name": f.Name,
+		"Type": f.Field.GetType(),
+		"Index": idxVar,
+		"InnerCode": string(innerBuf.Bytes()),
+	})
+	if err != nil { panic(err) }
+}
+
+
+func (f SliceField) WriteUnmarshal(buf *bytes.Buffer) {
+	if shouldSkipSerdes(f.Tag) { return }
+
+	// Elements are decoded in place, so that the slices and maps of the previous elements are reused
+	innerBuf := new(bytes.Buffer)
+	idxVar := fmt.Sprintf("i%d", f.IndexDepth)
+	f.Field.SetName(fmt.Sprintf("%s[%s]", f.Name, idxVar))
+	f.Field.WriteUnmarshal(innerBuf)
+
+	// debugPrintln("GETTYPE: ", f.Field.GetType())
+	err := basicTemp.ExecuteTemplate(buf, "slice_unmarshal", map[string]any{
+		"Name": f.Name,
+		"Type": f.Field.GetType(),
+		"Index": idxVar,
+		"InnerCode": string(innerBuf.Bytes()),
+	})
+	if err != nil { panic(err) }
+
+}
+
+func (f SliceField) WriteSize(buf *bytes.Buffer) {
+	if shouldSkipSerdes(f.Tag) { return }
+
+	innerBuf := new(bytes.Buffer)
+	idxVar := fmt.Sprintf("i%d", f.IndexDepth)
+	f.Field.SetName(fmt.Sprintf("%s[%s]", f.Name, idxVar))
+	f.Field.WriteSize(innerBuf)
+
+	err := basicTemp.ExecuteTemplate(buf, "slice_size", map[string]any{
+		"Name": f.Name,
+		"Index": idxVar,
+		"InnerCode": innerBuf.String(),
+	})
+	if err != nil { panic(err) }
+}
+
+type MapField struct {
+	Name string
+	Key Field
+	Val Field
+	Tag string
+	IndexDepth int
+	Deterministic bool // If true, the map is encoded in sorted key order
+}
+
+func (f *MapField) GetName() string {
+	return f.Name
+}
+func (f *MapField) SetName(name string) {
+	f.Name = name
+}
+func (f *MapField) SetTag(tag string) {
+	f.Tag = tag
+	// The tag overrides the package default in both directions
+	switch tagSearchDeterministic(tag) {
+	case "true":
+		f.Deterministic = true
+	case "false":
+		f.Deterministic = false
+	}
+	f.Key.SetTag(tag)
+	f.Val.SetTag(tag)
+}
+func (f *MapField) GetTag() string {
+	return f.Tag
+}
+func (f *MapField) GetType() string {
+	return fmt.Sprintf("map[%s]%s", f.Key.GetType(), f.Val.GetType())
+}
+
+func (f MapField) WriteEquality(buf *bytes.Buffer) {
+	if shouldSkipEquality(tagSearchSkip(f.Tag)) { return }
+
+	innerBuf := new(bytes.Buffer)
+
+	keyIdxName := fmt.Sprintf("k%d", f.IndexDepth)
+	// f.Key.SetName(keyIdxName)
+	// f.Key.WriteEquality(innerBuf)
+
+	valIdxName := fmt.Sprintf("v%d", f.IndexDepth)
+	f.Val.SetName(valIdxName)
+	f.Val.WriteEquality(innerBuf)
+
+	err := basicTemp.ExecuteTemplate(buf, "map_equality", map[string]any{
+		"Name": f.Name,
+		"Name2": "t"+f.Name,
+		"KeyIdx": keyIdxName,
+		"ValIdx": valIdxName,
+		"InnerCode": string(innerBuf.Bytes()),
+	})
+	if err != nil { panic(err) }
+}
+
+func (f MapField) WriteClone(buf *bytes.Buffer) {
+	if shouldSkipEquality(tagSearchSkip(f.Tag)) { return }
+
+	innerBuf := new(bytes.Buffer)
+
+	// Note: Keys are comparable, so they are always copied by value
+	keyIdxName := fmt.Sprintf("k%d", f.IndexDepth)
+	valIdxName := fmt.Sprintf("v%d", f.IndexDepth)
+	f.Val.SetName(valIdxName)
+	f.Val.WriteClone(innerBuf)
+
+	err := basicTemp.ExecuteTemplate(buf, "map_clone", map[string]any{
+		"Name": f.Name,
+		"Name2": "c"+f.Name,
+		"Type": f.GetType(),
+		"KeyIdx": keyIdxName,
+		"ValIdx": valIdxName,
+		"ValType": f.Val.GetType(),
+		"InnerCode": string(innerBuf.Bytes()),
+	})
+	if err != nil { panic(err) }
+}
+
+func (f MapField) WriteMarshal(buf *bytes.Buffer) {
+	if shouldSkipSerdes(f.Tag) { return }
+
+	innerBuf := new(bytes.Buffer)
+
+	keyIdxName := fmt.Sprintf("k%d", f.IndexDepth)
+	f.Key.SetName(keyIdxName)
+	f.Key.WriteMarshal(innerBuf)
+
+	valIdxName := fmt.Sprintf("v%d", f.IndexDepth)
+	f.Val.SetName(valIdxName)
+	f.Val.WriteMarshal(innerBuf)
+
+	// Keys that can't be ordered (ie structs) are sorted by their encoded bytes instead
+	templateName := "map_marshal"
+	if f.Deterministic {
+		templateName = "map_marshal_encoded"
+		if isOrderedKey(f.Key) {
+			templateName = "map_marshal_sorted"
+		}
+	}
+
+	err := basicTemp.ExecuteTemplate(buf, templateName, map[string]any{
+		"Name": f.Name,
+		"KeyIdx": keyIdxName,
+		"ValIdx": valIdxName,
+		"KeyType": f.Key.GetType(),
+		"ValType": f.Val.GetType(),
+		"InnerCode": string(innerBuf.Bytes()),
+	})
+	if err != nil { panic(err) }
+}
+
+
+func (f MapField) WriteUnmarshal(buf *bytes.Buffer) {
+	if shouldSkipSerdes(f.Tag) { return }
+
+	innerBuf := new(bytes.Buffer)
+	keyVarName := fmt.Sprintf("key%d", f.IndexDepth)
+	f.Key.SetName(keyVarName)
+	f.Key.WriteUnmarshal(innerBuf)
+
+	valVarName := fmt.Sprintf("val%d", f.IndexDepth)
+	f.Val.SetName(valVarName)
+	f.Val.WriteUnmarshal(innerBuf)
+
+	// debugPrintln("GETTYPE: ", f.GetType(), f.Key.GetType(), f.Val.GetType())
+	err := basicTemp.ExecuteTemplate(buf, "map_unmarshal", map[string]any{
+		"Name": f.Name,
+		"Type": f.GetType(),
+		"KeyVar": keyVarName,
+		"ValVar": valVarName,
+		"KeyType": f.Key.GetType(),
+		"ValType": f.Val.GetType(),
+		"InnerCode": string(innerBuf.Bytes()),
+
+		"Index": fmt.Sprintf("i%d", f.IndexDepth),
+	})
+	if err != nil { panic(err) }
+
+}
+
+func (f MapField) WriteSize(buf *bytes.Buffer) {
+	if shouldSkipSerdes(f.Tag) { return }
+
+	innerBuf := new(bytes.Buffer)
+
+	keyIdxName := fmt.Sprintf("k%d", f.IndexDepth)
+	f.Key.SetName(keyIdxName)
+	f.Key.WriteSize(innerBuf)
+
+	valIdxName := fmt.Sprintf("v%d", f.IndexDepth)
+	f.Val.SetName(valIdxName)
+	f.Val.WriteSize(innerBuf)
+
+	err := basicTemp.ExecuteTemplate(buf, "map_size", map[string]any{
+		"Name": f.Name,
+		"KeyIdx": keyIdxName,
+		"ValIdx": valIdxName,
+		"InnerCode": innerBuf.String(),
+	})
+	if err != nil { panic(err) }
+}
+
+// Returns true if the field can be sorted by the backend
+func isOrderedKey(f Field) bool {
+	basic, ok := f.(*BasicField)
+	if !ok { return false }
+	apiName, _, supported := basic.getApi()
+	return supported && apiName != "Bool"
+}
+
+type AliasField struct {
+	Name string
+	AliasType string
+	Field Field
+	Tag string
+	IndexDepth int
+}
+
+func (f *AliasField) GetName() string {
+	return f.Name
+}
+func (f *AliasField) SetName(name string) {
+	f.Name = name
+}
+func (f *AliasField) SetTag(tag string) {
+	f.Tag = tag
+	f.Field.SetTag(tag)
+}
+func (f *AliasField) GetTag() string {
+	return f.Tag
+}
+func (f *AliasField) GetType() string {
+	return fmt.Sprintf("%s", f.Field.GetType())
+}
+
+func (f AliasField) WriteEquality(buf *bytes.Buffer) {
+	innerBuf := new(bytes.Buffer)
+
+	valName := fmt.Sprintf("value%d", f.IndexDepth)
+	f.Field.SetName(valName)
+	f.Field.WriteEquality(innerBuf)
+
+	err := basicTemp.ExecuteTemplate(buf, "alias_equality", map[string]any{
+		"Name": f.Name,
+		"Name2": "t"+f.Name,
+		"AliasType": f.Name,
+		"Type": f.GetType(),
+		"ValName": valName,
+		"InnerCode": string(innerBuf.Bytes()),
+	})
+	if err != nil { panic(err) }
+}
+
+func (f AliasField) WriteClone(buf *bytes.Buffer) {
+	innerBuf := new(bytes.Buffer)
+
+	valName := fmt.Sprintf("value%d", f.IndexDepth)
+	f.Field.SetName(valName)
+	f.Field.WriteClone(innerBuf)
+
+	err := basicTemp.ExecuteTemplate(buf, "alias_clone", map[string]any{
+		"Name": f.Name,
+		"Name2": "c"+f.Name,
+		"AliasType": f.AliasType,
+		"Type": f.GetType(),
+		"ValName": valName,
+		"InnerCode": string(innerBuf.Bytes()),
+	})
+	if err != nil { panic(err) }
+}
+
+func (f AliasField) WriteMarshal(buf *bytes.Buffer) {
+	if shouldSkipSerdes(f.Tag) { return }
+
+	innerBuf := new(bytes.Buffer)
+
+	valName := fmt.Sprintf("value%d", f.IndexDepth)
+	f.Field.SetName(valName)
+	f.Field.WriteMarshal(innerBuf)
+
+	err := basicTemp.ExecuteTemplate(buf, "alias_marshal", map[string]any{
+		"Name": f.Name,
+		"AliasType": f.Name,
+		"Type": f.GetType(),
+		"ValName": valName,
+		"InnerCode": string(innerBuf.Bytes()),
+	})
+	if err != nil { panic(err) }
+}
+
+
+func (f AliasField) WriteUnmarshal(buf *bytes.Buffer) {
+	if shouldSkipSerdes(f.Tag) { return }
+
+	innerBuf := new(bytes.Buffer)
+	valName := fmt.Sprintf("value%d", f.IndexDepth)
+	f.Field.SetName(valName)
+	f.Field.WriteUnmarshal(innerBuf)
+
+	// debugPrintln("ALIAS_GETTYPE: ", f.GetType(), f.Field.GetType())
+	err := basicTemp.ExecuteTemplate(buf, "alias_unmarshal", map[string]any{
+		"Name": f.Name,
+		"AliasType": f.AliasType,
+		"Type": f.GetType(),
+		"ValName": valName,
+		"ValType": f.Field.GetType(),
+		"InnerCode": string(innerBuf.Bytes()),
+	})
+	if err != nil { panic(err) }
+
+}
+
+func (f AliasField) WriteSize(buf *bytes.Buffer) {
+	if shouldSkipSerdes(f.Tag) { return }
+
+	innerBuf := new(bytes.Buffer)
+
+	valName := fmt.Sprintf("value%d", f.IndexDepth)
+	f.Field.SetName(valName)
+	f.Field.WriteSize(innerBuf)
+
+	err := basicTemp.ExecuteTemplate(buf, "alias_size", map[string]any{
+		"Name": f.Name,
+		"Type": f.GetType(),
+		"ValName": valName,
+		"InnerCode": innerBuf.String(),
+	})
+	if err != nil { panic(err) }
+}
+
+func NewUnionField(field Field, tag int) UnionField {
+	return UnionField{
+		Name: field.GetName(), // TODO: Is this even needed?
+		UnionTag: tag,
+		Field: field,
+	}
+}
+type UnionField struct {
+	Name string // TODO: Is this even needed?
+	UnionTag int // This is the actual ID used to tag the data in the union
+	Variant string // The name used in the generated type-safe getters and setters
+	Tag string // This is the tag string after a specific field
+	Field Field
+	// IndexDepth int
+}
+
+func (f *UnionField) GetName() string {
+	return f.Name
+}
+func (f *UnionField) SetName(name string) {
+	f.Name = name
+}
+func (f *UnionField) SetTag(tag string) {
+	f.Tag = tag
+	f.Field.SetTag(tag)
+}
+func (f *UnionField) GetTag() string {
+	return f.Tag
+}
+func (f *UnionField) GetType() string {
+	return f.Field.GetType()
+}
+
+func (f UnionField) WriteEquality(buf *bytes.Buffer) {
+	err := basicTemp.ExecuteTemplate(buf, "union_case_equality", map[string]any{
+		"Name": f.Name,
+		"Name2": "t"+f.Name,
+		"Type": f.GetType(),
+		"Tag": f.UnionTag,
+	})
+	if err != nil { panic(err) }
+}
+
+//TODO: you could probably support basic types by just marshalling the f.Field code and putting it in the union case statement
+// Returns true if the variant has its own delta functions, used by the union delta template
+func (f UnionField) HasDelta() bool {
+	return hasDeltaFuncs(f.Field)
+}
+
+func (f UnionField) WriteClone(buf *bytes.Buffer) {
+	basic, ok := f.Field.(*BasicField)
+	err := basicTemp.ExecuteTemplate(buf, "union_case_clone", map[string]any{
+		"Type": f.GetType(),
+		"Variant": f.Variant,
+		"NoClone": ok && basic.NoClone,
+	})
+	if err != nil { panic(err) }
+}
+
+func (f UnionField) WriteMarshal(buf *bytes.Buffer) {
+	err := basicTemp.ExecuteTemplate(buf, "union_case_marshal", map[string]any{
+		"Name": f.Name,
+		"Type": f.GetType(),
+		"Tag": f.UnionTag,
+	})
+	if err != nil { panic(err) }
+}
+
+
+func (f UnionField) WriteUnmarshal(buf *bytes.Buffer) {
+	// debugPrintln("ALIAS_GETTYPE: ", f.GetType(), f.Field.GetType())
+	basic, ok := f.Field.(*BasicField)
+	err := basicTemp.ExecuteTemplate(buf, "union_case_unmarshal", map[string]any{
+		"Name": f.Name,
+		"Type": f.GetType(),
+		"Tag": f.UnionTag,
+		"Variant": f.Variant,
+		"Limited": ok && basic.Limited,
+	})
+	if err != nil { panic(err) }
+}
+
+func (f UnionField) WriteSize(buf *bytes.Buffer) {
+	basic, ok := f.Field.(*BasicField)
+	err := basicTemp.ExecuteTemplate(buf, "union_case_size", map[string]any{
+		"Name": f.Name,
+		"Type": f.GetType(),
+		"Tag": f.UnionTag,
+		"NoSize": ok && basic.NoSize,
+	})
+	if err != nil { panic(err) }
+}
+
+type PointerField struct {
+	Name string
+	Field Field
+	IndexDepth int
+}
+
+func (f *PointerField) GetName() string {
+	return f.Name
+}
+func (f *PointerField) SetName(name string) {
+	f.Name = name
+}
+func (f *PointerField) SetTag(tag string) {
+	f.Field.SetTag(tag)
+}
+func (f *PointerField) GetTag() string {
+	return f.Field.GetTag()
+}
+func (f *PointerField) GetType() string {
+	return f.Field.GetType()
+}
+
+func (f PointerField) WriteEquality(buf *bytes.Buffer) {
+	if shouldSkipEquality(tagSearchSkip(f.GetTag())) { return }
+
+	innerBuf := new(bytes.Buffer)
+
+	valName := fmt.Sprintf("value%d", f.IndexDepth)
+	f.Field.SetName(valName)
+	f.Field.WriteEquality(innerBuf)
+
+	err := basicTemp.ExecuteTemplate(buf, "pointer_equality", map[string]any{
+		"Name": f.Name,
+		"Name2": "t"+f.Name,
+		"Type": f.GetType(),
+		"ValName": valName,
+		"InnerCode": innerBuf.String(),
+	})
+	if err != nil { panic(err) }
+}
+
+//TODO: you could probably support basic types by just marshalling the f.Field code and putting it in the union case statement
+func (f PointerField) WriteClone(buf *bytes.Buffer) {
+	if shouldSkipEquality(tagSearchSkip(f.GetTag())) { return }
+
+	innerBuf := new(bytes.Buffer)
+
+	valName := fmt.Sprintf("value%d", f.IndexDepth)
+	f.Field.SetName(valName)
+	f.Field.WriteClone(innerBuf)
+
+	err := basicTemp.ExecuteTemplate(buf, "pointer_clone", map[string]any{
+		"Name": f.Name,
+		"Name2": "c"+f.Name,
+		"ValName": valName,
+		"ValType": f.Field.GetType(),
+		"InnerCode": innerBuf.String(),
+	})
+	if err != nil { panic(err) }
+}
+
+func (f PointerField) WriteMarshal(buf *bytes.Buffer) {
+	// TODO: f.Field has the tag
+	// if shouldSkipSerdes(f.Tag) { return }
+
+	innerBuf := new(bytes.Buffer)
+
+	valName := fmt.Sprintf("value%d", f.IndexDepth)
+	f.Field.SetName(valName)
+	f.Field.WriteMarshal(innerBuf)
+
+
+	err := basicTemp.ExecuteTemplate(buf, "pointer_marshal", map[string]any{
+		"Name": f.Name,
+		"Type": f.GetType(),
+		"ValName": valName,
+		"InnerCode": innerBuf.String(),
+	})
+	if err != nil { panic(err) }
+}
+
+
+func (f PointerField) WriteUnmarshal(buf *bytes.Buffer) {
+	// TODO: f.Field has the tag
+	// if shouldSkipSerdes(f.Tag) { return }
+
+	innerBuf := new(bytes.Buffer)
+	valName := fmt.Sprintf("value%d", f.IndexDepth)
+	f.Field.SetName(valName)
+	f.Field.WriteUnmarshal(innerBuf)
+
+	// debugPrintln("ALIAS_GETTYPE: ", f.GetType(), f.Field.GetType())
+	err := basicTemp.ExecuteTemplate(buf, "pointer_unmarshal", map[string]any{
+		"Name": f.Name,
+		"Type": f.GetType(),
+		"ValName": valName,
+		"ValType": f.Field.GetType(),
+		"InnerCode": innerBuf.String(),
+	})
+	if err != nil { panic(err) }
+}
+
+func (f PointerField) WriteSize(buf *bytes.Buffer) {
+	innerBuf := new(bytes.Buffer)
+
+	valName := fmt.Sprintf("value%d", f.IndexDepth)
+	f.Field.SetName(valName)
+	f.Field.WriteSize(innerBuf)
+
+	err := basicTemp.ExecuteTemplate(buf, "pointer_size", map[string]any{
+		"Name": f.Name,
+		"ValName": valName,
+		"InnerCode": innerBuf.String(),
+	})
+	if err != nil { panic(err) }
+}
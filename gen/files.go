@@ -0,0 +1,28 @@
+package gen
+
+import (
+	"go/build"
+	"io/fs"
+	"strings"
+)
+
+// Returns the build context used to select files
+func (cfg Config) buildContext() build.Context {
+	ctx := build.Default
+	if cfg.GOOS != "" { ctx.GOOS = cfg.GOOS }
+	if cfg.GOARCH != "" { ctx.GOARCH = cfg.GOARCH }
+	ctx.BuildTags = append(ctx.BuildTags[:len(ctx.BuildTags):len(ctx.BuildTags)], cfg.Tags...)
+	return ctx
+}
+
+// Returns a filter for the files of a package. Test files, generated files, and files excluded by build constraints are left out.
+// Note: The generated files are left out, so that a broken or stale one doesn't stop it from being regenerated
+func fileFilter(ctx build.Context, dir string) func(fs.FileInfo) bool {
+	return func(fi fs.FileInfo) bool {
+		name := fi.Name()
+		if name == generatedFileName || name == fuzzFileName { return false }
+		if strings.HasSuffix(name, "_test.go") { return false }
+		match, err := ctx.MatchFile(dir, name)
+		return err == nil && match
+	}
+}
@@ -0,0 +1,45 @@
+package gen
+
+import (
+	"bytes"
+	"go/token"
+	"sort"
+)
+
+const fuzzFileName = "cod_fuzz_test.go"
+
+// Returns a file with a fuzz test for every struct and union that gets a codec, or nil if there are none. Generic types are skipped because they have no concrete type to fuzz
+func (v *Visitor) OutputFuzz() []byte {
+	names := make([]string, 0)
+	for name, sd := range v.structs {
+		if len(sd.TypeParams) > 0 { continue }
+		for _, req := range v.requests[name] {
+			if req.Type == RequestTypeSerdes || req.Type == RequestTypeUnion {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil // Skip: nothing to fuzz
+	}
+	sort.Strings(names)
+
+	buf := new(bytes.Buffer)
+	buf.WriteString(GeneratedHeader + "\n")
+	buf.WriteString("package " + v.pkg.Name)
+	buf.WriteString("\n\nimport \"testing\"\n")
+	for _, name := range names {
+		err := basicTemp.ExecuteTemplate(buf, "fuzz_test", map[string]any{
+			"Name": name,
+		})
+		if err != nil { panic(err) }
+	}
+
+	formatted, err := formatFile(buf)
+	if err != nil {
+		v.errorf(token.NoPos, "package %s: %v", v.pkg.Name, err)
+		return nil
+	}
+	return formatted
+}
@@ -1,14 +1,9 @@
-package main
+package gen
 
 import (
 	"fmt"
-	"time"
 )
 
-func printDuration(name string, t time.Time) {
-	fmt.Printf("%s: %v\n", name, time.Since(t))
-}
-
 var enableDebug = false
 
 func debugPrintf(format string, a ...any) {
@@ -0,0 +1,500 @@
+package gen
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"github.com/unitoftime/cod/backend"
+)
+
+// Inspecting walks encoded data with the schemas of the tagged types, so that every value can be shown with its position in the data.
+// Types from other packages and generic types are resolved from the imports of the package that uses them
+
+// A value that was read while inspecting
+type InspectLine struct {
+	Offset int // The offset of the value in the data
+	Bytes []byte // The bytes that were read for the value. Its children are on their own lines
+	Depth int // The depth of the value in the tree
+	Path string // The path of the value from the root (ie Person.Slice[2])
+	Type string // The Go type of the value
+	Kind string // How the value is encoded (see EncodingSchema)
+	Value string // The decoded value, or a summary (ie len 3)
+}
+
+// The position where inspecting failed
+type InspectError struct {
+	Offset int
+	Path string
+	Err error
+}
+
+func (e *InspectError) Error() string {
+	return fmt.Sprintf("offset %d: %s: %v", e.Offset, e.Path, e.Err)
+}
+
+func (e *InspectError) Unwrap() error {
+	return e.Err
+}
+
+// Decodes the data as the type named typeName from the package in dir, and returns a line for every value that was read along with the number of bytes read.
+// If decoding fails, then the lines that were read before the failure are returned with an *InspectError
+func Inspect(dir string, typeName string, data []byte, cfg Config) ([]InspectLine, int, error) {
+	in := &inspector{
+		cfg: cfg,
+		ctx: cfg.buildContext(),
+		pkgs: make(map[string]*inspectPkg),
+	}
+
+	pkg, err := in.loadPackage(dir)
+	if err != nil { return nil, 0, err }
+	ref, err := in.resolve(inspectScope{pkg: pkg}, typeName)
+	if err != nil { return nil, 0, err }
+	if _, ok := ref.pkg.types[ref.name]; !ok {
+		return nil, 0, fmt.Errorf("%s isn't tagged with //cod:struct or //cod:union in %s", typeName, dir)
+	}
+
+	n, err := in.walkType(data, 0, 0, typeName, ref)
+	return in.lines, n, err
+}
+
+type inspectPkg struct {
+	dir string
+	types map[string]TypeSchema
+	imports map[string]string // Maps an import name to its path
+}
+
+// A resolved reference to a named type, with its type arguments if it is generic
+type inspectRef struct {
+	pkg *inspectPkg
+	name string
+	args []inspectRef
+	str string // The type as it was written
+}
+
+// Type names are resolved in the package that they were written in. Type parameters are resolved to their arguments
+type inspectScope struct {
+	pkg *inspectPkg
+	params map[string]inspectRef
+}
+
+type inspector struct {
+	cfg Config
+	ctx build.Context
+	pkgs map[string]*inspectPkg // Cached by directory
+	lines []InspectLine
+}
+
+func (in *inspector) loadPackage(dir string) (*inspectPkg, error) {
+	if pkg, ok := in.pkgs[dir]; ok { return pkg, nil }
+
+	schema, diags, err := LoadSchema(dir, in.cfg)
+	if err != nil { return nil, err }
+	for _, d := range diags {
+		if d.Severity == SeverityError {
+			return nil, fmt.Errorf("%s: the package has errors: %v", dir, d)
+		}
+	}
+
+	pkg := &inspectPkg{
+		dir: dir,
+		types: make(map[string]TypeSchema),
+		imports: make(map[string]string),
+	}
+	for _, ts := range schema.Types {
+		if ts.Kind == "def" { continue }
+		pkg.types[ts.Name] = ts
+	}
+
+	packages, err := parser.ParseDir(token.NewFileSet(), dir, fileFilter(in.ctx, dir), parser.ImportsOnly)
+	if err != nil { return nil, err }
+	for _, p := range packages {
+		for _, file := range p.Files {
+			for _, spec := range file.Imports {
+				path, err := strconv.Unquote(spec.Path.Value)
+				if err != nil { continue }
+				name := path[strings.LastIndex(path, "/")+1:]
+				if spec.Name != nil {
+					name = spec.Name.Name
+				}
+				pkg.imports[name] = path
+			}
+		}
+	}
+
+	in.pkgs[dir] = pkg
+	return pkg, nil
+}
+
+// Resolves a type as it is written in a schema (ie subpackage.Option[Vec])
+func (in *inspector) resolve(scope inspectScope, typeStr string) (inspectRef, error) {
+	expr, err := parser.ParseExpr(typeStr)
+	if err != nil { return inspectRef{}, fmt.Errorf("invalid type %s: %w", typeStr, err) }
+	return in.resolveExpr(scope, expr)
+}
+
+func (in *inspector) resolveExpr(scope inspectScope, expr ast.Expr) (inspectRef, error) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if param, ok := scope.params[e.Name]; ok { return param, nil }
+		return inspectRef{pkg: scope.pkg, name: e.Name, str: e.Name}, nil
+
+	case *ast.SelectorExpr:
+		x, ok := e.X.(*ast.Ident)
+		if !ok { break }
+		path, ok := scope.pkg.imports[x.Name]
+		if !ok { return inspectRef{}, fmt.Errorf("couldn't find import %s in %s", x.Name, scope.pkg.dir) }
+		bp, err := in.ctx.Import(path, scope.pkg.dir, build.FindOnly)
+		if err != nil { return inspectRef{}, err }
+		pkg, err := in.loadPackage(bp.Dir)
+		if err != nil { return inspectRef{}, err }
+		return inspectRef{pkg: pkg, name: e.Sel.Name, str: types.ExprString(e)}, nil
+
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		var x ast.Expr
+		var indices []ast.Expr
+		switch e := e.(type) {
+		case *ast.IndexExpr:
+			x, indices = e.X, []ast.Expr{e.Index}
+		case *ast.IndexListExpr:
+			x, indices = e.X, e.Indices
+		}
+		ref, err := in.resolveExpr(scope, x)
+		if err != nil { return inspectRef{}, err }
+		for _, index := range indices {
+			arg, err := in.resolveExpr(scope, index)
+			if err != nil { return inspectRef{}, err }
+			ref.args = append(ref.args, arg)
+		}
+		ref.str = types.ExprString(expr)
+		return ref, nil
+
+	case *ast.StarExpr:
+		// The pointer type argument of a generic type (ie Option[Vec, *Vec]). It is only used to decode, so it is never walked
+		ref, err := in.resolveExpr(scope, e.X)
+		if err != nil { return inspectRef{}, err }
+		ref.str = types.ExprString(expr)
+		return ref, nil
+	}
+	return inspectRef{}, fmt.Errorf("unsupported type %s", types.ExprString(expr))
+}
+
+func (in *inspector) add(bs []byte, start, end, depth int, path, typ, kind, value string) {
+	in.lines = append(in.lines, InspectLine{
+		Offset: start,
+		Bytes: bs[start:end],
+		Depth: depth,
+		Path: path,
+		Type: typ,
+		Kind: kind,
+		Value: value,
+	})
+}
+
+func inspectErr(off int, path string, err error) error {
+	var inspectErr *InspectError
+	if errors.As(err, &inspectErr) { return err }
+	return &InspectError{Offset: off, Path: path, Err: err}
+}
+
+// Walks a tagged type starting at off, and returns the offset after it
+func (in *inspector) walkType(bs []byte, off, depth int, path string, ref inspectRef) (int, error) {
+	ts, ok := ref.pkg.types[ref.name]
+	if !ok {
+		return off, inspectErr(off, path, fmt.Errorf("%s has no schema (it isn't tagged with //cod:struct or //cod:union), so its encoding is unknown", ref.str))
+	}
+	if len(ts.TypeParams) != len(ref.args) {
+		return off, inspectErr(off, path, fmt.Errorf("%s needs %d type arguments", ref.str, len(ts.TypeParams)))
+	}
+	scope := inspectScope{pkg: ref.pkg, params: make(map[string]inspectRef)}
+	for i, param := range ts.TypeParams {
+		scope.params[param] = ref.args[i]
+	}
+
+	switch {
+	case ts.Kind == "union":
+		return in.walkUnion(bs, off, depth, path, ref, ts, scope)
+	case ts.Encoding != nil:
+		return in.walkEncoding(bs, off, depth, path, *ts.Encoding, ref.str, scope)
+	case ts.Evolvable:
+		in.add(bs, off, off, depth, path, ref.str, "evolvable", "")
+		return in.walkEvolvable(bs, off, depth+1, path, ts, scope)
+	}
+
+	in.add(bs, off, off, depth, path, ref.str, "struct", "")
+	var err error
+	for _, f := range ts.Fields {
+		if strings.Contains(f.Skip, "serdes") { continue }
+		fieldPath := path + "." + f.Name
+		if f.Name == "" {
+			fieldPath = path // A bitfield, which names its own fields
+		}
+		off, err = in.walkEncoding(bs, off, depth+1, fieldPath, f.Encoding, "", scope)
+		if err != nil { return off, err }
+	}
+	return off, nil
+}
+
+func (in *inspector) walkUnion(bs []byte, off, depth int, path string, ref inspectRef, ts TypeSchema, scope inspectScope) (int, error) {
+	tag, n, err := readBasic(ts.TagEncoding, bs[off:])
+	if err != nil { return off, inspectErr(off, path, err) }
+	tagVal, _ := strconv.Atoi(tag)
+	if tagVal == 0 {
+		in.add(bs, off, off+n, depth, path, ref.str, "union", "nil")
+		return off+n, nil
+	}
+
+	for _, v := range ts.Variants {
+		if v.Tag != tagVal { continue }
+		in.add(bs, off, off+n, depth, path, ref.str, "union", fmt.Sprintf("tag %d (%s)", v.Tag, v.Type))
+
+		variant, err := in.resolve(scope, v.Type)
+		if err != nil { return off+n, inspectErr(off+n, path, err) }
+		return in.walkType(bs, off+n, depth+1, path + "." + v.Name, variant)
+	}
+	return off, inspectErr(off, path, fmt.Errorf("unknown tag %d", tagVal))
+}
+
+func (in *inspector) walkEvolvable(bs []byte, off, depth int, path string, ts TypeSchema, scope inspectScope) (int, error) {
+	fields := make(map[int]FieldSchema)
+	for _, f := range ts.Fields {
+		if strings.Contains(f.Skip, "serdes") { continue }
+		fields[f.Number] = f
+	}
+
+	for {
+		start := off
+		number, n, err := backend.ReadVarUint64(bs[off:])
+		if err != nil { return off, inspectErr(off, path, err) }
+		off += n
+		if number == 0 {
+			in.add(bs, start, off, depth, path, "", "varuint64", "end")
+			return off, nil
+		}
+
+		length, n, err := backend.ReadVarUint64(bs[off:])
+		if err != nil { return off, inspectErr(off, path, err) }
+		off += n
+		if length > uint64(len(bs) - off) {
+			return start, inspectErr(start, path, fmt.Errorf("field %d has length %d, but only %d bytes remain: %w", number, length, len(bs) - off, backend.ErrTruncatedData))
+		}
+		end := off + int(length)
+
+		f, ok := fields[int(number)]
+		if !ok {
+			in.add(bs, start, end, depth, path, "", "field", fmt.Sprintf("unknown field %d, skipped %d bytes", number, length))
+			off = end
+			continue
+		}
+
+		fieldPath := path + "." + f.Name
+		in.add(bs, start, off, depth, fieldPath, "", "field", fmt.Sprintf("number %d, len %d", number, length))
+		_, err = in.walkEncoding(bs[:end], off, depth+1, fieldPath, f.Encoding, "", scope)
+		if err != nil { return off, err }
+		off = end
+	}
+}
+
+// Walks a value. The type name is used for the value instead of the Go type in the encoding if it is set (ie for type Tick uint32)
+func (in *inspector) walkEncoding(bs []byte, off, depth int, path string, enc EncodingSchema, typeName string, scope inspectScope) (int, error) {
+	if typeName == "" {
+		typeName = enc.String()
+		if enc.Type != "" { typeName = enc.Type }
+	}
+
+	switch enc.Kind {
+	case "codec", "typeparam":
+		ref, err := in.resolve(scope, enc.Type)
+		if err != nil { return off, inspectErr(off, path, err) }
+		return in.walkType(bs, off, depth, path, ref)
+
+	case "slice":
+		length, n, err := readLength(bs, off, in.minSize(*enc.Elem, scope))
+		if err != nil { return off, inspectErr(off, path, err) }
+		in.add(bs, off, off+n, depth, path, typeName, "slice", fmt.Sprintf("len %d", length))
+		off += n
+		for i := 0; i < length; i++ {
+			off, err = in.walkEncoding(bs, off, depth+1, fmt.Sprintf("%s[%d]", path, i), *enc.Elem, "", scope)
+			if err != nil { return off, err }
+		}
+		return off, nil
+
+	case "array":
+		length, err := strconv.Atoi(enc.Len)
+		if err != nil { return off, inspectErr(off, path, fmt.Errorf("unknown array length %s", enc.Len)) }
+		in.add(bs, off, off, depth, path, typeName, "array", fmt.Sprintf("len %d", length))
+		for i := 0; i < length; i++ {
+			off, err = in.walkEncoding(bs, off, depth+1, fmt.Sprintf("%s[%d]", path, i), *enc.Elem, "", scope)
+			if err != nil { return off, err }
+		}
+		return off, nil
+
+	case "map":
+		length, n, err := readLength(bs, off, in.minSize(*enc.Key, scope) + in.minSize(*enc.Elem, scope))
+		if err != nil { return off, inspectErr(off, path, err) }
+		in.add(bs, off, off+n, depth, path, typeName, "map", fmt.Sprintf("len %d", length))
+		off += n
+		for i := 0; i < length; i++ {
+			off, err = in.walkEncoding(bs, off, depth+1, fmt.Sprintf("%s.keys[%d]", path, i), *enc.Key, "", scope)
+			if err != nil { return off, err }
+
+			// Values are named after their key if it is a basic value
+			valPath := fmt.Sprintf("%s[#%d]", path, i)
+			if last := in.lines[len(in.lines)-1]; last.Depth == depth+1 && enc.Key.Kind != "codec" && enc.Key.Kind != "typeparam" {
+				valPath = fmt.Sprintf("%s[%s]", path, last.Value)
+			}
+			off, err = in.walkEncoding(bs, off, depth+1, valPath, *enc.Elem, "", scope)
+			if err != nil { return off, err }
+		}
+		return off, nil
+
+	case "pointer":
+		tag, n, err := backend.ReadUint8(bs[off:])
+		if err != nil { return off, inspectErr(off, path, err) }
+		if tag == 0 {
+			in.add(bs, off, off+n, depth, path, typeName, "pointer", "nil")
+			return off+n, nil
+		}
+		in.add(bs, off, off+n, depth, path, typeName, "pointer", "set")
+		return in.walkEncoding(bs, off+n, depth+1, path, *enc.Elem, "", scope)
+
+	case "bitfield":
+		size := (len(enc.Bits) + 7) / 8
+		if len(bs) - off < size { return off, inspectErr(off, path, backend.ErrTruncatedData) }
+		in.add(bs, off, off+size, depth, path, "", "bitfield", fmt.Sprintf("%d bools", len(enc.Bits)))
+		for i, name := range enc.Bits {
+			bit := bs[off + i/8] & (1 << (i % 8))
+			in.add(bs, off + i/8, off + i/8, depth+1, path + "." + name, "bool", "bit", strconv.FormatBool(bit != 0))
+		}
+		return off + size, nil
+
+	case "packedbools":
+		length, n, err := backend.ReadVarUint64(bs[off:])
+		if err != nil { return off, inspectErr(off, path, err) }
+		if length > uint64(len(bs) - off - n) * 8 {
+			return off, inspectErr(off, path, fmt.Errorf("length %d needs more than the remaining %d bytes: %w", length, len(bs) - off - n, backend.ErrTruncatedData))
+		}
+		size := int((length + 7) / 8)
+		vals := make([]string, 0, length)
+		for i := 0; i < int(length); i++ {
+			bit := bs[off + n + i/8] & (1 << (i % 8))
+			vals = append(vals, strconv.FormatBool(bit != 0))
+		}
+		in.add(bs, off, off+n+size, depth, path, typeName, "packedbools", fmt.Sprintf("len %d [%s]", length, strings.Join(vals, " ")))
+		return off + n + size, nil
+	}
+
+	value, n, err := readBasic(enc.Kind, bs[off:])
+	if err != nil { return off, inspectErr(off, path, err) }
+	in.add(bs, off, off+n, depth, path, typeName, enc.Kind, value)
+	return off+n, nil
+}
+
+// The max length of a slice or map whose elements can be encoded with zero bytes (ie empty structs), because the data doesn't bound it
+const maxEmptyElemLen = 1 << 20
+
+// Reads a slice or map length. Each element takes at least minSize bytes, so a length that needs more than the remaining data is reported instead of walked
+func readLength(bs []byte, off int, minSize int) (int, int, error) {
+	length, n, err := backend.ReadVarUint64(bs[off:])
+	if err != nil { return 0, 0, err }
+	if minSize == 0 {
+		if length > maxEmptyElemLen {
+			return 0, 0, fmt.Errorf("length %d of empty elements is more than the max of %d", length, maxEmptyElemLen)
+		}
+		return int(length), n, nil
+	}
+	remaining := len(bs) - off - n
+	if length > uint64(remaining / minSize) {
+		return 0, 0, fmt.Errorf("length %d needs at least %d bytes per element, but only %d bytes remain: %w", length, minSize, remaining, backend.ErrTruncatedData)
+	}
+	return int(length), n, nil
+}
+
+// Returns the fewest bytes that a value can be encoded with. Types that can't be resolved count as zero, and fail when they are walked
+func (in *inspector) minSize(enc EncodingSchema, scope inspectScope) int {
+	switch enc.Kind {
+	case "codec", "typeparam":
+		ref, err := in.resolve(scope, enc.Type)
+		if err != nil { return 0 }
+		return in.minTypeSize(ref)
+	case "array":
+		length, err := strconv.Atoi(enc.Len)
+		if err != nil { return 0 }
+		return length * in.minSize(*enc.Elem, scope)
+	case "bitfield":
+		return (len(enc.Bits) + 7) / 8
+	case "uint16", "int16":
+		return 2
+	case "uint32", "int32", "float32":
+		return 4
+	case "uint64", "int64", "float64":
+		return 8
+	}
+	return 1 // Lengths, pointer tags, varints, bools and single bytes
+}
+
+func (in *inspector) minTypeSize(ref inspectRef) int {
+	ts, ok := ref.pkg.types[ref.name]
+	if !ok || len(ts.TypeParams) != len(ref.args) { return 0 }
+	scope := inspectScope{pkg: ref.pkg, params: make(map[string]inspectRef)}
+	for i, param := range ts.TypeParams {
+		scope.params[param] = ref.args[i]
+	}
+
+	switch {
+	case ts.Kind == "union":
+		return in.minSize(EncodingSchema{Kind: ts.TagEncoding}, scope) // A nil union is only its tag
+	case ts.Encoding != nil:
+		return in.minSize(*ts.Encoding, scope)
+	case ts.Evolvable:
+		return 1 // The end marker
+	}
+
+	size := 0
+	for _, f := range ts.Fields {
+		if strings.Contains(f.Skip, "serdes") { continue }
+		size += in.minSize(f.Encoding, scope)
+	}
+	return size
+}
+
+// Reads a basic value with the backend function that the kind is named after, and formats it
+func readBasic(kind string, bs []byte) (string, int, error) {
+	switch kind {
+	case "uint8": return readFormat(bs, backend.ReadUint8)
+	case "int8": return readFormat(bs, backend.ReadInt8)
+	case "uint": return readFormat(bs, backend.ReadUint)
+	case "int": return readFormat(bs, backend.ReadInt)
+	case "uint16": return readFormat(bs, backend.ReadUint16)
+	case "uint32": return readFormat(bs, backend.ReadUint32)
+	case "uint64": return readFormat(bs, backend.ReadUint64)
+	case "int16": return readFormat(bs, backend.ReadInt16)
+	case "int32": return readFormat(bs, backend.ReadInt32)
+	case "int64": return readFormat(bs, backend.ReadInt64)
+	case "varuint16": return readFormat(bs, backend.ReadVarUint16)
+	case "varuint32": return readFormat(bs, backend.ReadVarUint32)
+	case "varuint64": return readFormat(bs, backend.ReadVarUint64)
+	case "varint16": return readFormat(bs, backend.ReadVarInt16)
+	case "varint32": return readFormat(bs, backend.ReadVarInt32)
+	case "varint64": return readFormat(bs, backend.ReadVarInt64)
+	case "float32": return readFormat(bs, backend.ReadFloat32)
+	case "float64": return readFormat(bs, backend.ReadFloat64)
+	case "bool": return readFormat(bs, backend.ReadBool)
+	case "string":
+		v, n, err := backend.ReadString(bs)
+		return strconv.Quote(v), n, err
+	}
+	return "", 0, fmt.Errorf("unknown encoding %s", kind)
+}
+
+func readFormat[T any](bs []byte, read func([]byte) (T, int, error)) (string, int, error) {
+	v, n, err := read(bs)
+	if err != nil { return "", 0, err }
+	return fmt.Sprint(v), n, nil
+}
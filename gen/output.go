@@ -0,0 +1,19 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+)
+
+// The first line of every generated Go file. The cod command only removes stale files that start with it
+const GeneratedHeader = "// Code generated by cod; DO NOT EDIT."
+
+// Formats a generated file. If the generated code can't be formatted, then it has a syntax error and it must not be written
+func formatFile(buf *bytes.Buffer) ([]byte, error) {
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated code has a syntax error (this is a bug in cod): %w", err)
+	}
+	return formatted, nil
+}
@@ -0,0 +1,128 @@
+package gen
+
+import (
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"strings"
+)
+
+type RequestType int
+
+type GenRequest struct {
+	Type RequestType
+	CSV []string
+}
+
+type requestConfig struct {
+	str string
+	RequestType RequestType
+
+	// TODO: Ideally the required imports would be tracked by the generating code because doing it up here is super broad and doesnt handle perfectly
+	RequiredImports []string
+	TrackImports bool // If true, this requestType means we need to track and use the imports of the inner types in the struct
+}
+
+func (v *Visitor) getRequests(name string, t ast.GenDecl) (bool, bool) {
+	if t.Doc == nil {
+		return false, false
+	}
+
+	generatedCodeRequiresImports := false
+	for _, c := range t.Doc.List {
+		for _, search := range directiveSearch {
+			after, found := cutDirective(c.Text, search.str)
+			if found {
+				v.directives[c.Pos()] = true
+
+				if search.TrackImports {
+					generatedCodeRequiresImports = search.TrackImports
+				}
+
+				for _, reqImport := range search.RequiredImports {
+					v.useImport(reqImport, token.NoPos)
+				}
+
+				csv := strings.Split(after, ",")
+				for i := range csv {
+					csv[i] = strings.TrimSpace(csv[i])
+				}
+
+				v.requests[name] = append(v.requests[name], GenRequest{
+					Type: search.RequestType,
+					CSV: csv,
+				})
+			}
+		}
+	}
+	return (len(v.requests[name]) > 0), generatedCodeRequiresImports
+}
+
+// Cuts the directive from the start of a comment. The directive must be followed by a space or the end of the comment (ie //cod:structs is not //cod:struct)
+func cutDirective(text, directive string) (string, bool) {
+	after, found := strings.CutPrefix(text, directive)
+	if !found { return "", false }
+	if after != "" && after[0] != ' ' { return "", false }
+	return after, true
+}
+
+// Reports directives that are misspelled, or that aren't attached to a type and so do nothing
+func (v *Visitor) checkDirectives() {
+	for name, file := range v.pkg.Files {
+		if filepath.Base(name) == generatedFileName { continue }
+
+		for _, group := range file.Comments {
+			for _, c := range group.List {
+				if !strings.HasPrefix(c.Text, "//cod:") { continue }
+				if _, found := cutDirective(c.Text, packageDirective); found { continue }
+
+				known := false
+				for _, search := range directiveSearch {
+					if _, found := cutDirective(c.Text, search.str); found { known = true }
+				}
+				directive, _, _ := strings.Cut(c.Text, " ")
+				if !known {
+					v.errorf(c.Pos(), "unknown directive %s", directive)
+				} else if !v.directives[c.Pos()] {
+					v.warnf(c.Pos(), "%s is ignored because it isn't attached to a type declaration", directive)
+				}
+			}
+		}
+	}
+}
+
+const packageDirective = "//cod:package"
+
+// Options that apply to every type in a package. Set with a comment anywhere in the package: //cod:package <CSV list of options>
+type packageConfig struct {
+	DeterministicMaps bool // If true, maps are encoded in sorted key order
+	Bitpack bool // If true, structs pack their bool fields into a bitfield by default
+}
+
+func (v *Visitor) getPackageConfig() packageConfig {
+	config := packageConfig{}
+	for name, file := range v.pkg.Files {
+		if strings.HasSuffix(filepath.Base(name), "_test.go") { continue }
+
+		for _, group := range file.Comments {
+			for _, c := range group.List {
+				after, found := cutDirective(c.Text, packageDirective)
+				if !found { continue }
+
+				for _, opt := range strings.Split(after, ",") {
+					opt = strings.TrimSpace(opt)
+					switch opt {
+					case "":
+					case "deterministic":
+						config.DeterministicMaps = true
+					case "bitpack":
+						config.Bitpack = true
+					default:
+						v.errorf(c.Pos(), "unknown package option: %s", opt)
+					}
+				}
+			}
+		}
+	}
+	return config
+}
@@ -0,0 +1,261 @@
+package gen
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const schemaFileName = "cod_schema.json"
+
+// The schema of a package describes the wire layout of each of its tagged types. It is written to cod_schema.json with the -schema flag
+type Schema struct {
+	Package string `json:"package"`
+	Types []TypeSchema `json:"types"` // Sorted by name
+}
+
+// A type tagged with //cod:struct, //cod:union or //cod:def
+type TypeSchema struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"` // struct, union or def
+	TypeParams []string `json:"typeParams,omitempty"`
+
+	// Structs
+	Evolvable bool `json:"evolvable,omitempty"` // If true, each field is written as: varuint64 field number, varuint64 length, field data. The struct ends with a zero field number
+	Bitpack bool `json:"bitpack,omitempty"`
+	Fields []FieldSchema `json:"fields,omitempty"` // In wire order
+	Encoding *EncodingSchema `json:"encoding,omitempty"` // Set instead of fields if the type isn't a struct (ie type Tick uint32)
+
+	// Unions are written as the tag followed by the variant. Tag 0 is nil, and has no variant data
+	Def string `json:"def,omitempty"`
+	TagEncoding string `json:"tagEncoding,omitempty"`
+	Variants []VariantSchema `json:"variants,omitempty"` // Sorted by tag
+}
+
+type FieldSchema struct {
+	Name string `json:"name,omitempty"` // Empty for a bitfield, which holds multiple fields
+	Number int `json:"number,omitempty"` // The field number in an evolvable struct
+	Skip string `json:"skip,omitempty"` // Fields that skip serdes aren't written
+	Encoding EncodingSchema `json:"encoding"`
+}
+
+// How a value is written. Basic kinds are named after the backend function that writes them (ie varuint32 is written with backend.WriteVarUint32).
+// The other kinds are:
+//  - codec: written by the EncodeCod method of the type
+//  - typeparam: written by the EncodeCod method of the type argument
+//  - slice: varuint64 length, then each element
+//  - array: each element
+//  - map: varuint64 length, then each key and value
+//  - pointer: uint8 0 for nil, otherwise uint8 1 then the element
+//  - bitfield: the bools packed into ceil(n/8) bytes. Bit i is bit i%8 of byte i/8
+//  - packedbools: varuint64 length, then the bools packed into ceil(length/8) bytes
+type EncodingSchema struct {
+	Kind string `json:"kind"`
+	Type string `json:"type,omitempty"` // The Go type of basic, codec and typeparam values
+	Cast string `json:"cast,omitempty"` // The type the value is converted to before it is written
+	Len string `json:"len,omitempty"` // The array length
+	Sorted bool `json:"sorted,omitempty"` // If true, map entries are written in sorted key order
+	Key *EncodingSchema `json:"key,omitempty"`
+	Elem *EncodingSchema `json:"elem,omitempty"`
+	Bits []string `json:"bits,omitempty"` // The fields of a bitfield, in bit order
+}
+
+type VariantSchema struct {
+	Tag int `json:"tag"`
+	Name string `json:"name"` // The name used in the generated getters and setters
+	Type string `json:"type"` // Written by the EncodeCod method of the type
+}
+
+// Returns a short description of the encoding (ie []varuint32 or map[string]Id)
+func (e EncodingSchema) String() string {
+	switch e.Kind {
+	case "codec", "typeparam":
+		return e.Type
+	case "slice":
+		return "[]" + e.Elem.String()
+	case "array":
+		return "[" + e.Len + "]" + e.Elem.String()
+	case "map":
+		if e.Sorted {
+			return "sorted map[" + e.Key.String() + "]" + e.Elem.String()
+		}
+		return "map[" + e.Key.String() + "]" + e.Elem.String()
+	case "pointer":
+		return "*" + e.Elem.String()
+	case "packedbools":
+		return "packed []bool"
+	}
+	return e.Kind
+}
+
+// Returns the schema of the package in dir without writing anything. The schema has no types if the package has errors
+func LoadSchema(dir string, cfg Config) (Schema, []Diagnostic, error) {
+	cfg.Schema = true
+	files, diags, err := Generate(dir, cfg)
+	if err != nil { return Schema{}, diags, err }
+
+	var schema Schema
+	file, ok := files[filepath.Join(dir, schemaFileName)]
+	if !ok { return schema, diags, nil }
+	err = json.Unmarshal(file, &schema)
+	if err != nil { panic(err) }
+	return schema, diags, nil
+}
+
+// Returns the formatted schema file, or nil if there are no types to describe
+func (v *Visitor) OutputSchema() []byte {
+	schema := v.Schema()
+	if len(schema.Types) == 0 {
+		return nil // Skip: no tagged types
+	}
+
+	file, err := json.MarshalIndent(schema, "", "\t")
+	if err != nil { panic(err) }
+	return append(file, '\n')
+}
+
+// Returns the schema of the package. It must only be called if the package has no errors
+func (v *Visitor) Schema() Schema {
+	names := make([]string, 0, len(v.structs))
+	for name := range v.structs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	schema := Schema{
+		Package: v.pkg.Name,
+		Types: make([]TypeSchema, 0),
+	}
+	for _, name := range names {
+		sd := v.structs[name]
+		for _, req := range v.requests[name] {
+			switch req.Type {
+			case RequestTypeSerdes:
+				schema.Types = append(schema.Types, structSchema(sd, getStructConfig(req.CSV, v.config)))
+			case RequestTypeUnion:
+				schema.Types = append(schema.Types, unionSchema(sd, req.CSV, v.structs))
+			case RequestTypeUnionDef:
+				ts := structSchema(sd, structConfig{})
+				ts.Kind = "def"
+				schema.Types = append(schema.Types, ts)
+			}
+		}
+	}
+	return schema
+}
+
+func structSchema(sd StructData, config structConfig) TypeSchema {
+	ts := TypeSchema{
+		Name: sd.Name,
+		Kind: "struct",
+		TypeParams: sd.TypeParams,
+		Evolvable: config.Evolvable,
+	}
+
+	// A type that isn't a struct has a single alias field
+	if len(sd.Fields) == 1 {
+		if alias, ok := sd.Fields[0].(*AliasField); ok {
+			enc := encodingSchema(alias.Field)
+			ts.Encoding = &enc
+			return ts
+		}
+	}
+
+	if config.Evolvable {
+		for _, nf := range getFieldNumbers(sd) {
+			fs := fieldSchema(nf.Field)
+			fs.Number = nf.Number
+			ts.Fields = append(ts.Fields, fs)
+		}
+		return ts
+	}
+
+	fields := sd.Fields
+	if config.Bitpack {
+		ts.Bitpack = true
+		fields = bitpackFields(fields)
+	}
+	for _, f := range fields {
+		ts.Fields = append(ts.Fields, fieldSchema(f))
+	}
+	return ts
+}
+
+func unionSchema(sd StructData, csv []string, structs map[string]StructData) TypeSchema {
+	config := getUnionConfig(csv)
+	ts := TypeSchema{
+		Name: sd.Name,
+		Kind: "union",
+		Def: csv[0],
+		TagEncoding: strings.ToLower(config.TagApi),
+	}
+
+	for _, f := range getUnionFields(csv, structs) {
+		ts.Variants = append(ts.Variants, VariantSchema{
+			Tag: f.UnionTag,
+			Name: f.Variant,
+			Type: f.GetType(),
+		})
+	}
+	sort.Slice(ts.Variants, func(i, j int) bool {
+		return ts.Variants[i].Tag < ts.Variants[j].Tag
+	})
+	return ts
+}
+
+func fieldSchema(f Field) FieldSchema {
+	fs := FieldSchema{
+		Name: strings.TrimPrefix(f.GetName(), "t."),
+		Skip: tagSearchSkip(f.GetTag()),
+		Encoding: encodingSchema(f),
+	}
+	if _, ok := f.(*BitfieldField); ok {
+		fs.Name = "" // The names are in the bits
+	}
+	return fs
+}
+
+func encodingSchema(f Field) EncodingSchema {
+	switch f := f.(type) {
+	case *BasicField:
+		apiName, _, supported := f.getApi()
+		if !supported {
+			return EncodingSchema{Kind: "codec", Type: f.Type}
+		}
+		return EncodingSchema{
+			Kind: strings.ToLower(apiName),
+			Type: f.Type,
+			Cast: tagSearchCast(f.Tag),
+		}
+	case *TypeParamField:
+		return EncodingSchema{Kind: "typeparam", Type: f.Type}
+	case *ArrayField:
+		elem := encodingSchema(f.Field)
+		return EncodingSchema{Kind: "array", Len: f.Len, Elem: &elem}
+	case *PackedBoolsField:
+		elem := encodingSchema(f.Field)
+		return EncodingSchema{Kind: "packedbools", Elem: &elem}
+	case *SliceField:
+		elem := encodingSchema(f.Field)
+		return EncodingSchema{Kind: "slice", Elem: &elem}
+	case *MapField:
+		key := encodingSchema(f.Key)
+		elem := encodingSchema(f.Val)
+		return EncodingSchema{Kind: "map", Sorted: f.Deterministic, Key: &key, Elem: &elem}
+	case *PointerField:
+		elem := encodingSchema(f.Field)
+		return EncodingSchema{Kind: "pointer", Elem: &elem}
+	case *AliasField:
+		return encodingSchema(f.Field)
+	case *BitfieldField:
+		bits := make([]string, 0, len(f.Bools))
+		for _, b := range f.Bools {
+			bits = append(bits, strings.TrimPrefix(b.Name, "t."))
+		}
+		return EncodingSchema{Kind: "bitfield", Bits: bits}
+	default:
+		panic(fmt.Sprintf("schema: unhandled field type %T", f))
+	}
+}
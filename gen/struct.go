@@ -0,0 +1,215 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// List of supported reads and writes
+var supportedApis = map[string]string{
+	"uint8": "Uint8",
+	"int8": "Int8",
+
+	"uint": "Uint",
+	"int": "Int",
+
+	// As a default, we always use Variable length encoding APIs for anything > 2 bytes
+	"uint16": "VarUint16",
+	"uint32": "VarUint32",
+	"uint64": "VarUint64",
+
+	"int16": "VarInt16",
+	"int32": "VarInt32",
+	"int64": "VarInt64",
+
+	"float32": "Float32",
+	"float64": "Float64",
+
+	"string": "String",
+	"bool": "Bool",
+}
+
+type fixedApi struct {
+	ApiName string
+	Type string // The type that the api reads and writes
+}
+
+// List of fixed width reads and writes. Used instead of the variable length apis when a field is tagged with `cod.fixed:"true"`
+var fixedApis = map[string]fixedApi{
+	"uint": {"Uint64", "uint64"},
+	"int": {"Int64", "int64"},
+
+	"uint16": {"Uint16", "uint16"},
+	"uint32": {"Uint32", "uint32"},
+	"uint64": {"Uint64", "uint64"},
+
+	"int16": {"Int16", "int16"},
+	"int32": {"Int32", "int32"},
+	"int64": {"Int64", "int64"},
+}
+
+// Controls how a struct is encoded. Set with options after the directive: //cod:struct <CSV list of options>
+type structConfig struct {
+	Evolvable bool // If true, fields are written with their field number and length so that fields can be added and removed
+	Bitpack bool // If true, bool fields are packed into a bitfield
+}
+
+func getStructConfig(csv []string, pkgConfig packageConfig) structConfig {
+	config := structConfig{
+		Bitpack: pkgConfig.Bitpack,
+	}
+	for _, opt := range csv {
+		switch opt {
+		case "":
+		case "evolvable":
+			config.Evolvable = true
+		case "bitpack":
+			config.Bitpack = true
+		case "nobitpack":
+			config.Bitpack = false
+		default:
+			panic(fmt.Sprintf("unknown struct option: %s", opt))
+		}
+	}
+	return config
+}
+
+func GenerateSerdesData(sd StructData, config structConfig, buf *bytes.Buffer) {
+	debugPrintln("Struct: ", sd.Name)
+
+	// Note: Evolvable structs are never bitpacked, because each field needs its own field number
+	if config.Evolvable {
+		// Only struct fields have field numbers
+		if len(sd.Fields) > 0 {
+			if _, isAlias := sd.Fields[0].(*AliasField); isAlias {
+				panic(fmt.Sprintf("evolvable is only supported on struct types, %s is not a struct", sd.Name))
+			}
+		}
+		GenerateEvolvableSerdesData(sd, buf)
+		WriteFullDelta(sd, buf) // Evolvable structs are always written in full in deltas
+		return
+	}
+
+	if config.Bitpack {
+		sd.Fields = bitpackFields(sd.Fields)
+	}
+
+	// If no fields, then its a blank struct
+	if len(sd.Fields) <= 0 {
+		GenerateBlankSerdesData(sd, buf)
+		return
+	}
+
+	WriteStructMarshal(sd, buf)
+	WriteStructUnmarshal(sd, buf)
+	WriteStructEquality(sd, buf)
+	WriteStructClone(sd, buf)
+	WriteStructSize(sd, buf)
+	WriteStructDelta(sd, buf)
+}
+
+func GenerateBlankSerdesData(sd StructData, buf *bytes.Buffer) {
+	// Write the encode func
+	err := basicTemp.ExecuteTemplate(buf, "blank_marshal_func", map[string]any{
+		"Name": sd.Name,
+	})
+	if err != nil { panic(err) }
+
+	// Write the decode func
+	err = basicTemp.ExecuteTemplate(buf, "blank_unmarshal_func", map[string]any{
+		"Name": sd.Name,
+	})
+	if err != nil { panic(err) }
+
+	err = basicTemp.ExecuteTemplate(buf, "blank_equality_func", map[string]any{
+		"Name": sd.Name,
+	})
+	if err != nil { panic(err) }
+
+	err = basicTemp.ExecuteTemplate(buf, "blank_clone_func", map[string]any{
+		"Name": sd.Name,
+	})
+	if err != nil { panic(err) }
+
+	err = basicTemp.ExecuteTemplate(buf, "blank_size_func", map[string]any{
+		"Name": sd.Name,
+	})
+	if err != nil { panic(err) }
+
+	WriteFullDelta(sd, buf)
+}
+
+func WriteStructMarshal(sd StructData, buf *bytes.Buffer) {
+	marshBuf := new(bytes.Buffer)
+	for _, f := range sd.Fields {
+		f.WriteMarshal(marshBuf)
+	}
+	err := basicTemp.ExecuteTemplate(buf, "marshal_func", map[string]any{
+		"Name": sd.Name,
+		"MarshalCode": marshBuf.String(),
+	})
+	if err != nil { panic(err) }
+}
+
+func WriteStructUnmarshal(sd StructData, buf *bytes.Buffer) {
+	unmarshBuf := new(bytes.Buffer)
+	for _, f := range sd.Fields {
+		f.WriteUnmarshal(unmarshBuf)
+	}
+	// Write the decode func
+	err := basicTemp.ExecuteTemplate(buf, "unmarshal_func", map[string]any{
+		"Name": sd.Name,
+		"MarshalCode": unmarshBuf.String(),
+	})
+	if err != nil { panic(err) }
+}
+
+func WriteStructEquality(s StructData, buf *bytes.Buffer) {
+	innerBuf := new(bytes.Buffer)
+
+	for _, f := range s.Fields {
+		f.WriteEquality(innerBuf)
+	}
+	// Write the equality func
+	err := basicTemp.ExecuteTemplate(buf, "equality_func", map[string]any{
+		"Name": s.Name,
+		"InnerCode": innerBuf.String(),
+	})
+	if err != nil { panic(err) }
+}
+
+func WriteStructClone(s StructData, buf *bytes.Buffer) {
+	innerBuf := new(bytes.Buffer)
+
+	for _, f := range s.Fields {
+		f.WriteClone(innerBuf)
+	}
+	// Write the clone func
+	err := basicTemp.ExecuteTemplate(buf, "clone_func", map[string]any{
+		"Name": s.Name,
+		"InnerCode": innerBuf.String(),
+	})
+	if err != nil { panic(err) }
+}
+
+func WriteStructSize(s StructData, buf *bytes.Buffer) {
+	innerBuf := new(bytes.Buffer)
+
+	for _, f := range s.Fields {
+		f.WriteSize(innerBuf)
+	}
+	// Write the size func
+	err := basicTemp.ExecuteTemplate(buf, "size_func", map[string]any{
+		"Name": s.Name,
+		"InnerCode": innerBuf.String(),
+	})
+	if err != nil { panic(err) }
+}
+
+// Writes the functions that encode to a backend.Writer and decode from a backend.Reader
+func WriteStreamFuncs(sd StructData, buf *bytes.Buffer) {
+	err := basicTemp.ExecuteTemplate(buf, "stream_funcs", map[string]any{
+		"Name": sd.Name,
+	})
+	if err != nil { panic(err) }
+}
@@ -0,0 +1,60 @@
+package gen
+
+import (
+	"strings"
+)
+
+// Searches a struct tag for a key and returns its unquoted value
+func tagSearch(tag string, key string) string {
+	// `bson:"pageId" json:"pageId"`
+
+	split := strings.Split(tag, " ")
+	for _, s := range split {
+		s = strings.Trim(strings.TrimSpace(s), "`")
+
+		valQuoted, ok := strings.CutPrefix(s, key + ":")
+		if !ok { continue }
+
+		val := strings.Trim(valQuoted, "\"")
+		return val
+	}
+	return ""
+}
+
+func tagSearchCast(tag string) string {
+	// Example: `cod.cast:"uint64"`
+	return tagSearch(tag, "cod.cast")
+}
+
+func tagSearchSkip(tag string) string {
+	// Example: `cod.skip:"equality"`
+	return tagSearch(tag, "cod.skip")
+}
+
+func tagSearchFixed(tag string) string {
+	// Example: `cod.fixed:"true"`
+	return tagSearch(tag, "cod.fixed")
+}
+
+func tagSearchUnionTag(tag string) string {
+	// Example: `cod.tag:"7"`
+	return tagSearch(tag, "cod.tag")
+}
+
+func tagSearchDeterministic(tag string) string {
+	// Example: `cod.deterministic:"true"`
+	return tagSearch(tag, "cod.deterministic")
+}
+
+func shouldSkipEquality(skipString string) bool {
+	return strings.Contains(skipString, "equality")
+}
+func shouldSkipSerdes(tag string) bool {
+	skip := tagSearchSkip(tag)
+	return strings.Contains(skip, "serdes")
+}
+
+// Returns true if integers should be encoded with fixed width instead of variable length
+func shouldUseFixed(tag string) bool {
+	return tagSearchFixed(tag) == "true"
+}
@@ -0,0 +1,356 @@
+package gen
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const generatedFileName = "cod_gen.go"
+
+// The source importer caches every package it has checked, so we share one across all of the packages that we generate for.
+// The importer isn't safe for concurrent use, so Generate holds cacheMu
+var cacheMu sync.Mutex
+var sourceImporter types.Importer
+var sourceFset = token.NewFileSet() // The fileset that holds the positions of imported objects
+
+// Clears the cached imported packages, so that the next call to Generate sees any changes to them
+func ResetCache() {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	sourceImporter = nil
+	sourceFset = token.NewFileSet()
+	directiveFiles = make(map[string]*ast.File)
+}
+
+func getSourceImporter() types.Importer {
+	if sourceImporter == nil {
+		sourceImporter = importer.ForCompiler(sourceFset, "source", nil)
+	}
+	return sourceImporter
+}
+
+// Caches the files that we parsed to look up the directives of imported types
+var directiveFiles = make(map[string]*ast.File)
+
+// Returns true if an imported type is tagged with a directive that generates EncodeCod and DecodeCod.
+// This is needed because the package may not have been generated yet (ie if it is generated in the same run)
+func hasExternalDirective(obj *types.TypeName) bool {
+	pos := sourceFset.Position(obj.Pos())
+	if !pos.IsValid() { return false }
+
+	file, ok := directiveFiles[pos.Filename]
+	if !ok {
+		var err error
+		file, err = parser.ParseFile(token.NewFileSet(), pos.Filename, nil, parser.ParseComments)
+		if err != nil { file = nil }
+		directiveFiles[pos.Filename] = file
+	}
+	if file == nil { return false }
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE || gen.Doc == nil { continue }
+
+		for _, spec := range gen.Specs {
+			s, ok := spec.(*ast.TypeSpec)
+			if !ok || s.Name.Name != obj.Name() { continue }
+
+			for _, c := range gen.Doc.List {
+				if strings.HasPrefix(c.Text, "//cod:struct") || strings.HasPrefix(c.Text, "//cod:union") {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Runs the go/types checker over a parsed package so that we can resolve field types to their underlying types.
+// The generated file and test files are left out, because the generated file is about to be replaced and may be stale.
+// Type errors are not fatal: the checker keeps going and records everything it could resolve. The errors are returned so that the ones in tagged types can be reported
+func typeCheck(fset *token.FileSet, pkg *ast.Package) (*types.Package, *types.Info, []types.Error) {
+	fileNames := make([]string, 0, len(pkg.Files))
+	for name := range pkg.Files {
+		base := filepath.Base(name)
+		if base == generatedFileName { continue }
+		if strings.HasSuffix(base, "_test.go") { continue }
+		fileNames = append(fileNames, name)
+	}
+	sort.Strings(fileNames)
+
+	files := make([]*ast.File, 0, len(fileNames))
+	for _, name := range fileNames {
+		files = append(files, pkg.Files[name])
+	}
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs: make(map[*ast.Ident]types.Object),
+	}
+	typeErrors := make([]types.Error, 0)
+	conf := types.Config{
+		Importer: getSourceImporter(),
+		Error: func(err error) {
+			debugPrintln("Type Error:", err)
+			if typeErr, ok := err.(types.Error); ok {
+				typeErrors = append(typeErrors, typeErr)
+			}
+		},
+	}
+	typesPkg, _ := conf.Check(pkg.Name, fset, files, info)
+	return typesPkg, info, typeErrors
+}
+
+// Returns the first type error inside of a node that hasn't been reported yet
+func (v *Visitor) findTypeError(node ast.Node) (types.Error, bool) {
+	for _, err := range v.typeErrors {
+		if err.Pos < node.Pos() || err.Pos >= node.End() { continue }
+		if v.reportedTypeErrors[err.Pos] { continue }
+		return err, true
+	}
+	return types.Error{}, false
+}
+
+// Reports the type errors inside of the tagged types, because the generated code would be wrong or wouldn't compile.
+// Soft errors are left out, because they are usually caused by the methods that are about to be generated (ie a type argument that doesn't satisfy cod.Codec yet)
+func (v *Visitor) checkTypeErrors() {
+	for _, node := range v.tagged {
+		for {
+			err, ok := v.findTypeError(node)
+			if !ok { break }
+			v.reportedTypeErrors[err.Pos] = true
+			if err.Soft { continue }
+			v.errorf(err.Pos, "%s", err.Msg)
+		}
+	}
+}
+
+// Returns true if the type (or its pointer) has the EncodeCod and DecodeCod methods
+func hasCodMethods(t types.Type) bool {
+	enc, _, _ := types.LookupFieldOrMethod(t, true, nil, "EncodeCod")
+	if _, ok := enc.(*types.Func); !ok { return false }
+	dec, _, _ := types.LookupFieldOrMethod(types.NewPointer(t), true, nil, "DecodeCod")
+	if _, ok := dec.(*types.Func); !ok { return false }
+	return true
+}
+
+// Returns true if the type has the method. Methods that the generated code calls on values must not have a pointer receiver
+func hasMethod(t types.Type, name string, pointer bool) bool {
+	if pointer {
+		t = types.NewPointer(t)
+	}
+	obj, _, _ := types.LookupFieldOrMethod(t, false, nil, name)
+	_, ok := obj.(*types.Func)
+	return ok
+}
+
+// Returns true if the type is tagged in another package, so it will have every generated method once that package is generated (even if its generated file is stale)
+func (v *Visitor) isExternalTagged(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() != v.typesPkg && hasExternalDirective(named.Obj())
+}
+
+// Returns true if the type will have a DecodeCodWithLimits function. Tagged types from other packages always will once they are regenerated
+func (v *Visitor) hasLimitedDecode(t types.Type) bool {
+	dec, _, _ := types.LookupFieldOrMethod(types.NewPointer(t), true, nil, "DecodeCodWithLimits")
+	if _, ok := dec.(*types.Func); ok { return true }
+
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() != v.typesPkg && hasExternalDirective(named.Obj())
+}
+
+func isCodUnion(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok { return false }
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == "github.com/unitoftime/cod" && obj.Name() == "Union"
+}
+
+// Returns the type parameter names of a generic type.
+// Generated methods can't add constraints to a type parameter, so each one must already be constrained to types that have the generated methods (ie cod.Codec[T]),
+// and have a pointer type parameter constrained to the generated decode methods (ie cod.CodecPtr[T])
+func (v *Visitor) getTypeParams(s *ast.TypeSpec, trackImports bool) []string {
+	if s.TypeParams == nil { return nil }
+
+	names := make([]string, 0)
+	for _, f := range s.TypeParams.List {
+		for _, n := range f.Names {
+			names = append(names, n.Name)
+		}
+	}
+
+	if v.info == nil { return names }
+	obj, ok := v.info.Defs[s.Name].(*types.TypeName)
+	if !ok { return names }
+	named, ok := obj.Type().(*types.Named)
+	if !ok { return names }
+
+	for i := 0; i < named.TypeParams().Len(); i++ {
+		tp := named.TypeParams().At(i)
+		elem, ok := pointerTypeParam(tp)
+		if !ok { continue }
+		v.typeParamPtrs[elem] = tp.Obj().Name()
+		if trackImports && !hasMethods(tp.Constraint().Underlying().(*types.Interface), decoderMethods) {
+			v.errorf(tp.Obj().Pos(), "type parameter %s of %s must be constrained to cod.CodecPtr[%s]", tp.Obj().Name(), s.Name.Name, elem.Obj().Name())
+		}
+	}
+	if !trackImports { return names }
+
+	for i := 0; i < named.TypeParams().Len(); i++ {
+		tp := named.TypeParams().At(i)
+		if _, ok := pointerTypeParam(tp); ok { continue }
+		iface, ok := tp.Constraint().Underlying().(*types.Interface)
+		if !ok || !hasMethods(iface, codecMethods) {
+			v.errorf(tp.Obj().Pos(), "type parameter %s of %s must be constrained to cod.Codec[%s]", tp.Obj().Name(), s.Name.Name, tp.Obj().Name())
+			continue
+		}
+		if _, ok := v.typeParamPtrs[tp]; !ok {
+			v.errorf(tp.Obj().Pos(), "type parameter %s of %s needs a pointer type parameter constrained to cod.CodecPtr[%s]", tp.Obj().Name(), s.Name.Name, tp.Obj().Name())
+		}
+	}
+	return names
+}
+
+// The methods of cod.Codec, which the generated methods call on type parameters
+var codecMethods = []string{"EncodeCod", "CodEquals", "CodSize", "CodClone"}
+
+// The methods of cod.CodecPtr, which the generated methods call on pointer type parameters
+var decoderMethods = []string{"DecodeCod", "DecodeCodWithLimits"}
+
+func hasMethods(iface *types.Interface, methods []string) bool {
+	for _, name := range methods {
+		found := false
+		for i := 0; i < iface.NumMethods(); i++ {
+			if iface.Method(i).Name() == name { found = true }
+		}
+		if !found { return false }
+	}
+	return true
+}
+
+// Returns the type parameter that tp points to, if tp is constrained to a pointer to another type parameter (ie PT interface{ *T })
+func pointerTypeParam(tp *types.TypeParam) (*types.TypeParam, bool) {
+	iface, ok := tp.Constraint().Underlying().(*types.Interface)
+	if !ok { return nil, false }
+	for i := 0; i < iface.NumEmbeddeds(); i++ {
+		ptr, ok := iface.EmbeddedType(i).(*types.Pointer)
+		if !ok { continue }
+		elem, ok := ptr.Elem().(*types.TypeParam)
+		if ok { return elem, true }
+	}
+	return nil, false
+}
+
+func (v *Visitor) typeParam(expr ast.Expr) (*types.TypeParam, bool) {
+	if v.info == nil { return nil, false }
+	tp, ok := v.info.TypeOf(expr).(*types.TypeParam)
+	return tp, ok
+}
+
+// Marks the packages that are referenced in a type expression (ie the type arguments of subpackage.Option[subpackage.Vec]) as used
+func (v *Visitor) trackExprImports(expr ast.Expr) {
+	ast.Inspect(expr, func(node ast.Node) bool {
+		sel, ok := node.(*ast.SelectorExpr)
+		if !ok { return true }
+		x, ok := sel.X.(*ast.Ident)
+		if ok {
+			v.useImport(x.Name, x.Pos())
+		}
+		return false
+	})
+}
+
+// An unresolvedType is a field type that doesn't have cod methods and isn't a basic type.
+// It is only valid if it is a type in the current package that will have code generated for it.
+type unresolvedType struct {
+	Pos token.Pos
+	Name string
+}
+
+// Uses the type information to decide how a named or basic field type should be encoded
+func (v *Visitor) resolveBasicField(field *BasicField, expr ast.Expr, trackImports bool) {
+	if v.info == nil { return }
+	t := v.info.TypeOf(expr)
+	if t == nil || t == types.Typ[types.Invalid] {
+		if !trackImports { return }
+		typeErr, ok := v.findTypeError(expr)
+		if ok {
+			v.reportedTypeErrors[typeErr.Pos] = true
+			v.errorf(expr.Pos(), "couldn't resolve field type %s: %s", types.ExprString(expr), typeErr.Msg)
+			return
+		}
+		v.errorf(expr.Pos(), "couldn't resolve field type %s", types.ExprString(expr))
+		return
+	}
+
+	if hasCodMethods(t) {
+		// Has custom encoders or generated ones, so use them
+		field.Limited = v.hasLimitedDecode(t)
+		if !v.isExternalTagged(t) {
+			// Hand written types only need EncodeCod, DecodeCod and CodEquals. The generated code falls back for the other methods
+			field.NoSize = !hasMethod(t, "CodSize", false)
+			field.NoClone = !hasMethod(t, "CodClone", false)
+			field.NoDelta = !hasMethod(t, "EncodeCodDelta", false) || !hasMethod(t, "DecodeCodDelta", true)
+		}
+		return
+	}
+	if isCodUnion(t) {
+		return // Unions are generated from their def, so the underlying cod.Union is never encoded directly
+	}
+
+	basic, ok := t.Underlying().(*types.Basic)
+	if ok {
+		// Note: Indexing by kind maps aliases like byte and rune to their real names
+		basicName := types.Typ[basic.Kind()].Name()
+		if _, supported := supportedApis[basicName]; supported {
+			if basicName != field.Type {
+				field.Underlying = basicName
+			}
+			return
+		}
+	}
+
+	if !trackImports { return } // We don't generate encoders for this request, so we don't care
+
+	named, ok := t.(*types.Named)
+	if ok && named.Obj().Pkg() == v.typesPkg {
+		// A type from the current package may be tagged, in which case its methods don't exist yet
+		field.Limited = true
+		v.unresolved = append(v.unresolved, unresolvedType{
+			Pos: expr.Pos(),
+			Name: named.Obj().Name(),
+		})
+		return
+	}
+	if ok && hasExternalDirective(named.Obj()) {
+		field.Limited = true
+		return // Tagged in its own package, so it will have methods once that package is generated
+	}
+
+	v.errorf(expr.Pos(), "unsupported field type %s: it must be a basic type or implement EncodeCod and DecodeCod", t)
+}
+
+// Checks that all types from the current package that didn't have methods will have them generated
+func (v *Visitor) checkUnresolved() {
+	for _, u := range v.unresolved {
+		if v.generatesMethods(u.Name) { continue }
+		v.errorf(u.Pos, "unsupported field type %s: it must be tagged with //cod:struct or //cod:union", u.Name)
+	}
+}
+
+// Returns true if the type has a request that will generate EncodeCod and DecodeCod
+func (v *Visitor) generatesMethods(name string) bool {
+	for _, req := range v.requests[name] {
+		if req.Type == RequestTypeSerdes || req.Type == RequestTypeUnion {
+			return true
+		}
+	}
+	return false
+}
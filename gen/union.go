@@ -0,0 +1,312 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Controls how the union tag is written. Set with options after the def name: //cod:union <UnionDefType>, varint
+type unionConfig struct {
+	TagApi string // The backend api used to read and write the tag
+	TagType string // The type returned by Tag()
+	MaxTag int // The largest tag that can be written
+	SetError bool // If true, Set returns an error for non-member types instead of panicking
+}
+
+func getUnionConfig(csv []string) unionConfig {
+	// Default: A single byte, which supports 255 types
+	config := unionConfig{
+		TagApi: "Uint8",
+		TagType: "uint8",
+		MaxTag: math.MaxUint8,
+	}
+
+	for _, opt := range csv[1:] {
+		switch opt {
+		case "varint":
+			// Uvarint tags use one byte for the first 127 tags, and grow as needed after that
+			config.TagApi = "VarUint64"
+			config.TagType = "uint64"
+			config.MaxTag = math.MaxInt32
+		case "seterror":
+			config.SetError = true
+		default:
+			panic(fmt.Sprintf("unknown union option: %s", opt))
+		}
+	}
+	return config
+}
+
+// Looks up the union def, which must be the first csv element, and assigns a tag to each of its types.
+// Types tagged with `cod.tag:"N"` are pinned to N, all others use their position in the def (starting at 1)
+func getUnionFields(csv []string, structs map[string]StructData) []UnionField {
+	config := getUnionConfig(csv)
+
+	unionDefName := csv[0]
+	unionDef, ok := structs[unionDefName]
+	if !ok { panic(fmt.Sprintf("couldn't find union def %s: it must be the first option (//cod:union <UnionDefType>) and be tagged with //cod:def", unionDefName)) }
+
+	debugPrintln("UnionDef: ", unionDef.Fields)
+	unionFields := make([]UnionField, 0, len(unionDef.Fields))
+	usedTags := make(map[int]string)
+	for i, f := range unionDef.Fields {
+		unionTag := i+1
+		tagStr := tagSearchUnionTag(f.GetTag())
+		if tagStr != "" {
+			var err error
+			unionTag, err = strconv.Atoi(tagStr)
+			if err != nil {
+				panic(fmt.Sprintf("union def %s: invalid tag for %s: %s", unionDefName, f.GetType(), tagStr))
+			}
+		}
+
+		if unionTag <= 0 || unionTag > config.MaxTag {
+			panic(fmt.Sprintf("union def %s: tag for %s must be between 1 and %d, got %d", unionDefName, f.GetType(), config.MaxTag, unionTag))
+		}
+		otherType, used := usedTags[unionTag]
+		if used {
+			panic(fmt.Sprintf("union def %s: tag %d is used by both %s and %s", unionDefName, unionTag, otherType, f.GetType()))
+		}
+		usedTags[unionTag] = f.GetType()
+
+		unionFields = append(unionFields, NewUnionField(f, unionTag))
+	}
+
+	// Variants are named after their type. If two types share a name, then they are both prefixed with their package
+	variantCount := make(map[string]int)
+	for _, f := range unionFields {
+		variantCount[variantName(f.GetType(), false)]++
+	}
+	usedVariants := make(map[string]string)
+	for i, f := range unionFields {
+		variant := variantName(f.GetType(), false)
+		if variantCount[variant] > 1 {
+			variant = variantName(f.GetType(), true)
+		}
+		otherType, used := usedVariants[variant]
+		if used {
+			panic(fmt.Sprintf("union def %s: %s and %s both generate methods named %s", unionDefName, otherType, f.GetType(), variant))
+		}
+		usedVariants[variant] = f.GetType()
+		unionFields[i].Variant = variant
+	}
+	return unionFields
+}
+
+// Converts a type name (ie subpackage.Vec) into a name that can be used in a method (ie Vec or SubpackageVec)
+func variantName(typeName string, withPackage bool) string {
+	pkg, name, found := strings.Cut(typeName, ".")
+	if !found {
+		return typeName
+	}
+	if !withPackage {
+		return name
+	}
+	return strings.ToUpper(pkg[:1]) + pkg[1:] + name
+}
+
+func maxUnionTag(unionFields []UnionField) int {
+	ret := 0
+	for _, f := range unionFields {
+		ret = max(ret, f.UnionTag)
+	}
+	return ret
+}
+
+func GenerateUnionData(sd StructData, csv []string, structs map[string]StructData, buf *bytes.Buffer) {
+	marshBuf := new(bytes.Buffer)
+	unmarshBuf := new(bytes.Buffer)
+
+	debugPrintln("Union: ", sd.Name)
+
+	// If no fields, then its a blank struct
+	if len(sd.Fields) <= 0 {
+		// Write the encode func
+		GenerateBlankSerdesData(sd, buf)
+		return
+	}
+
+	// Write the marshal code
+	WriteUnionMarshal(sd, csv, structs, marshBuf)
+	err := basicTemp.ExecuteTemplate(buf, "marshal_func", map[string]any{
+		"Name": sd.Name,
+		"MarshalCode": marshBuf.String(),
+	})
+	if err != nil { panic(err) }
+
+	// Write the unmarshal code
+	WriteUnionUnmarshal(sd, csv, structs, unmarshBuf)
+	err = basicTemp.ExecuteTemplate(buf, "unmarshal_func", map[string]any{
+		"Name": sd.Name,
+		"MarshalCode": unmarshBuf.String(),
+	})
+	if err != nil { panic(err) }
+
+	// Special Union funcs
+	WriteUnionCodeToBuffer(sd, csv, structs, buf)
+	WriteUnionEqualityCode(sd, csv, structs, buf)
+	WriteUnionCloneCode(sd, csv, structs, buf)
+	WriteUnionDeltaCode(sd, csv, structs, buf)
+	WriteUnionSizeCode(sd, csv, structs, buf)
+
+	//----------------------------------------
+	// - Union Helper Functions
+	//----------------------------------------
+
+	// Create constructors, getters, setters per union type
+	config := getUnionConfig(csv)
+	unionFields := getUnionFields(csv, structs)
+	unionTypes := make([]string, 0, len(unionFields))
+	for _, f := range unionFields {
+		unionTypes = append(unionTypes, f.GetType())
+	}
+
+	err = basicTemp.ExecuteTemplate(buf, "union_getter", map[string]any{
+		"Name": sd.Name,
+	})
+	if err != nil { panic(err) }
+	err = basicTemp.ExecuteTemplate(buf, "union_setter", map[string]any{
+		"Name": sd.Name,
+		"Types": unionTypes,
+		"SetError": config.SetError,
+	})
+	if err != nil { panic(err) }
+	err = basicTemp.ExecuteTemplate(buf, "union_constructor", map[string]any{
+		"Name": sd.Name,
+		"SetError": config.SetError,
+	})
+	if err != nil { panic(err) }
+
+	for _, f := range unionFields {
+		err = basicTemp.ExecuteTemplate(buf, "union_variant", map[string]any{
+			"Name": sd.Name,
+			"Type": f.GetType(),
+			"Variant": f.Variant,
+		})
+		if err != nil { panic(err) }
+	}
+}
+
+func WriteUnionMarshal(sd StructData, csv []string, structs map[string]StructData, buf *bytes.Buffer) {
+	unionFields := getUnionFields(csv, structs)
+
+	innerBuf := new(bytes.Buffer)
+	for _, f := range unionFields {
+		f.WriteMarshal(innerBuf)
+	}
+	config := getUnionConfig(csv)
+	err := basicTemp.ExecuteTemplate(buf, "union_marshal", map[string]any{
+		"InnerCode": innerBuf.String(),
+		"TagApi": config.TagApi,
+		"TagType": config.TagType,
+	})
+	if err != nil { panic(err) }
+}
+
+func WriteUnionEqualityCode(sd StructData, csv []string, structs map[string]StructData, buf *bytes.Buffer) {
+	unionFields := getUnionFields(csv, structs)
+
+	innerBuf := new(bytes.Buffer)
+	for _, f := range unionFields {
+		err := basicTemp.ExecuteTemplate(innerBuf, "union_case_equality", map[string]any{
+			"Name": f.Name,
+			"Name2": "t"+f.Name,
+			"Type": f.GetType(),
+			"Tag": f.UnionTag,
+		})
+		if err != nil { panic(err) }
+	}
+
+	err := basicTemp.ExecuteTemplate(buf, "union_equality_func", map[string]any{
+		"Name": sd.Name,
+		"InnerCode": innerBuf.String(),
+	})
+	if err != nil { panic(err) }
+}
+
+func WriteUnionCloneCode(sd StructData, csv []string, structs map[string]StructData, buf *bytes.Buffer) {
+	unionFields := getUnionFields(csv, structs)
+
+	innerBuf := new(bytes.Buffer)
+	for _, f := range unionFields {
+		f.WriteClone(innerBuf)
+	}
+
+	err := basicTemp.ExecuteTemplate(buf, "union_clone_func", map[string]any{
+		"Name": sd.Name,
+		"InnerCode": innerBuf.String(),
+	})
+	if err != nil { panic(err) }
+}
+
+func WriteUnionSizeCode(sd StructData, csv []string, structs map[string]StructData, buf *bytes.Buffer) {
+	unionFields := getUnionFields(csv, structs)
+
+	innerBuf := new(bytes.Buffer)
+	for _, f := range unionFields {
+		f.WriteSize(innerBuf)
+	}
+
+	config := getUnionConfig(csv)
+	err := basicTemp.ExecuteTemplate(buf, "union_size_func", map[string]any{
+		"Name": sd.Name,
+		"InnerCode": innerBuf.String(),
+		"TagApi": config.TagApi,
+	})
+	if err != nil { panic(err) }
+}
+
+func WriteUnionUnmarshal(sd StructData, csv []string, structs map[string]StructData, buf *bytes.Buffer) {
+	unionFields := getUnionFields(csv, structs)
+
+	innerBuf := new(bytes.Buffer)
+	for _, f := range unionFields {
+		f.WriteUnmarshal(innerBuf)
+	}
+
+	config := getUnionConfig(csv)
+	err := basicTemp.ExecuteTemplate(buf, "union_unmarshal", map[string]any{
+		"Name": sd.Name,
+		"InnerCode": innerBuf.String(),
+		"TagApi": config.TagApi,
+		"TagType": config.TagType,
+	})
+	if err != nil { panic(err) }
+}
+
+func WriteUnionCodeToBuffer(sd StructData, csv []string, structs map[string]StructData, buf *bytes.Buffer) {
+	unionFields := getUnionFields(csv, structs)
+
+	// GetTag()
+	{
+		innerBuf := new(bytes.Buffer)
+		for _, f := range unionFields {
+			err := basicTemp.ExecuteTemplate(innerBuf, "union_case_get_tag", map[string]any{
+				"Name": f.Name,
+				"Type": f.GetType(),
+				"Tag": f.UnionTag,
+			})
+			if err != nil { panic(err) }
+		}
+
+		config := getUnionConfig(csv)
+		err := basicTemp.ExecuteTemplate(buf, "union_get_tag_func", map[string]any{
+			"Name": sd.Name,
+			"InnerCode": innerBuf.String(),
+			"TagType": config.TagType,
+		})
+		if err != nil { panic(err) }
+	}
+
+	// GetSize()
+	{
+		err := basicTemp.ExecuteTemplate(buf, "union_get_size_func", map[string]any{
+			"Name": sd.Name,
+			"Size": maxUnionTag(unionFields) + 1, // Note: + 1 b/c 0 is the nil case
+		})
+		if err != nil { panic(err) }
+	}
+}
@@ -0,0 +1,59 @@
+package cod
+
+import (
+	"errors"
+)
+
+var ErrTrailingData = errors.New("cod: unmarshal did not consume all of the data")
+
+type Encoder interface {
+	EncodeCod([]byte) []byte
+}
+
+// Decoders are implemented by pointers to generated types (ie *Person)
+type Decoder interface {
+	DecodeCod([]byte) (int, error)
+}
+
+// Encodes v into a new byte slice
+func Marshal[T Encoder](v T) []byte {
+	return v.EncodeCod(nil)
+}
+
+// Encodes v onto the end of bs, and returns the extended slice
+func AppendMarshal[T Encoder](bs []byte, v T) []byte {
+	return v.EncodeCod(bs)
+}
+
+type unmarshalConfig struct {
+	strict bool
+}
+
+type UnmarshalOption func(*unmarshalConfig)
+
+// Makes Unmarshal return ErrTrailingData if the value doesn't use the whole buffer
+func Strict() UnmarshalOption {
+	return func(c *unmarshalConfig) {
+		c.strict = true
+	}
+}
+
+// Decodes a T from the start of bs. Only the type needs to be passed, because *T is inferred (ie cod.Unmarshal[Person](bs))
+func Unmarshal[T any, PT interface{ *T; Decoder }](bs []byte, opts ...UnmarshalOption) (T, error) {
+	config := unmarshalConfig{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	var v T
+	n, err := PT(&v).DecodeCod(bs)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	if config.strict && n != len(bs) {
+		var zero T
+		return zero, ErrTrailingData
+	}
+	return v, nil
+}
@@ -0,0 +1,70 @@
+package reflect
+
+import (
+	goreflect "reflect"
+	"strings"
+
+	"github.com/unitoftime/cod/backend"
+)
+
+// Returns the CodEquals method of a type, if it has the generated (or hand-crafted) one
+func codEqualsMethod(t goreflect.Type) (goreflect.Method, bool) {
+	m, ok := t.MethodByName("CodEquals")
+	if !ok { return m, false }
+	if m.Type.NumIn() != 2 || m.Type.In(1) != t { return m, false }
+	if m.Type.NumOut() != 1 || m.Type.Out(0).Kind() != goreflect.Bool { return m, false }
+	return m, true
+}
+
+// Compares two values of the same type the way that the generated CodEquals does.
+// Slices and maps are compared by their elements (so nil equals empty), floats are compared with backend.EqualFloat, and struct fields tagged with cod.skip:"equality" are left out
+func equalValue(a, b goreflect.Value) bool {
+	kind := a.Kind()
+	if kind == goreflect.Pointer {
+		if a.IsNil() || b.IsNil() { return a.IsNil() == b.IsNil() }
+		return equalValue(a.Elem(), b.Elem())
+	}
+
+	if m, ok := codEqualsMethod(a.Type()); ok {
+		return m.Func.Call([]goreflect.Value{a, b})[0].Bool()
+	}
+
+	switch kind {
+	case goreflect.Struct:
+		t := a.Type()
+		a, b = addressable(a), addressable(b)
+		for i := 0; i < t.NumField(); i++ {
+			if strings.Contains(t.Field(i).Tag.Get("cod.skip"), "equality") { continue }
+			if !equalValue(field(a, i), field(b, i)) { return false }
+		}
+		return true
+
+	case goreflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			if !equalValue(a.Index(i), b.Index(i)) { return false }
+		}
+		return true
+
+	case goreflect.Slice:
+		if a.Len() != b.Len() { return false }
+		for i := 0; i < a.Len(); i++ {
+			if !equalValue(a.Index(i), b.Index(i)) { return false }
+		}
+		return true
+
+	case goreflect.Map:
+		if a.Len() != b.Len() { return false }
+		iter := a.MapRange()
+		for iter.Next() {
+			bv := b.MapIndex(iter.Key())
+			if !bv.IsValid() { return false }
+			if !equalValue(iter.Value(), bv) { return false }
+		}
+		return true
+
+	case goreflect.Float32, goreflect.Float64:
+		return backend.EqualFloat(a.Float(), b.Float())
+	}
+
+	return a.Equal(b)
+}
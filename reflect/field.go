@@ -0,0 +1,57 @@
+package reflect
+
+import (
+	goreflect "reflect"
+
+	"github.com/unitoftime/cod/backend"
+)
+
+// Wraps a type that doesn't have generated methods, so that it can be used as a field of a generated struct (ie Field[thirdparty.Config]).
+// The value is encoded with reflection. EncodeCod panics if the type isn't supported
+type Field[T any] struct {
+	Val T
+}
+
+func (t Field[T]) EncodeCod(bs []byte) []byte {
+	bs, err := Append(bs, t.Val)
+	if err != nil { panic(err) }
+	return bs
+}
+
+func (t *Field[T]) DecodeCod(bs []byte) (int, error) {
+	return Unmarshal(bs, &t.Val)
+}
+
+// Compares the values the same way as the generated CodEquals (ie NaN equals NaN, and nil slices equal empty ones)
+func (t Field[T]) CodEquals(tt Field[T]) bool {
+	return equalValue(goreflect.ValueOf(&t.Val).Elem(), goreflect.ValueOf(&tt.Val).Elem())
+}
+
+func (t Field[T]) CodSize() int {
+	return len(t.EncodeCod(nil))
+}
+
+// Clones by encoding and decoding, so that the clone doesn't share memory
+func (t Field[T]) CodClone() Field[T] {
+	var ct Field[T]
+	_, err := ct.DecodeCod(t.EncodeCod(nil))
+	if err != nil { panic(err) }
+	return ct
+}
+
+// Deltas are always written in full
+func (t Field[T]) EncodeCodDelta(bs []byte, tt Field[T]) []byte {
+	return t.EncodeCod(bs)
+}
+
+func (t *Field[T]) DecodeCodDelta(bs []byte, tt Field[T]) (int, error) {
+	return t.DecodeCod(bs)
+}
+
+func (t Field[T]) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *Field[T]) DecodeCodFrom(r *backend.Reader) error {
+	return r.Decode(t.DecodeCod)
+}
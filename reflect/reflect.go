@@ -0,0 +1,507 @@
+// Package reflect encodes and decodes values with reflection, using the same wire format as the generated code.
+// It is slower than the generated code, but works for types that can't be generated (ie types from other packages).
+// Types that have generated methods are encoded with them, so generated and non-generated types can be mixed in one message.
+//
+// Struct fields are encoded in order, and honor the cod.cast, cod.skip, cod.fixed and cod.deterministic tags.
+// Struct directive options (ie evolvable and bitpack) can't be seen with reflection, so those types must be generated
+package reflect
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	goreflect "reflect"
+	"slices"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"github.com/unitoftime/cod"
+	"github.com/unitoftime/cod/backend"
+)
+
+var ErrUnsupportedType = errors.New("cod: unsupported type")
+
+var encoderType = goreflect.TypeFor[cod.Encoder]()
+var decoderType = goreflect.TypeFor[cod.Decoder]()
+
+// The types that can be used with the cod.cast tag
+var castTypes = map[string]goreflect.Type{
+	"uint8": goreflect.TypeFor[uint8](),
+	"int8": goreflect.TypeFor[int8](),
+	"uint": goreflect.TypeFor[uint](),
+	"int": goreflect.TypeFor[int](),
+	"uint16": goreflect.TypeFor[uint16](),
+	"uint32": goreflect.TypeFor[uint32](),
+	"uint64": goreflect.TypeFor[uint64](),
+	"int16": goreflect.TypeFor[int16](),
+	"int32": goreflect.TypeFor[int32](),
+	"int64": goreflect.TypeFor[int64](),
+	"float32": goreflect.TypeFor[float32](),
+	"float64": goreflect.TypeFor[float64](),
+	"string": goreflect.TypeFor[string](),
+	"bool": goreflect.TypeFor[bool](),
+}
+
+// Encodes v into a new byte slice
+func Marshal(v any) ([]byte, error) {
+	return Append(nil, v)
+}
+
+// Encodes v onto the end of bs, and returns the extended slice
+func Append(bs []byte, v any) ([]byte, error) {
+	rv := goreflect.ValueOf(v)
+	if !rv.IsValid() {
+		return bs, fmt.Errorf("%w: nil", ErrUnsupportedType)
+	}
+	return appendValue(bs, rv, fieldTag{})
+}
+
+// Decodes into v, which must be a non-nil pointer, and returns the number of bytes read
+func Unmarshal(bs []byte, v any) (int, error) {
+	rv := goreflect.ValueOf(v)
+	if rv.Kind() != goreflect.Pointer || rv.IsNil() {
+		return 0, fmt.Errorf("%w: Unmarshal needs a non-nil pointer, got %T", ErrUnsupportedType, v)
+	}
+	return decodeValue(bs, rv.Elem(), fieldTag{})
+}
+
+// The options from a struct field tag. Like the generated code, they apply to the elements of slices, arrays, maps and pointers too
+type fieldTag struct {
+	Cast goreflect.Type // The type to convert basic values to before they are encoded
+	Fixed bool // If true, integers are encoded with fixed width
+	Deterministic bool // If true, maps are encoded in sorted key order
+}
+
+type structField struct {
+	Index int
+	Tag fieldTag
+}
+
+var structFieldCache sync.Map // map[goreflect.Type][]structField
+
+// Returns the fields of a struct that are encoded, with their parsed tags
+func getStructFields(t goreflect.Type) ([]structField, error) {
+	cached, ok := structFieldCache.Load(t)
+	if ok { return cached.([]structField), nil }
+
+	fields := make([]structField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if strings.Contains(f.Tag.Get("cod.skip"), "serdes") { continue }
+
+		tag := fieldTag{
+			Fixed: f.Tag.Get("cod.fixed") == "true",
+			Deterministic: f.Tag.Get("cod.deterministic") == "true",
+		}
+		castName := f.Tag.Get("cod.cast")
+		if castName != "" {
+			tag.Cast, ok = castTypes[castName]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s.%s has an unsupported cast: %s", ErrUnsupportedType, t, f.Name, castName)
+			}
+		}
+		fields = append(fields, structField{i, tag})
+	}
+
+	structFieldCache.Store(t, fields)
+	return fields, nil
+}
+
+// Returns true if the type has the generated (or hand-crafted) EncodeCod and DecodeCod methods
+func hasCodMethods(t goreflect.Type) bool {
+	return t.Implements(encoderType) && goreflect.PointerTo(t).Implements(decoderType)
+}
+
+func isBasicKind(k goreflect.Kind) bool {
+	switch k {
+	case goreflect.Bool, goreflect.String, goreflect.Float32, goreflect.Float64,
+		goreflect.Int, goreflect.Int8, goreflect.Int16, goreflect.Int32, goreflect.Int64,
+		goreflect.Uint, goreflect.Uint8, goreflect.Uint16, goreflect.Uint32, goreflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// Returns a settable version of a struct field, including unexported fields. Like the generated code, unexported fields are encoded
+func field(v goreflect.Value, i int) goreflect.Value {
+	f := v.Field(i)
+	if f.CanSet() { return f }
+	return goreflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+}
+
+// Returns an addressable copy of v if it isn't addressable, so that its unexported fields can be read
+func addressable(v goreflect.Value) goreflect.Value {
+	if v.CanAddr() { return v }
+	c := goreflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
+}
+
+func appendValue(bs []byte, v goreflect.Value, tag fieldTag) ([]byte, error) {
+	kind := v.Kind()
+	if kind == goreflect.Pointer {
+		if v.IsNil() {
+			return backend.WriteUint8(bs, 0), nil // Zero tag indicates nil
+		}
+		bs = backend.WriteUint8(bs, 1)
+		return appendValue(bs, v.Elem(), tag)
+	}
+
+	// Note: Like the generated code, a cast takes priority over the methods of the type
+	if tag.Cast != nil && isBasicKind(kind) {
+		if !v.CanConvert(tag.Cast) {
+			return bs, fmt.Errorf("%w: can't cast %s to %s", ErrUnsupportedType, v.Type(), tag.Cast)
+		}
+		return appendBasic(bs, v.Convert(tag.Cast), tag.Fixed)
+	}
+	if hasCodMethods(v.Type()) {
+		return v.Interface().(cod.Encoder).EncodeCod(bs), nil
+	}
+
+	switch kind {
+	case goreflect.Struct:
+		fields, err := getStructFields(v.Type())
+		if err != nil { return bs, err }
+		v = addressable(v)
+		for _, f := range fields {
+			bs, err = appendValue(bs, field(v, f.Index), f.Tag)
+			if err != nil { return bs, err }
+		}
+		return bs, nil
+
+	case goreflect.Array:
+		var err error
+		for i := 0; i < v.Len(); i++ {
+			bs, err = appendValue(bs, v.Index(i), tag)
+			if err != nil { return bs, err }
+		}
+		return bs, nil
+
+	case goreflect.Slice:
+		bs = backend.WriteVarUint64(bs, uint64(v.Len()))
+		var err error
+		for i := 0; i < v.Len(); i++ {
+			bs, err = appendValue(bs, v.Index(i), tag)
+			if err != nil { return bs, err }
+		}
+		return bs, nil
+
+	case goreflect.Map:
+		bs = backend.WriteVarUint64(bs, uint64(v.Len()))
+		keys := v.MapKeys()
+		if tag.Deterministic {
+			if !isOrderedKey(v.Type().Key(), tag) {
+				return appendSortedEntries(bs, v, keys, tag)
+			}
+			sortKeys(keys)
+		}
+
+		var err error
+		for _, k := range keys {
+			bs, err = appendValue(bs, k, tag)
+			if err != nil { return bs, err }
+			bs, err = appendValue(bs, v.MapIndex(k), tag)
+			if err != nil { return bs, err }
+		}
+		return bs, nil
+	}
+
+	if isBasicKind(kind) {
+		return appendBasic(bs, v, tag.Fixed)
+	}
+	return bs, fmt.Errorf("%w: %s", ErrUnsupportedType, v.Type())
+}
+
+// Returns true if the generated code sorts the keys with backend.SortedKeys. Other keys are sorted by their encoded bytes
+func isOrderedKey(t goreflect.Type, tag fieldTag) bool {
+	if t.Kind() == goreflect.Bool || !isBasicKind(t.Kind()) { return false }
+	return tag.Cast != nil || !hasCodMethods(t)
+}
+
+// Sorts map keys the same way as backend.SortedKeys
+func sortKeys(keys []goreflect.Value) {
+	if len(keys) == 0 { return }
+	switch keys[0].Kind() {
+	case goreflect.String:
+		slices.SortFunc(keys, func(a, b goreflect.Value) int { return cmp.Compare(a.String(), b.String()) })
+	case goreflect.Int, goreflect.Int8, goreflect.Int16, goreflect.Int32, goreflect.Int64:
+		slices.SortFunc(keys, func(a, b goreflect.Value) int { return cmp.Compare(a.Int(), b.Int()) })
+	case goreflect.Uint, goreflect.Uint8, goreflect.Uint16, goreflect.Uint32, goreflect.Uint64, goreflect.Uintptr:
+		slices.SortFunc(keys, func(a, b goreflect.Value) int { return cmp.Compare(a.Uint(), b.Uint()) })
+	case goreflect.Float32, goreflect.Float64:
+		slices.SortFunc(keys, func(a, b goreflect.Value) int { return cmp.Compare(a.Float(), b.Float()) })
+	}
+}
+
+// Writes the map entries in the order of their encoded bytes, the same way as backend.AppendSortedEntries
+func appendSortedEntries(bs []byte, v goreflect.Value, keys []goreflect.Value, tag fieldTag) ([]byte, error) {
+	entries := make([][]byte, 0, len(keys))
+	for _, k := range keys {
+		entry, err := appendValue(nil, k, tag)
+		if err != nil { return bs, err }
+		entry, err = appendValue(entry, v.MapIndex(k), tag)
+		if err != nil { return bs, err }
+		entries = append(entries, entry)
+	}
+	slices.SortFunc(entries, bytes.Compare)
+	for _, e := range entries {
+		bs = append(bs, e...)
+	}
+	return bs, nil
+}
+
+// Writes a basic value with the same backend api that the generated code uses for its kind
+func appendBasic(bs []byte, v goreflect.Value, fixed bool) ([]byte, error) {
+	switch v.Kind() {
+	case goreflect.Bool:
+		return backend.WriteBool(bs, v.Bool()), nil
+	case goreflect.String:
+		return backend.WriteString(bs, v.String()), nil
+	case goreflect.Float32:
+		return backend.WriteFloat32(bs, float32(v.Float())), nil
+	case goreflect.Float64:
+		return backend.WriteFloat64(bs, v.Float()), nil
+	case goreflect.Uint8:
+		return backend.WriteUint8(bs, uint8(v.Uint())), nil
+	case goreflect.Int8:
+		return backend.WriteInt8(bs, int8(v.Int())), nil
+	}
+
+	if fixed {
+		switch v.Kind() {
+		case goreflect.Uint, goreflect.Uint64:
+			return backend.WriteUint64(bs, v.Uint()), nil
+		case goreflect.Uint16:
+			return backend.WriteUint16(bs, uint16(v.Uint())), nil
+		case goreflect.Uint32:
+			return backend.WriteUint32(bs, uint32(v.Uint())), nil
+		case goreflect.Int, goreflect.Int64:
+			return backend.WriteInt64(bs, v.Int()), nil
+		case goreflect.Int16:
+			return backend.WriteInt16(bs, int16(v.Int())), nil
+		case goreflect.Int32:
+			return backend.WriteInt32(bs, int32(v.Int())), nil
+		}
+	}
+
+	switch v.Kind() {
+	case goreflect.Uint:
+		return backend.WriteUint(bs, uint(v.Uint())), nil
+	case goreflect.Uint16:
+		return backend.WriteVarUint16(bs, uint16(v.Uint())), nil
+	case goreflect.Uint32:
+		return backend.WriteVarUint32(bs, uint32(v.Uint())), nil
+	case goreflect.Uint64:
+		return backend.WriteVarUint64(bs, v.Uint()), nil
+	case goreflect.Int:
+		return backend.WriteInt(bs, int(v.Int())), nil
+	case goreflect.Int16:
+		return backend.WriteVarInt16(bs, int16(v.Int())), nil
+	case goreflect.Int32:
+		return backend.WriteVarInt32(bs, int32(v.Int())), nil
+	case goreflect.Int64:
+		return backend.WriteVarInt64(bs, v.Int()), nil
+	}
+	return bs, fmt.Errorf("%w: %s", ErrUnsupportedType, v.Type())
+}
+
+// Decodes into v, which must be settable. Like the generated code, slices and maps are reset and reuse their capacity
+func decodeValue(bs []byte, v goreflect.Value, tag fieldTag) (int, error) {
+	kind := v.Kind()
+	if kind == goreflect.Pointer {
+		tagVal, n, err := backend.ReadUint8(bs)
+		if err != nil { return 0, err }
+		if tagVal == 0 {
+			// Zero tag indicates nil
+			v.SetZero()
+			return n, nil
+		}
+
+		elem := goreflect.New(v.Type().Elem())
+		nOff, err := decodeValue(bs[n:], elem.Elem(), tag)
+		if err != nil { return 0, err }
+		v.Set(elem)
+		return n + nOff, nil
+	}
+
+	if tag.Cast != nil && isBasicKind(kind) {
+		if !tag.Cast.ConvertibleTo(v.Type()) {
+			return 0, fmt.Errorf("%w: can't cast %s to %s", ErrUnsupportedType, tag.Cast, v.Type())
+		}
+		decoded := goreflect.New(tag.Cast).Elem()
+		n, err := decodeBasic(bs, decoded, tag.Fixed)
+		if err != nil { return 0, err }
+		v.Set(decoded.Convert(v.Type()))
+		return n, nil
+	}
+	if hasCodMethods(v.Type()) {
+		return v.Addr().Interface().(cod.Decoder).DecodeCod(bs)
+	}
+
+	switch kind {
+	case goreflect.Struct:
+		fields, err := getStructFields(v.Type())
+		if err != nil { return 0, err }
+		n := 0
+		for _, f := range fields {
+			nOff, err := decodeValue(bs[n:], field(v, f.Index), f.Tag)
+			if err != nil { return 0, err }
+			n += nOff
+		}
+		return n, nil
+
+	case goreflect.Array:
+		n := 0
+		for i := 0; i < v.Len(); i++ {
+			nOff, err := decodeValue(bs[n:], v.Index(i), tag)
+			if err != nil { return 0, err }
+			n += nOff
+		}
+		return n, nil
+
+	case goreflect.Slice:
+		length, n, err := backend.ReadVarUint64(bs)
+		if err != nil { return 0, err }
+
+		s := v
+		if s.IsNil() {
+			if length > 0 {
+				s = goreflect.MakeSlice(v.Type(), 0, backend.PreallocLen(length, len(bs[n:])))
+			}
+		} else {
+			s = s.Slice(0, 0)
+		}
+
+		elemType := v.Type().Elem()
+		for i := 0; i < int(length); i++ {
+			elem := goreflect.New(elemType).Elem()
+			nOff, err := decodeValue(bs[n:], elem, tag)
+			if err != nil { return 0, err }
+			n += nOff
+			s = goreflect.Append(s, elem)
+		}
+		v.Set(s)
+		return n, nil
+
+	case goreflect.Map:
+		length, n, err := backend.ReadVarUint64(bs)
+		if err != nil { return 0, err }
+
+		if v.IsNil() {
+			v.Set(goreflect.MakeMapWithSize(v.Type(), backend.PreallocLen(length, len(bs[n:]))))
+		} else {
+			v.Clear()
+		}
+
+		keyType := v.Type().Key()
+		valType := v.Type().Elem()
+		for i := 0; i < int(length); i++ {
+			key := goreflect.New(keyType).Elem()
+			nOff, err := decodeValue(bs[n:], key, tag)
+			if err != nil { return 0, err }
+			n += nOff
+
+			val := goreflect.New(valType).Elem()
+			nOff, err = decodeValue(bs[n:], val, tag)
+			if err != nil { return 0, err }
+			n += nOff
+
+			v.SetMapIndex(key, val)
+		}
+		return n, nil
+	}
+
+	if isBasicKind(kind) {
+		return decodeBasic(bs, v, tag.Fixed)
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnsupportedType, v.Type())
+}
+
+// Reads a basic value with the same backend api that the generated code uses for its kind
+func decodeBasic(bs []byte, v goreflect.Value, fixed bool) (int, error) {
+	switch v.Kind() {
+	case goreflect.Bool:
+		val, n, err := backend.ReadBool(bs)
+		if err != nil { return 0, err }
+		v.SetBool(val)
+		return n, nil
+	case goreflect.String:
+		val, n, err := backend.ReadString(bs)
+		if err != nil { return 0, err }
+		v.SetString(val)
+		return n, nil
+	case goreflect.Float32:
+		val, n, err := backend.ReadFloat32(bs)
+		if err != nil { return 0, err }
+		v.SetFloat(float64(val))
+		return n, nil
+	case goreflect.Float64:
+		val, n, err := backend.ReadFloat64(bs)
+		if err != nil { return 0, err }
+		v.SetFloat(val)
+		return n, nil
+	case goreflect.Uint8:
+		val, n, err := backend.ReadUint8(bs)
+		if err != nil { return 0, err }
+		v.SetUint(uint64(val))
+		return n, nil
+	case goreflect.Int8:
+		val, n, err := backend.ReadInt8(bs)
+		if err != nil { return 0, err }
+		v.SetInt(int64(val))
+		return n, nil
+	}
+
+	if fixed {
+		switch v.Kind() {
+		case goreflect.Uint, goreflect.Uint64:
+			return decodeUint(bs, v, backend.ReadUint64)
+		case goreflect.Uint16:
+			return decodeUint(bs, v, backend.ReadUint16)
+		case goreflect.Uint32:
+			return decodeUint(bs, v, backend.ReadUint32)
+		case goreflect.Int, goreflect.Int64:
+			return decodeInt(bs, v, backend.ReadInt64)
+		case goreflect.Int16:
+			return decodeInt(bs, v, backend.ReadInt16)
+		case goreflect.Int32:
+			return decodeInt(bs, v, backend.ReadInt32)
+		}
+	}
+
+	switch v.Kind() {
+	case goreflect.Uint:
+		return decodeUint(bs, v, backend.ReadUint)
+	case goreflect.Uint16:
+		return decodeUint(bs, v, backend.ReadVarUint16)
+	case goreflect.Uint32:
+		return decodeUint(bs, v, backend.ReadVarUint32)
+	case goreflect.Uint64:
+		return decodeUint(bs, v, backend.ReadVarUint64)
+	case goreflect.Int:
+		return decodeInt(bs, v, backend.ReadInt)
+	case goreflect.Int16:
+		return decodeInt(bs, v, backend.ReadVarInt16)
+	case goreflect.Int32:
+		return decodeInt(bs, v, backend.ReadVarInt32)
+	case goreflect.Int64:
+		return decodeInt(bs, v, backend.ReadVarInt64)
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnsupportedType, v.Type())
+}
+
+func decodeUint[T uint | uint16 | uint32 | uint64](bs []byte, v goreflect.Value, read func([]byte) (T, int, error)) (int, error) {
+	val, n, err := read(bs)
+	if err != nil { return 0, err }
+	v.SetUint(uint64(val))
+	return n, nil
+}
+
+func decodeInt[T int | int16 | int32 | int64](bs []byte, v goreflect.Value, read func([]byte) (T, int, error)) (int, error) {
+	val, n, err := read(bs)
+	if err != nil { return 0, err }
+	v.SetInt(int64(val))
+	return n, nil
+}
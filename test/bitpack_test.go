@@ -0,0 +1,75 @@
+package test
+
+import (
+	"testing"
+)
+
+func TestBitpackRoundtrip(t *testing.T) {
+	d := EntityFlags{
+		Id: 12,
+		Alive: true,
+		Visible: true,
+		Name: "hello",
+		Swimming: true,
+		Flying: true,
+		Sleeping: true,
+		Mask: []bool{true, false, false, true, true, false, true, false, true},
+	}
+
+	bs := d.EncodeCod(nil)
+
+	// 1 byte id, 2 bytes of packed bools, 6 byte string, 1 byte slice length + 2 bytes of packed slice
+	if len(bs) != 12 {
+		t.Errorf("expected 12 bytes, got %d", len(bs))
+	}
+	if d.CodSize() != len(bs) {
+		t.Errorf("CodSize %d != encoded length %d", d.CodSize(), len(bs))
+	}
+
+	res := EntityFlags{Moving: true, Stunned: true, Mask: []bool{true}}
+	n, err := res.DecodeCod(bs)
+	if err != nil { panic(err) }
+	if n != len(bs) {
+		t.Errorf("decoded %d bytes, expected %d", n, len(bs))
+	}
+	if !d.CodEquals(res) {
+		t.Errorf("expected %v, got %v", d, res)
+	}
+}
+
+func TestBitpackTruncated(t *testing.T) {
+	d := EntityFlags{Alive: true, Mask: []bool{true, true}}
+	bs := d.EncodeCod(nil)
+
+	for i := 0; i < len(bs); i++ {
+		res := EntityFlags{}
+		_, err := res.DecodeCod(bs[:i])
+		if err == nil {
+			t.Errorf("expected an error when decoding %d of %d bytes", i, len(bs))
+		}
+	}
+}
+
+// A struct of only bools is encoded as just the bitfield
+func TestBitpackOnlyBools(t *testing.T) {
+	d := OnlyBools{A: true, C: true}
+	bs := d.EncodeCod(nil)
+	if len(bs) != 1 || bs[0] != 0b101 {
+		t.Errorf("expected a single byte 0b101, got %v", bs)
+	}
+
+	res := OnlyBools{B: true}
+	n, err := res.DecodeCod(bs)
+	if err != nil { panic(err) }
+	if n != 1 || !d.CodEquals(res) {
+		t.Errorf("expected %v, got %v", d, res)
+	}
+
+	base := OnlyBools{}
+	res = OnlyBools{}
+	_, err = res.DecodeCodDelta(d.EncodeCodDelta(nil, base), base)
+	if err != nil { panic(err) }
+	if !d.CodEquals(res) {
+		t.Errorf("expected %v, got %v", d, res)
+	}
+}
@@ -0,0 +1,116 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/unitoftime/cod/test/subpackage"
+	"github.com/unitoftime/cod/test/subpackage/blocked"
+)
+
+func TestPersonClone(t *testing.T) {
+	d := Person{
+		Name: "hello",
+		Age: 5,
+		Id: Id{7},
+		Array: [2]uint16{8, 9},
+		Slice: []uint32{100, 101, 102},
+		DoubleSlice: [][]uint8{{1, 2, 3}, {4, 5, 6}},
+		Map: map[string][]uint64{
+			"a": {1000, 2000, 3000},
+		},
+		MultiMap: map[string]map[uint32][]uint8{
+			"c": {1: {11, 12}},
+		},
+		MyUnion: NewMyUnion(SpecialMap{"a": {1, 2}}),
+		Pointer: &BlockedStruct{
+			Basic: blocked.Basic(1),
+		},
+	}
+
+	c := d.CodClone()
+	if !d.CodEquals(c) {
+		t.Errorf("expected %v, got %v", d, c)
+	}
+
+	// Changing the clone must not change the original
+	c.Slice[0] = 0
+	c.DoubleSlice[1][0] = 0
+	c.Map["a"][0] = 0
+	c.MultiMap["c"][1][0] = 0
+	c.Pointer.Basic = 2
+	special, _ := c.MyUnion.GetSpecialMap()
+	special["a"][0] = 0
+
+	expected := Person{
+		Name: "hello",
+		Age: 5,
+		Id: Id{7},
+		Array: [2]uint16{8, 9},
+		Slice: []uint32{100, 101, 102},
+		DoubleSlice: [][]uint8{{1, 2, 3}, {4, 5, 6}},
+		Map: map[string][]uint64{
+			"a": {1000, 2000, 3000},
+		},
+		MultiMap: map[string]map[uint32][]uint8{
+			"c": {1: {11, 12}},
+		},
+		MyUnion: NewMyUnion(SpecialMap{"a": {1, 2}}),
+		Pointer: &BlockedStruct{
+			Basic: blocked.Basic(1),
+		},
+	}
+	if !expected.CodEquals(d) {
+		t.Errorf("original was changed by the clone: %v", d)
+	}
+}
+
+func TestUnionClone(t *testing.T) {
+	d := NewMyUnion(subpackage.Vec{X: 1, Y: 2})
+	c := d.CodClone()
+	if !d.CodEquals(c) {
+		t.Errorf("expected %v, got %v", d, c)
+	}
+
+	empty := MyUnion{}
+	c = empty.CodClone()
+	if c.Get() != nil {
+		t.Errorf("expected an empty union, got %v", c.Get())
+	}
+}
+
+func TestCloneSkipsEquality(t *testing.T) {
+	d := Snapshot{
+		Tick: 5,
+		Entities: []EntityFlags{{Id: 1, Alive: true, Mask: []bool{true}}},
+		Names: map[uint32]string{1: "a"},
+		Cache: []uint8{1, 2, 3},
+	}
+
+	c := d.CodClone()
+	if !d.CodEquals(c) {
+		t.Errorf("expected %v, got %v", d, c)
+	}
+	if c.Cache != nil {
+		t.Errorf("expected the skipped field to be left empty, got %v", c.Cache)
+	}
+
+	c.Entities[0].Mask[0] = false
+	if !d.Entities[0].Mask[0] {
+		t.Error("original was changed by the clone")
+	}
+}
+
+func TestGenericClone(t *testing.T) {
+	d := Pair[Id, *Id, subpackage.Vec, *subpackage.Vec]{
+		Key: Id{5},
+		Vals: []subpackage.Vec{{X: 5, Y: 6}},
+	}
+	c := d.CodClone()
+	if !d.CodEquals(c) {
+		t.Errorf("expected %v, got %v", d, c)
+	}
+	c.Vals[0].X = 100
+	if d.Vals[0].X != 5 {
+		t.Error("original was changed by the clone")
+	}
+}
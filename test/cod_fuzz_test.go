@@ -0,0 +1,844 @@
+// Code generated by cod; DO NOT EDIT.
+package test
+
+import "testing"
+
+func FuzzBlankStructDecode(f *testing.F) {
+	f.Add(BlankStruct{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v BlankStruct
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 BlankStruct
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzBlockedStructDecode(f *testing.F) {
+	f.Add(BlockedStruct{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v BlockedStruct
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 BlockedStruct
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzBlockedStruct2Decode(f *testing.F) {
+	f.Add(BlockedStruct2{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v BlockedStruct2
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 BlockedStruct2
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzCheckedUnionDecode(f *testing.F) {
+	f.Add(CheckedUnion{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v CheckedUnion
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 CheckedUnion
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzCustomFieldsDecode(f *testing.F) {
+	f.Add(CustomFields{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v CustomFields
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 CustomFields
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzCustomUnionDecode(f *testing.F) {
+	f.Add(CustomUnion{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v CustomUnion
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 CustomUnion
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzEntityFlagsDecode(f *testing.F) {
+	f.Add(EntityFlags{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v EntityFlags
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 EntityFlags
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzFixedIntsDecode(f *testing.F) {
+	f.Add(FixedInts{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v FixedInts
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 FixedInts
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzFloatsDecode(f *testing.F) {
+	f.Add(Floats{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v Floats
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 Floats
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzGenericsDecode(f *testing.F) {
+	f.Add(Generics{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v Generics
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 Generics
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzIdDecode(f *testing.F) {
+	f.Add(Id{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v Id
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 Id
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzIdListDecode(f *testing.F) {
+	f.Add(IdList{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v IdList
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 IdList
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzMinSizesDecode(f *testing.F) {
+	f.Add(MinSizes{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v MinSizes
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 MinSizes
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzMyStructDecode(f *testing.F) {
+	f.Add(MyStruct{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v MyStruct
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 MyStruct
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzMyUnionDecode(f *testing.F) {
+	f.Add(MyUnion{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v MyUnion
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 MyUnion
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzNamedBasicsDecode(f *testing.F) {
+	f.Add(NamedBasics{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v NamedBasics
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 NamedBasics
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzOnlyBoolsDecode(f *testing.F) {
+	f.Add(OnlyBools{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v OnlyBools
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 OnlyBools
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzPersonDecode(f *testing.F) {
+	f.Add(Person{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v Person
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 Person
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzPinnedUnionDecode(f *testing.F) {
+	f.Add(PinnedUnion{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v PinnedUnion
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 PinnedUnion
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzPooledDecode(f *testing.F) {
+	f.Add(Pooled{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v Pooled
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 Pooled
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzPooledSaveDecode(f *testing.F) {
+	f.Add(PooledSave{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v PooledSave
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 PooledSave
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzReflectedStructDecode(f *testing.F) {
+	f.Add(ReflectedStruct{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v ReflectedStruct
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 ReflectedStruct
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzSaveFileDecode(f *testing.F) {
+	f.Add(SaveFile{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v SaveFile
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 SaveFile
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzSaveV1Decode(f *testing.F) {
+	f.Add(SaveV1{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v SaveV1
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 SaveV1
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzSaveV2Decode(f *testing.F) {
+	f.Add(SaveV2{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v SaveV2
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 SaveV2
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzSnapshotDecode(f *testing.F) {
+	f.Add(Snapshot{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v Snapshot
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 Snapshot
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzSortedMapsDecode(f *testing.F) {
+	f.Add(SortedMaps{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v SortedMaps
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 SortedMaps
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzSpecialMapDecode(f *testing.F) {
+	f.Add(SpecialMap{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v SpecialMap
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 SpecialMap
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzUntrustedDecode(f *testing.F) {
+	f.Add(Untrusted{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v Untrusted
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 Untrusted
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
+
+func FuzzVarintUnionDecode(f *testing.F) {
+	f.Add(VarintUnion{}.EncodeCod(nil))
+	f.Fuzz(func(t *testing.T, bs []byte) {
+		var v VarintUnion
+		n, err := v.DecodeCod(bs)
+		if err != nil {
+			return
+		}
+		if n < 0 || n > len(bs) {
+			t.Fatalf("decode consumed %d bytes of %d", n, len(bs))
+		}
+
+		// Anything that decodes must survive a roundtrip
+		reencoded := v.EncodeCod(nil)
+		var v2 VarintUnion
+		n, err = v2.DecodeCod(reencoded)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded value: %v", err)
+		}
+		if n != len(reencoded) {
+			t.Fatalf("decode consumed %d bytes of %d re-encoded bytes", n, len(reencoded))
+		}
+		if !v.CodEquals(v2) {
+			t.Fatalf("roundtrip mismatch:\n%v\n%v", v, v2)
+		}
+	})
+}
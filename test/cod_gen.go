@@ -21,10 +21,39 @@ func (t *BlankStruct) DecodeCod(bs []byte) (n int, err error) {
 	return
 }
 
+func (t *BlankStruct) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (n int, err error) {
+	return
+}
+
 func (t BlankStruct) CodEquals(tt BlankStruct) bool {
 	return true
 }
 
+func (t BlankStruct) CodClone() BlankStruct {
+	return t
+}
+
+func (t BlankStruct) CodSize() int {
+	return 0
+}
+
+func (t BlankStruct) EncodeCodDelta(bs []byte, tt BlankStruct) []byte {
+	return t.EncodeCod(bs)
+}
+
+func (t *BlankStruct) DecodeCodDelta(bs []byte, tt BlankStruct) (int, error) {
+	return t.DecodeCod(bs)
+}
+
+func (t BlankStruct) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *BlankStruct) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
 func (t BlockedStruct) EncodeCod(bs []byte) []byte {
 
 	bs = backend.WriteVarUint64(bs, uint64(t.Basic))
@@ -33,9 +62,20 @@ func (t BlockedStruct) EncodeCod(bs []byte) []byte {
 }
 
 func (t *BlockedStruct) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *BlockedStruct) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
 	var err error
 	var n int
 	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
 
 	{
 		var decoded uint64
@@ -60,6 +100,88 @@ func (t BlockedStruct) CodEquals(tt BlockedStruct) bool {
 	return true
 }
 
+func (t BlockedStruct) CodClone() BlockedStruct {
+	var ct BlockedStruct
+
+	ct.Basic = t.Basic
+	return ct
+}
+
+func (t BlockedStruct) CodSize() int {
+	n := 0
+
+	n += backend.SizeVarUint64(uint64(t.Basic))
+	return n
+}
+
+func (t BlockedStruct) EncodeCodDelta(bs []byte, tt BlockedStruct) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 1)...)
+
+	if !func() bool {
+
+		if t.Basic != tt.Basic {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
+
+		bs = backend.WriteVarUint64(bs, uint64(t.Basic))
+
+	}
+
+	return bs
+}
+
+func (t *BlockedStruct) DecodeCodDelta(bs []byte, tt BlockedStruct) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 1 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:1]
+	n += 1
+
+	if mask[0]&(1<<0) != 0 {
+
+		{
+			var decoded uint64
+			decoded, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Basic = blocked.Basic(decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Basic = t.Basic
+	}
+
+	return n, err
+}
+
+func (t BlockedStruct) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *BlockedStruct) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
 func (t BlockedStruct2) EncodeCod(bs []byte) []byte {
 
 	{
@@ -74,9 +196,20 @@ func (t BlockedStruct2) EncodeCod(bs []byte) []byte {
 }
 
 func (t *BlockedStruct2) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *BlockedStruct2) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
 	var err error
 	var n int
 	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
 
 	{
 		var length uint64
@@ -85,9 +218,14 @@ func (t *BlockedStruct2) DecodeCod(bs []byte) (int, error) {
 			return 0, err
 		}
 		n += nOff
+		err = backend.LimitSlice[blocked.Basic](lim, length)
+		if err != nil {
+			return 0, err
+		}
+		t.Basic = backend.ResetSlice(t.Basic, length, len(bs[n:]))
 
 		for i1 := 0; i1 < int(length); i1++ {
-			var value1 blocked.Basic
+			t.Basic = backend.ExtendSlice(t.Basic)
 
 			{
 				var decoded uint64
@@ -96,14 +234,12 @@ func (t *BlockedStruct2) DecodeCod(bs []byte) (int, error) {
 					return 0, err
 				}
 				n += nOff
-				value1 = blocked.Basic(decoded)
+				t.Basic[i1] = blocked.Basic(decoded)
 			}
 
 			if err != nil {
 				return 0, err
 			}
-
-			t.Basic = append(t.Basic, value1)
 		}
 	}
 
@@ -128,109 +264,142 @@ func (t BlockedStruct2) CodEquals(tt BlockedStruct2) bool {
 	return true
 }
 
-func (t Id) EncodeCod(bs []byte) []byte {
+func (t BlockedStruct2) CodClone() BlockedStruct2 {
+	var ct BlockedStruct2
 
-	bs = backend.WriteVarUint16(bs, (t.Val))
+	if t.Basic != nil {
+		ct.Basic = make([]blocked.Basic, len(t.Basic))
+		for i1 := range t.Basic {
 
-	return bs
+			ct.Basic[i1] = t.Basic[i1]
+		}
+	}
+	return ct
 }
 
-func (t *Id) DecodeCod(bs []byte) (int, error) {
-	var err error
-	var n int
-	var nOff int
+func (t BlockedStruct2) CodSize() int {
+	n := 0
 
 	{
-		var decoded uint16
-		decoded, nOff, err = backend.ReadVarUint16(bs[n:])
-		if err != nil {
-			return 0, err
+		n += backend.SizeVarUint64(uint64(len(t.Basic)))
+		for i1 := range t.Basic {
+
+			n += backend.SizeVarUint64(uint64(t.Basic[i1]))
 		}
-		n += nOff
-		t.Val = (decoded)
 	}
-
-	// println("Id:", n)
-	return n, err
+	return n
 }
 
-func (t Id) CodEquals(tt Id) bool {
+func (t BlockedStruct2) EncodeCodDelta(bs []byte, tt BlockedStruct2) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 1)...)
 
-	if t.Val != tt.Val {
-		return false
-	}
+	if !func() bool {
 
-	return true
-}
+		{
+			if len(t.Basic) != len(tt.Basic) {
+				return false
+			}
+			for i1 := range t.Basic {
 
-func (t MyStruct) EncodeCod(bs []byte) []byte {
+				if t.Basic[i1] != tt.Basic[i1] {
+					return false
+				}
 
-	{
-		bs = backend.WriteVarUint64(bs, uint64(len(t.Vector)))
-		for i1 := range t.Vector {
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
 
-			bs = t.Vector[i1].EncodeCod(bs)
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Basic)))
+			for i1 := range t.Basic {
+
+				bs = backend.WriteVarUint64(bs, uint64(t.Basic[i1]))
+
+			}
 		}
 	}
+
 	return bs
 }
 
-func (t *MyStruct) DecodeCod(bs []byte) (int, error) {
+func (t *BlockedStruct2) DecodeCodDelta(bs []byte, tt BlockedStruct2) (int, error) {
 	var err error
 	var n int
 	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
 
-	{
-		var length uint64
-		length, nOff, err = backend.ReadVarUint64(bs[n:])
-		if err != nil {
-			return 0, err
-		}
-		n += nOff
+	if len(bs) < 1 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:1]
+	n += 1
 
-		for i1 := 0; i1 < int(length); i1++ {
-			var value1 subpackage.Vec
+	if mask[0]&(1<<0) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[blocked.Basic](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			t.Basic = backend.ResetSlice(t.Basic, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				t.Basic = backend.ExtendSlice(t.Basic)
+
+				{
+					var decoded uint64
+					decoded, nOff, err = backend.ReadVarUint64(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					t.Basic[i1] = blocked.Basic(decoded)
+				}
 
-			{
-				var decoded subpackage.Vec
-				nOff, err = decoded.DecodeCod(bs[n:])
 				if err != nil {
 					return 0, err
 				}
-				n += nOff
-				value1 = decoded
 			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
 
-			if err != nil {
-				return 0, err
-			}
+		if t.Basic != nil {
+			ct.Basic = make([]blocked.Basic, len(t.Basic))
+			for i1 := range t.Basic {
 
-			t.Vector = append(t.Vector, value1)
+				ct.Basic[i1] = t.Basic[i1]
+			}
 		}
 	}
 
-	// println("MyStruct:", n)
 	return n, err
 }
 
-func (t MyStruct) CodEquals(tt MyStruct) bool {
-
-	{
-		if len(t.Vector) != len(tt.Vector) {
-			return false
-		}
-		for i1 := range t.Vector {
-
-			if !t.Vector[i1].CodEquals(tt.Vector[i1]) {
-				return false
-			}
+func (t BlockedStruct2) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
 
-		}
-	}
-	return true
+func (t *BlockedStruct2) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
 }
 
-func (t MyUnion) EncodeCod(bs []byte) []byte {
+func (t CheckedUnion) EncodeCod(bs []byte) []byte {
 
 	tag := t.Tag()
 	bs = backend.WriteUint8(bs, tag)
@@ -243,14 +412,23 @@ func (t MyUnion) EncodeCod(bs []byte) []byte {
 	bs = rawVal.EncodeCod(bs)
 
 	return bs
+}
 
-	return bs
+func (t *CheckedUnion) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
 }
 
-func (t *MyUnion) DecodeCod(bs []byte) (int, error) {
+func (t *CheckedUnion) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
 	var err error
 	var n int
 	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
 
 	var tagVal uint8
 
@@ -262,47 +440,38 @@ func (t *MyUnion) DecodeCod(bs []byte) (int, error) {
 
 	switch tagVal {
 	case 0: // Zero tag indicates nil
-		return 0, nil
+		*t = CheckedUnion{}
+		return n, nil
 
 	case 1:
 		var decoded Id
-		nOff, err = decoded.DecodeCod(bs[n:])
+		nOff, err = decoded.DecodeCodWithLimits(bs[n:], lim)
 		if err != nil {
 			return 0, err
 		}
 		n += nOff
 
-		t.Set(decoded)
+		t.SetId(decoded)
 
 	case 2:
-		var decoded SpecialMap
-		nOff, err = decoded.DecodeCod(bs[n:])
-		if err != nil {
-			return 0, err
-		}
-		n += nOff
-
-		t.Set(decoded)
-
-	case 3:
 		var decoded subpackage.Vec
-		nOff, err = decoded.DecodeCod(bs[n:])
+		nOff, err = decoded.DecodeCodWithLimits(bs[n:], lim)
 		if err != nil {
 			return 0, err
 		}
 		n += nOff
 
-		t.Set(decoded)
+		t.SetVec(decoded)
 
 	default:
 		return 0, backend.ErrUnknownUnionType
 	}
 
-	// println("MyUnion:", n)
+	// println("CheckedUnion:", n)
 	return n, err
 }
 
-func (t MyUnion) Tag() uint8 {
+func (t CheckedUnion) Tag() uint8 {
 	rawVal := t.Get()
 	if rawVal == nil {
 		// Zero tag indicates nil
@@ -314,37 +483,32 @@ func (t MyUnion) Tag() uint8 {
 	case Id:
 		return 1
 
-	case SpecialMap:
-		return 2
-
 	case subpackage.Vec:
-		return 3
+		return 2
 
 	default:
 		panic(fmt.Sprintf("unknown type placed in union: %T", rawVal))
 	}
 }
 
-func (t MyUnion) Size() int {
-	return 4
+func (t CheckedUnion) Size() int {
+	return 3
 }
 
-func (t MyUnion) CodEquals(tt MyUnion) bool {
+func (t CheckedUnion) CodEquals(tt CheckedUnion) bool {
 	if t.Tag() != tt.Tag() {
 		return false
 	}
 
 	rawVal := t.Get()
 	switch sv := rawVal.(type) {
+	case nil:
+		return true
 
 	case Id:
 		sv2 := tt.Get().(Id)
 		return sv.CodEquals(sv2)
 
-	case SpecialMap:
-		sv2 := tt.Get().(SpecialMap)
-		return sv.CodEquals(sv2)
-
 	case subpackage.Vec:
 		sv2 := tt.Get().(subpackage.Vec)
 		return sv.CodEquals(sv2)
@@ -352,587 +516,11616 @@ func (t MyUnion) CodEquals(tt MyUnion) bool {
 	default:
 		panic(fmt.Sprintf("unknown type placed in union: %T", rawVal))
 	}
-
-	return true
 }
 
-func (t MyUnion) Get() cod.EncoderDecoder {
-	codUnion := cod.Union(t)
-	rawVal := codUnion.GetRawValue()
-	return rawVal
-
-	// switch rawVal.(type) {
-	// <no value>
-	// default:
-	//    panic("unknown type placed in union")
-	// }
-}
+func (t CheckedUnion) CodClone() CheckedUnion {
+	var ct CheckedUnion
 
-func (t *MyUnion) Set(v cod.EncoderDecoder) {
-	codUnion := cod.Union(*t)
-	codUnion.PutRawValue(v)
-	*t = MyUnion(codUnion)
+	rawVal := t.Get()
+	switch sv := rawVal.(type) {
+	case nil:
 
-	// switch tagVal {
-	// case 0: // Zero tag indicates nil
-	//    return nil
+	case Id:
+		ct.SetId(sv.CodClone())
 
-	// <no value>
-	// default:
-	//    panic("unknown type placed in union")
-	// }
-	// return err
-}
+	case subpackage.Vec:
+		ct.SetVec(sv.CodClone())
 
-func NewMyUnion(v cod.EncoderDecoder) MyUnion {
-	var ret MyUnion
-	ret.Set(v)
-	return ret
+	default:
+		panic(fmt.Sprintf("unknown type placed in union: %T", rawVal))
+	}
+	return ct
 }
 
-func (t Person) EncodeCod(bs []byte) []byte {
-
-	bs = backend.WriteString(bs, (t.Name))
-
-	bs = backend.WriteUint8(bs, (t.Age))
-
-	bs = t.Id.EncodeCod(bs)
-	for i1 := range t.Array {
-
-		bs = backend.WriteVarUint16(bs, (t.Array[i1]))
+func (t CheckedUnion) EncodeCodDelta(bs []byte, tt CheckedUnion) []byte {
+	tag := t.Tag()
+	if tag == 0 || tag != tt.Tag() {
+		// The type changed, so write the full value
+		return t.EncodeCod(bs)
+	}
 
+	bs = backend.WriteUint8(bs, tag)
+	switch sv := t.Get().(type) {
+	case Id:
+		bs = sv.EncodeCodDelta(bs, tt.Get().(Id))
+	case subpackage.Vec:
+		bs = sv.EncodeCodDelta(bs, tt.Get().(subpackage.Vec))
 	}
-	{
-		bs = backend.WriteVarUint64(bs, uint64(len(t.Slice)))
-		for i1 := range t.Slice {
+	return bs
+}
 
-			bs = backend.WriteVarUint32(bs, (t.Slice[i1]))
+func (t *CheckedUnion) DecodeCodDelta(bs []byte, tt CheckedUnion) (int, error) {
+	tagVal, n, err := backend.ReadUint8(bs)
+	if err != nil {
+		return 0, err
+	}
+	if tagVal == 0 || tagVal != tt.Tag() {
+		return t.DecodeCod(bs)
+	}
 
+	var nOff int
+	switch tagVal {
+	case 1:
+		var decoded Id
+		nOff, err = decoded.DecodeCodDelta(bs[n:], tt.Get().(Id))
+		if err != nil {
+			return 0, err
+		}
+		t.SetId(decoded)
+	case 2:
+		var decoded subpackage.Vec
+		nOff, err = decoded.DecodeCodDelta(bs[n:], tt.Get().(subpackage.Vec))
+		if err != nil {
+			return 0, err
 		}
+		t.SetVec(decoded)
 	}
-	{
-		bs = backend.WriteVarUint64(bs, uint64(len(t.DoubleSlice)))
-		for i1 := range t.DoubleSlice {
-
-			{
-				bs = backend.WriteVarUint64(bs, uint64(len(t.DoubleSlice[i1])))
-				for i2 := range t.DoubleSlice[i1] {
+	return n + nOff, nil
+}
 
-					bs = backend.WriteUint8(bs, (t.DoubleSlice[i1][i2]))
+func (t CheckedUnion) CodSize() int {
+	n := backend.SizeUint8(t.Tag())
 
-				}
-			}
-		}
-	}
-	{
-		bs = backend.WriteVarUint64(bs, uint64(len(t.Map)))
+	rawVal := t.Get()
+	switch sv := rawVal.(type) {
 
-		for k1, v1 := range t.Map {
+	case Id:
+		n += sv.CodSize()
 
-			bs = backend.WriteString(bs, (k1))
+	case subpackage.Vec:
+		n += sv.CodSize()
 
-			{
-				bs = backend.WriteVarUint64(bs, uint64(len(v1)))
-				for i2 := range v1 {
+	}
+	return n
+}
 
-					bs = backend.WriteVarUint64(bs, (v1[i2]))
+func (t CheckedUnion) Get() cod.EncoderDecoder {
+	codUnion := cod.Union(t)
+	rawVal := codUnion.GetRawValue()
+	return rawVal
 
-				}
-			}
-		}
+	// switch rawVal.(type) {
+	// <no value>
+	// default:
+	//    panic("unknown type placed in union")
+	// }
+}
 
+func (t *CheckedUnion) Set(v cod.EncoderDecoder) error {
+	switch v.(type) {
+	case nil, Id, subpackage.Vec:
+	default:
+		return fmt.Errorf("%w: %T is not a member of CheckedUnion", backend.ErrUnknownUnionType, v)
 	}
-	{
-		bs = backend.WriteVarUint64(bs, uint64(len(t.MultiMap)))
 
-		for k1, v1 := range t.MultiMap {
+	codUnion := cod.Union(*t)
+	codUnion.PutRawValue(v)
+	*t = CheckedUnion(codUnion)
+	return nil
+}
 
-			bs = backend.WriteString(bs, (k1))
+func NewCheckedUnion(v cod.EncoderDecoder) (CheckedUnion, error) {
+	var ret CheckedUnion
+	err := ret.Set(v)
+	return ret, err
+}
 
-			{
-				bs = backend.WriteVarUint64(bs, uint64(len(v1)))
+func NewCheckedUnionFromId(v Id) CheckedUnion {
+	var ret CheckedUnion
+	ret.SetId(v)
+	return ret
+}
 
-				for k2, v2 := range v1 {
+func (t CheckedUnion) GetId() (Id, bool) {
+	v, ok := t.Get().(Id)
+	return v, ok
+}
 
-					bs = backend.WriteVarUint32(bs, (k2))
+func (t *CheckedUnion) SetId(v Id) {
+	codUnion := cod.Union(*t)
+	codUnion.PutRawValue(v)
+	*t = CheckedUnion(codUnion)
+}
 
-					{
-						bs = backend.WriteVarUint64(bs, uint64(len(v2)))
-						for i3 := range v2 {
+func NewCheckedUnionFromVec(v subpackage.Vec) CheckedUnion {
+	var ret CheckedUnion
+	ret.SetVec(v)
+	return ret
+}
 
-							bs = backend.WriteUint8(bs, (v2[i3]))
+func (t CheckedUnion) GetVec() (subpackage.Vec, bool) {
+	v, ok := t.Get().(subpackage.Vec)
+	return v, ok
+}
 
-						}
-					}
-				}
+func (t *CheckedUnion) SetVec(v subpackage.Vec) {
+	codUnion := cod.Union(*t)
+	codUnion.PutRawValue(v)
+	*t = CheckedUnion(codUnion)
+}
 
-			}
-		}
+func (t CheckedUnion) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
 
-	}
-	bs = t.MyUnion.EncodeCod(bs)
+func (t *CheckedUnion) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t CustomFields) EncodeCod(bs []byte) []byte {
+
+	bs = t.Custom.EncodeCod(bs)
 	{
-		if t.Pointer == nil {
-			// Zero tag indicates nil
-			bs = backend.WriteUint8(bs, 0)
-		} else {
-			bs = backend.WriteUint8(bs, 1)
-			value1 := *t.Pointer
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Customs)))
+		for i1 := range t.Customs {
 
-			bs = value1.EncodeCod(bs)
+			bs = t.Customs[i1].EncodeCod(bs)
 		}
 	}
+	bs = t.Union.EncodeCod(bs)
 	return bs
 }
 
-func (t *Person) DecodeCod(bs []byte) (int, error) {
+func (t *CustomFields) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *CustomFields) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
 	var err error
 	var n int
 	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
 
-	{
-		var decoded string
-		decoded, nOff, err = backend.ReadString(bs[n:])
-		if err != nil {
-			return 0, err
-		}
-		n += nOff
-		t.Name = (decoded)
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	nOff, err = t.Custom.DecodeCod(bs[n:])
+	if err != nil {
+		return 0, err
 	}
+	n += nOff
 
 	{
-		var decoded uint8
-		decoded, nOff, err = backend.ReadUint8(bs[n:])
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
 		if err != nil {
 			return 0, err
 		}
 		n += nOff
-		t.Age = (decoded)
-	}
-
-	{
-		var decoded Id
-		nOff, err = decoded.DecodeCod(bs[n:])
+		err = backend.LimitSlice[Custom](lim, length)
 		if err != nil {
 			return 0, err
 		}
-		n += nOff
-		t.Id = decoded
-	}
+		t.Customs = backend.ResetSlice(t.Customs, length, len(bs[n:]))
 
-	for i1 := range t.Array {
+		for i1 := 0; i1 < int(length); i1++ {
+			t.Customs = backend.ExtendSlice(t.Customs)
 
-		{
-			var decoded uint16
-			decoded, nOff, err = backend.ReadVarUint16(bs[n:])
+			nOff, err = t.Customs[i1].DecodeCod(bs[n:])
 			if err != nil {
 				return 0, err
 			}
 			n += nOff
-			t.Array[i1] = (decoded)
-		}
 
-		if err != nil {
-			return 0, err
+			if err != nil {
+				return 0, err
+			}
 		}
 	}
+	nOff, err = t.Union.DecodeCodWithLimits(bs[n:], lim)
+	if err != nil {
+		return 0, err
+	}
+	n += nOff
+
+	// println("CustomFields:", n)
+	return n, err
+}
+
+func (t CustomFields) CodEquals(tt CustomFields) bool {
+
+	if !t.Custom.CodEquals(tt.Custom) {
+		return false
+	}
+
 	{
-		var length uint64
-		length, nOff, err = backend.ReadVarUint64(bs[n:])
-		if err != nil {
-			return 0, err
+		if len(t.Customs) != len(tt.Customs) {
+			return false
 		}
-		n += nOff
-
-		for i1 := 0; i1 < int(length); i1++ {
-			var value1 uint32
+		for i1 := range t.Customs {
 
-			{
-				var decoded uint32
-				decoded, nOff, err = backend.ReadVarUint32(bs[n:])
-				if err != nil {
-					return 0, err
-				}
-				n += nOff
-				value1 = (decoded)
+			if !t.Customs[i1].CodEquals(tt.Customs[i1]) {
+				return false
 			}
 
-			if err != nil {
-				return 0, err
-			}
+		}
+	}
+	if !t.Union.CodEquals(tt.Union) {
+		return false
+	}
+
+	return true
+}
+
+func (t CustomFields) CodClone() CustomFields {
+	var ct CustomFields
 
-			t.Slice = append(t.Slice, value1)
+	ct.Custom = t.Custom
+	if t.Customs != nil {
+		ct.Customs = make([]Custom, len(t.Customs))
+		for i1 := range t.Customs {
+
+			ct.Customs[i1] = t.Customs[i1]
 		}
 	}
+	ct.Union = t.Union.CodClone()
+	return ct
+}
+
+func (t CustomFields) CodSize() int {
+	n := 0
+
+	n += len(t.Custom.EncodeCod(nil))
 	{
-		var length uint64
-		length, nOff, err = backend.ReadVarUint64(bs[n:])
-		if err != nil {
-			return 0, err
+		n += backend.SizeVarUint64(uint64(len(t.Customs)))
+		for i1 := range t.Customs {
+
+			n += len(t.Customs[i1].EncodeCod(nil))
 		}
-		n += nOff
+	}
+	n += t.Union.CodSize()
+	return n
+}
 
-		for i1 := 0; i1 < int(length); i1++ {
-			var value1 []uint8
+func (t CustomFields) EncodeCodDelta(bs []byte, tt CustomFields) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 1)...)
 
-			{
-				var length uint64
-				length, nOff, err = backend.ReadVarUint64(bs[n:])
-				if err != nil {
-					return 0, err
-				}
-				n += nOff
+	if !func() bool {
 
-				for i2 := 0; i2 < int(length); i2++ {
-					var value2 uint8
+		if !t.Custom.CodEquals(tt.Custom) {
+			return false
+		}
 
-					{
-						var decoded uint8
-						decoded, nOff, err = backend.ReadUint8(bs[n:])
-						if err != nil {
-							return 0, err
-						}
-						n += nOff
-						value2 = (decoded)
-					}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
 
-					if err != nil {
-						return 0, err
-					}
+		bs = t.Custom.EncodeCod(bs)
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Customs) != len(tt.Customs) {
+				return false
+			}
+			for i1 := range t.Customs {
 
-					value1 = append(value1, value2)
+				if !t.Customs[i1].CodEquals(tt.Customs[i1]) {
+					return false
 				}
+
 			}
-			if err != nil {
-				return 0, err
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 1
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Customs)))
+			for i1 := range t.Customs {
+
+				bs = t.Customs[i1].EncodeCod(bs)
 			}
+		}
+	}
 
-			t.DoubleSlice = append(t.DoubleSlice, value1)
+	if !func() bool {
+
+		if !t.Union.CodEquals(tt.Union) {
+			return false
 		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 2
+
+		bs = t.Union.EncodeCodDelta(bs, tt.Union)
 	}
-	{
-		var length uint64
-		length, nOff, err = backend.ReadVarUint64(bs[n:])
+
+	return bs
+}
+
+func (t *CustomFields) DecodeCodDelta(bs []byte, tt CustomFields) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 1 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:1]
+	n += 1
+
+	if mask[0]&(1<<0) != 0 {
+
+		nOff, err = t.Custom.DecodeCod(bs[n:])
 		if err != nil {
 			return 0, err
 		}
 		n += nOff
 
-		if t.Map == nil {
-			t.Map = make(map[string][]uint64)
-		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
 
-		for i1 := 0; i1 < int(length); i1++ {
-			var key1 string
-			var val1 []uint64
+		ct.Custom = t.Custom
+	}
 
-			{
-				var decoded string
-				decoded, nOff, err = backend.ReadString(bs[n:])
+	if mask[0]&(1<<1) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[Custom](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			t.Customs = backend.ResetSlice(t.Customs, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				t.Customs = backend.ExtendSlice(t.Customs)
+
+				nOff, err = t.Customs[i1].DecodeCod(bs[n:])
 				if err != nil {
 					return 0, err
 				}
 				n += nOff
-				key1 = (decoded)
-			}
 
-			{
-				var length uint64
-				length, nOff, err = backend.ReadVarUint64(bs[n:])
 				if err != nil {
 					return 0, err
 				}
-				n += nOff
-
-				for i2 := 0; i2 < int(length); i2++ {
-					var value2 uint64
-
-					{
-						var decoded uint64
-						decoded, nOff, err = backend.ReadVarUint64(bs[n:])
-						if err != nil {
-							return 0, err
-						}
-						n += nOff
-						value2 = (decoded)
-					}
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
 
-					if err != nil {
-						return 0, err
-					}
+		if t.Customs != nil {
+			ct.Customs = make([]Custom, len(t.Customs))
+			for i1 := range t.Customs {
 
-					val1 = append(val1, value2)
-				}
-			}
-			if err != nil {
-				return 0, err
+				ct.Customs[i1] = t.Customs[i1]
 			}
-
-			t.Map[key1] = val1
 		}
 	}
-	{
-		var length uint64
-		length, nOff, err = backend.ReadVarUint64(bs[n:])
+
+	if mask[0]&(1<<2) != 0 {
+
+		nOff, err = t.Union.DecodeCodDelta(bs[n:], tt.Union)
 		if err != nil {
 			return 0, err
 		}
 		n += nOff
 
-		if t.MultiMap == nil {
-			t.MultiMap = make(map[string]map[uint32][]uint8)
-		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
 
-		for i1 := 0; i1 < int(length); i1++ {
-			var key1 string
-			var val1 map[uint32][]uint8
+		ct.Union = t.Union.CodClone()
+	}
 
-			{
-				var decoded string
-				decoded, nOff, err = backend.ReadString(bs[n:])
-				if err != nil {
-					return 0, err
-				}
-				n += nOff
-				key1 = (decoded)
-			}
+	return n, err
+}
 
-			{
-				var length uint64
-				length, nOff, err = backend.ReadVarUint64(bs[n:])
-				if err != nil {
-					return 0, err
-				}
-				n += nOff
+func (t CustomFields) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
 
-				if val1 == nil {
-					val1 = make(map[uint32][]uint8)
-				}
+func (t *CustomFields) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
 
-				for i2 := 0; i2 < int(length); i2++ {
-					var key2 uint32
-					var val2 []uint8
+func (t CustomUnion) EncodeCod(bs []byte) []byte {
 
-					{
-						var decoded uint32
-						decoded, nOff, err = backend.ReadVarUint32(bs[n:])
-						if err != nil {
-							return 0, err
-						}
-						n += nOff
-						key2 = (decoded)
-					}
+	tag := t.Tag()
+	bs = backend.WriteUint8(bs, tag)
+	if tag == 0 {
+		// Zero tag indicates nil, so write nothing else
+		return bs
+	}
 
-					{
-						var length uint64
-						length, nOff, err = backend.ReadVarUint64(bs[n:])
-						if err != nil {
-							return 0, err
-						}
-						n += nOff
+	rawVal := t.Get()
+	bs = rawVal.EncodeCod(bs)
 
-						for i3 := 0; i3 < int(length); i3++ {
-							var value3 uint8
+	return bs
+}
 
-							{
-								var decoded uint8
-								decoded, nOff, err = backend.ReadUint8(bs[n:])
-								if err != nil {
-									return 0, err
-								}
-								n += nOff
-								value3 = (decoded)
-							}
+func (t *CustomUnion) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
 
-							if err != nil {
-								return 0, err
-							}
+func (t *CustomUnion) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
 
-							val2 = append(val2, value3)
-						}
-					}
-					if err != nil {
-						return 0, err
-					}
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
 
-					val1[key2] = val2
-				}
-			}
-			if err != nil {
-				return 0, err
-			}
+	var tagVal uint8
 
-			t.MultiMap[key1] = val1
-		}
+	tagVal, nOff, err = backend.ReadUint8(bs[n:])
+	if err != nil {
+		return 0, err
 	}
-	{
-		var decoded MyUnion
+	n += nOff
+
+	switch tagVal {
+	case 0: // Zero tag indicates nil
+		*t = CustomUnion{}
+		return n, nil
+
+	case 1:
+		var decoded Custom
 		nOff, err = decoded.DecodeCod(bs[n:])
 		if err != nil {
 			return 0, err
 		}
 		n += nOff
-		t.MyUnion = decoded
-	}
 
-	{
-		var tagVal uint8
-		tagVal, nOff, err = backend.ReadUint8(bs[n:])
+		t.SetCustom(decoded)
+
+	case 2:
+		var decoded Id
+		nOff, err = decoded.DecodeCodWithLimits(bs[n:], lim)
 		if err != nil {
 			return 0, err
 		}
 		n += nOff
 
-		if tagVal == 0 {
-			// Zero tag indicates nil
-			t.Pointer = nil
-		} else {
-			var value1 BlockedStruct
-
-			{
-				var decoded BlockedStruct
-				nOff, err = decoded.DecodeCod(bs[n:])
-				if err != nil {
-					return 0, err
-				}
-				n += nOff
-				value1 = decoded
-			}
+		t.SetId(decoded)
 
-			t.Pointer = &value1
-		}
+	default:
+		return 0, backend.ErrUnknownUnionType
 	}
 
-	// println("Person:", n)
+	// println("CustomUnion:", n)
 	return n, err
 }
 
-func (t Person) CodEquals(tt Person) bool {
-
-	if t.Name != tt.Name {
-		return false
+func (t CustomUnion) Tag() uint8 {
+	rawVal := t.Get()
+	if rawVal == nil {
+		// Zero tag indicates nil
+		return 0
 	}
 
-	if t.Age != tt.Age {
-		return false
+	switch rawVal.(type) {
+
+	case Custom:
+		return 1
+
+	case Id:
+		return 2
+
+	default:
+		panic(fmt.Sprintf("unknown type placed in union: %T", rawVal))
 	}
+}
 
-	if !t.Id.CodEquals(tt.Id) {
+func (t CustomUnion) Size() int {
+	return 3
+}
+
+func (t CustomUnion) CodEquals(tt CustomUnion) bool {
+	if t.Tag() != tt.Tag() {
 		return false
 	}
 
-	for i1 := range t.Array {
+	rawVal := t.Get()
+	switch sv := rawVal.(type) {
+	case nil:
+		return true
 
-		if t.Array[i1] != tt.Array[i1] {
-			return false
-		}
+	case Custom:
+		sv2 := tt.Get().(Custom)
+		return sv.CodEquals(sv2)
+
+	case Id:
+		sv2 := tt.Get().(Id)
+		return sv.CodEquals(sv2)
 
+	default:
+		panic(fmt.Sprintf("unknown type placed in union: %T", rawVal))
 	}
-	{
-		if len(t.Slice) != len(tt.Slice) {
-			return false
-		}
-		for i1 := range t.Slice {
+}
 
-			if t.Slice[i1] != tt.Slice[i1] {
-				return false
-			}
+func (t CustomUnion) CodClone() CustomUnion {
+	var ct CustomUnion
 
-		}
-	}
-	{
-		if len(t.DoubleSlice) != len(tt.DoubleSlice) {
-			return false
-		}
-		for i1 := range t.DoubleSlice {
+	rawVal := t.Get()
+	switch sv := rawVal.(type) {
+	case nil:
 
-			{
-				if len(t.DoubleSlice[i1]) != len(tt.DoubleSlice[i1]) {
-					return false
-				}
-				for i2 := range t.DoubleSlice[i1] {
+	case Custom:
+		ct.SetCustom(sv)
 
-					if t.DoubleSlice[i1][i2] != tt.DoubleSlice[i1][i2] {
-						return false
-					}
+	case Id:
+		ct.SetId(sv.CodClone())
 
-				}
-			}
-		}
+	default:
+		panic(fmt.Sprintf("unknown type placed in union: %T", rawVal))
 	}
-	{
-		if len(t.Map) != len(tt.Map) {
-			return false
-		}
-		for k1, v1 := range t.Map {
-			tv1, ok := tt.Map[k1]
-			if !ok {
-				return false
-			}
+	return ct
+}
 
-			{
-				if len(v1) != len(tv1) {
-					return false
-				}
-				for i2 := range v1 {
+func (t CustomUnion) EncodeCodDelta(bs []byte, tt CustomUnion) []byte {
+	tag := t.Tag()
+	if tag == 0 || tag != tt.Tag() {
+		// The type changed, so write the full value
+		return t.EncodeCod(bs)
+	}
+
+	bs = backend.WriteUint8(bs, tag)
+	switch sv := t.Get().(type) {
+	case Custom:
+		bs = sv.EncodeCod(bs)
+	case Id:
+		bs = sv.EncodeCodDelta(bs, tt.Get().(Id))
+	}
+	return bs
+}
+
+func (t *CustomUnion) DecodeCodDelta(bs []byte, tt CustomUnion) (int, error) {
+	tagVal, n, err := backend.ReadUint8(bs)
+	if err != nil {
+		return 0, err
+	}
+	if tagVal == 0 || tagVal != tt.Tag() {
+		return t.DecodeCod(bs)
+	}
+
+	var nOff int
+	switch tagVal {
+	case 1:
+		var decoded Custom
+		nOff, err = decoded.DecodeCod(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		t.SetCustom(decoded)
+	case 2:
+		var decoded Id
+		nOff, err = decoded.DecodeCodDelta(bs[n:], tt.Get().(Id))
+		if err != nil {
+			return 0, err
+		}
+		t.SetId(decoded)
+	}
+	return n + nOff, nil
+}
+
+func (t CustomUnion) CodSize() int {
+	n := backend.SizeUint8(t.Tag())
+
+	rawVal := t.Get()
+	switch sv := rawVal.(type) {
+
+	case Custom:
+		n += len(sv.EncodeCod(nil))
+
+	case Id:
+		n += sv.CodSize()
+
+	}
+	return n
+}
+
+func (t CustomUnion) Get() cod.EncoderDecoder {
+	codUnion := cod.Union(t)
+	rawVal := codUnion.GetRawValue()
+	return rawVal
+
+	// switch rawVal.(type) {
+	// <no value>
+	// default:
+	//    panic("unknown type placed in union")
+	// }
+}
+
+func (t *CustomUnion) Set(v cod.EncoderDecoder) {
+	switch v.(type) {
+	case nil, Custom, Id:
+	default:
+		panic(fmt.Sprintf("%T is not a member of union CustomUnion", v))
+	}
+
+	codUnion := cod.Union(*t)
+	codUnion.PutRawValue(v)
+	*t = CustomUnion(codUnion)
+}
+
+func NewCustomUnion(v cod.EncoderDecoder) CustomUnion {
+	var ret CustomUnion
+	ret.Set(v)
+	return ret
+}
+
+func NewCustomUnionFromCustom(v Custom) CustomUnion {
+	var ret CustomUnion
+	ret.SetCustom(v)
+	return ret
+}
+
+func (t CustomUnion) GetCustom() (Custom, bool) {
+	v, ok := t.Get().(Custom)
+	return v, ok
+}
+
+func (t *CustomUnion) SetCustom(v Custom) {
+	codUnion := cod.Union(*t)
+	codUnion.PutRawValue(v)
+	*t = CustomUnion(codUnion)
+}
+
+func NewCustomUnionFromId(v Id) CustomUnion {
+	var ret CustomUnion
+	ret.SetId(v)
+	return ret
+}
+
+func (t CustomUnion) GetId() (Id, bool) {
+	v, ok := t.Get().(Id)
+	return v, ok
+}
+
+func (t *CustomUnion) SetId(v Id) {
+	codUnion := cod.Union(*t)
+	codUnion.PutRawValue(v)
+	*t = CustomUnion(codUnion)
+}
+
+func (t CustomUnion) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *CustomUnion) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t EntityFlags) EncodeCod(bs []byte) []byte {
+
+	bs = backend.WriteVarUint32(bs, (t.Id))
+
+	{
+		var bits [2]byte
+		if t.Alive {
+			bits[0] |= 1 << 0
+		}
+		if t.Moving {
+			bits[0] |= 1 << 1
+		}
+		if t.Jumping {
+			bits[0] |= 1 << 2
+		}
+		if t.Visible {
+			bits[0] |= 1 << 3
+		}
+		if t.Grounded {
+			bits[0] |= 1 << 4
+		}
+		if t.Crouching {
+			bits[0] |= 1 << 5
+		}
+		if t.Sprinting {
+			bits[0] |= 1 << 6
+		}
+		if t.Swimming {
+			bits[0] |= 1 << 7
+		}
+		if t.Flying {
+			bits[1] |= 1 << 0
+		}
+		if t.Stunned {
+			bits[1] |= 1 << 1
+		}
+		if t.Sleeping {
+			bits[1] |= 1 << 2
+		}
+		bs = append(bs, bits[:]...)
+	}
+
+	bs = backend.WriteString(bs, (t.Name))
+
+	bs = backend.WritePackedBools(bs, t.Mask)
+
+	return bs
+}
+
+func (t *EntityFlags) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *EntityFlags) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	{
+		var decoded uint32
+		decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		t.Id = (decoded)
+	}
+
+	{
+		if len(bs[n:]) < 2 {
+			return 0, backend.ErrTruncatedData
+		}
+		bits := bs[n : n+2]
+		t.Alive = bool(bits[0]&(1<<0) != 0)
+		t.Moving = bool(bits[0]&(1<<1) != 0)
+		t.Jumping = bool(bits[0]&(1<<2) != 0)
+		t.Visible = Visible(bits[0]&(1<<3) != 0)
+		t.Grounded = bool(bits[0]&(1<<4) != 0)
+		t.Crouching = bool(bits[0]&(1<<5) != 0)
+		t.Sprinting = bool(bits[0]&(1<<6) != 0)
+		t.Swimming = bool(bits[0]&(1<<7) != 0)
+		t.Flying = bool(bits[1]&(1<<0) != 0)
+		t.Stunned = bool(bits[1]&(1<<1) != 0)
+		t.Sleeping = bool(bits[1]&(1<<2) != 0)
+		n += 2
+	}
+
+	{
+		var decoded string
+		decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		t.Name = (decoded)
+	}
+
+	t.Mask, nOff, err = backend.ReadPackedBools(bs[n:], t.Mask, lim)
+	if err != nil {
+		return 0, err
+	}
+	n += nOff
+
+	// println("EntityFlags:", n)
+	return n, err
+}
+
+func (t EntityFlags) CodEquals(tt EntityFlags) bool {
+
+	if t.Id != tt.Id {
+		return false
+	}
+
+	if t.Alive != tt.Alive {
+		return false
+	}
+
+	if t.Moving != tt.Moving {
+		return false
+	}
+
+	if t.Jumping != tt.Jumping {
+		return false
+	}
+
+	if t.Visible != tt.Visible {
+		return false
+	}
+
+	if t.Grounded != tt.Grounded {
+		return false
+	}
+
+	if t.Crouching != tt.Crouching {
+		return false
+	}
+
+	if t.Sprinting != tt.Sprinting {
+		return false
+	}
+
+	if t.Swimming != tt.Swimming {
+		return false
+	}
+
+	if t.Flying != tt.Flying {
+		return false
+	}
+
+	if t.Stunned != tt.Stunned {
+		return false
+	}
+
+	if t.Sleeping != tt.Sleeping {
+		return false
+	}
+
+	if t.Name != tt.Name {
+		return false
+	}
+
+	if t.Ignored != tt.Ignored {
+		return false
+	}
+
+	{
+		if len(t.Mask) != len(tt.Mask) {
+			return false
+		}
+		for i1 := range t.Mask {
+
+			if t.Mask[i1] != tt.Mask[i1] {
+				return false
+			}
+
+		}
+	}
+	return true
+}
+
+func (t EntityFlags) CodClone() EntityFlags {
+	var ct EntityFlags
+
+	ct.Id = t.Id
+	ct.Alive = t.Alive
+	ct.Moving = t.Moving
+	ct.Jumping = t.Jumping
+	ct.Visible = t.Visible
+	ct.Grounded = t.Grounded
+	ct.Crouching = t.Crouching
+	ct.Sprinting = t.Sprinting
+	ct.Swimming = t.Swimming
+	ct.Flying = t.Flying
+	ct.Stunned = t.Stunned
+	ct.Sleeping = t.Sleeping
+	ct.Name = t.Name
+	ct.Ignored = t.Ignored
+	if t.Mask != nil {
+		ct.Mask = make([]bool, len(t.Mask))
+		for i1 := range t.Mask {
+
+			ct.Mask[i1] = t.Mask[i1]
+		}
+	}
+	return ct
+}
+
+func (t EntityFlags) CodSize() int {
+	n := 0
+
+	n += backend.SizeVarUint32((t.Id))
+	n += 2
+	n += backend.SizeString((t.Name))
+	n += backend.SizePackedBools(t.Mask)
+	return n
+}
+
+func (t EntityFlags) EncodeCodDelta(bs []byte, tt EntityFlags) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 1)...)
+
+	if !func() bool {
+
+		if t.Id != tt.Id {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
+
+		bs = backend.WriteVarUint32(bs, (t.Id))
+
+	}
+
+	if !func() bool {
+
+		if t.Alive != tt.Alive {
+			return false
+		}
+
+		if t.Moving != tt.Moving {
+			return false
+		}
+
+		if t.Jumping != tt.Jumping {
+			return false
+		}
+
+		if t.Visible != tt.Visible {
+			return false
+		}
+
+		if t.Grounded != tt.Grounded {
+			return false
+		}
+
+		if t.Crouching != tt.Crouching {
+			return false
+		}
+
+		if t.Sprinting != tt.Sprinting {
+			return false
+		}
+
+		if t.Swimming != tt.Swimming {
+			return false
+		}
+
+		if t.Flying != tt.Flying {
+			return false
+		}
+
+		if t.Stunned != tt.Stunned {
+			return false
+		}
+
+		if t.Sleeping != tt.Sleeping {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 1
+
+		{
+			var bits [2]byte
+			if t.Alive {
+				bits[0] |= 1 << 0
+			}
+			if t.Moving {
+				bits[0] |= 1 << 1
+			}
+			if t.Jumping {
+				bits[0] |= 1 << 2
+			}
+			if t.Visible {
+				bits[0] |= 1 << 3
+			}
+			if t.Grounded {
+				bits[0] |= 1 << 4
+			}
+			if t.Crouching {
+				bits[0] |= 1 << 5
+			}
+			if t.Sprinting {
+				bits[0] |= 1 << 6
+			}
+			if t.Swimming {
+				bits[0] |= 1 << 7
+			}
+			if t.Flying {
+				bits[1] |= 1 << 0
+			}
+			if t.Stunned {
+				bits[1] |= 1 << 1
+			}
+			if t.Sleeping {
+				bits[1] |= 1 << 2
+			}
+			bs = append(bs, bits[:]...)
+		}
+
+	}
+
+	if !func() bool {
+
+		if t.Name != tt.Name {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 2
+
+		bs = backend.WriteString(bs, (t.Name))
+
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Mask) != len(tt.Mask) {
+				return false
+			}
+			for i1 := range t.Mask {
+
+				if t.Mask[i1] != tt.Mask[i1] {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 3
+
+		bs = backend.WritePackedBools(bs, t.Mask)
+
+	}
+
+	return bs
+}
+
+func (t *EntityFlags) DecodeCodDelta(bs []byte, tt EntityFlags) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 1 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:1]
+	n += 1
+
+	if mask[0]&(1<<0) != 0 {
+
+		{
+			var decoded uint32
+			decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Id = (decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Id = t.Id
+	}
+
+	if mask[0]&(1<<1) != 0 {
+
+		{
+			if len(bs[n:]) < 2 {
+				return 0, backend.ErrTruncatedData
+			}
+			bits := bs[n : n+2]
+			t.Alive = bool(bits[0]&(1<<0) != 0)
+			t.Moving = bool(bits[0]&(1<<1) != 0)
+			t.Jumping = bool(bits[0]&(1<<2) != 0)
+			t.Visible = Visible(bits[0]&(1<<3) != 0)
+			t.Grounded = bool(bits[0]&(1<<4) != 0)
+			t.Crouching = bool(bits[0]&(1<<5) != 0)
+			t.Sprinting = bool(bits[0]&(1<<6) != 0)
+			t.Swimming = bool(bits[0]&(1<<7) != 0)
+			t.Flying = bool(bits[1]&(1<<0) != 0)
+			t.Stunned = bool(bits[1]&(1<<1) != 0)
+			t.Sleeping = bool(bits[1]&(1<<2) != 0)
+			n += 2
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Alive = t.Alive
+		ct.Moving = t.Moving
+		ct.Jumping = t.Jumping
+		ct.Visible = t.Visible
+		ct.Grounded = t.Grounded
+		ct.Crouching = t.Crouching
+		ct.Sprinting = t.Sprinting
+		ct.Swimming = t.Swimming
+		ct.Flying = t.Flying
+		ct.Stunned = t.Stunned
+		ct.Sleeping = t.Sleeping
+	}
+
+	if mask[0]&(1<<2) != 0 {
+
+		{
+			var decoded string
+			decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Name = (decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Name = t.Name
+	}
+
+	if mask[0]&(1<<3) != 0 {
+
+		t.Mask, nOff, err = backend.ReadPackedBools(bs[n:], t.Mask, lim)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Mask != nil {
+			ct.Mask = make([]bool, len(t.Mask))
+			for i1 := range t.Mask {
+
+				ct.Mask[i1] = t.Mask[i1]
+			}
+		}
+	}
+
+	return n, err
+}
+
+func (t EntityFlags) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *EntityFlags) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t FixedInts) EncodeCod(bs []byte) []byte {
+
+	bs = backend.WriteUint64(bs, (t.Hash))
+
+	bs = backend.WriteUint32(bs, (t.Id))
+
+	bs = backend.WriteInt16(bs, (t.Offset))
+
+	bs = backend.WriteInt64(bs, int64(t.Count))
+
+	bs = backend.WriteUint32(bs, uint32(t.Tick))
+
+	for i1 := range t.Coords {
+
+		bs = backend.WriteInt32(bs, (t.Coords[i1]))
+
+	}
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Ids)))
+		for i1 := range t.Ids {
+
+			bs = backend.WriteUint32(bs, (t.Ids[i1]))
+
+		}
+	}
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Lookup)))
+
+		for k1, v1 := range t.Lookup {
+
+			bs = backend.WriteUint16(bs, (k1))
+
+			bs = backend.WriteInt64(bs, (v1))
+
+		}
+
+	}
+	bs = backend.WriteVarUint64(bs, (t.Varint))
+
+	return bs
+}
+
+func (t *FixedInts) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *FixedInts) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	{
+		var decoded uint64
+		decoded, nOff, err = backend.ReadUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		t.Hash = (decoded)
+	}
+
+	{
+		var decoded uint32
+		decoded, nOff, err = backend.ReadUint32(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		t.Id = (decoded)
+	}
+
+	{
+		var decoded int16
+		decoded, nOff, err = backend.ReadInt16(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		t.Offset = (decoded)
+	}
+
+	{
+		var decoded int64
+		decoded, nOff, err = backend.ReadInt64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		t.Count = int(decoded)
+	}
+
+	{
+		var decoded uint32
+		decoded, nOff, err = backend.ReadUint32(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		t.Tick = Tick(decoded)
+	}
+
+	for i1 := range t.Coords {
+
+		{
+			var decoded int32
+			decoded, nOff, err = backend.ReadInt32(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Coords[i1] = (decoded)
+		}
+
+		if err != nil {
+			return 0, err
+		}
+	}
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitSlice[uint32](lim, length)
+		if err != nil {
+			return 0, err
+		}
+		t.Ids = backend.ResetSlice(t.Ids, length, len(bs[n:]))
+
+		for i1 := 0; i1 < int(length); i1++ {
+			t.Ids = backend.ExtendSlice(t.Ids)
+
+			{
+				var decoded uint32
+				decoded, nOff, err = backend.ReadUint32(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				t.Ids[i1] = (decoded)
+			}
+
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitMap[uint16, int64](lim, length)
+		if err != nil {
+			return 0, err
+		}
+
+		if t.Lookup == nil {
+			t.Lookup = make(map[uint16]int64, backend.PreallocLen(length, len(bs[n:])))
+		} else {
+			clear(t.Lookup)
+		}
+
+		for i1 := 0; i1 < int(length); i1++ {
+			var key1 uint16
+			var val1 int64
+
+			{
+				var decoded uint16
+				decoded, nOff, err = backend.ReadUint16(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				key1 = (decoded)
+			}
+
+			{
+				var decoded int64
+				decoded, nOff, err = backend.ReadInt64(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				val1 = (decoded)
+			}
+
+			if err != nil {
+				return 0, err
+			}
+
+			t.Lookup[key1] = val1
+		}
+	}
+	{
+		var decoded uint64
+		decoded, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		t.Varint = (decoded)
+	}
+
+	// println("FixedInts:", n)
+	return n, err
+}
+
+func (t FixedInts) CodEquals(tt FixedInts) bool {
+
+	if t.Hash != tt.Hash {
+		return false
+	}
+
+	if t.Id != tt.Id {
+		return false
+	}
+
+	if t.Offset != tt.Offset {
+		return false
+	}
+
+	if t.Count != tt.Count {
+		return false
+	}
+
+	if t.Tick != tt.Tick {
+		return false
+	}
+
+	for i1 := range t.Coords {
+
+		if t.Coords[i1] != tt.Coords[i1] {
+			return false
+		}
+
+	}
+	{
+		if len(t.Ids) != len(tt.Ids) {
+			return false
+		}
+		for i1 := range t.Ids {
+
+			if t.Ids[i1] != tt.Ids[i1] {
+				return false
+			}
+
+		}
+	}
+	{
+		if len(t.Lookup) != len(tt.Lookup) {
+			return false
+		}
+		for k1, v1 := range t.Lookup {
+			tv1, ok := tt.Lookup[k1]
+			if !ok {
+				return false
+			}
+
+			if v1 != tv1 {
+				return false
+			}
+
+		}
+	}
+	if t.Varint != tt.Varint {
+		return false
+	}
+
+	return true
+}
+
+func (t FixedInts) CodClone() FixedInts {
+	var ct FixedInts
+
+	ct.Hash = t.Hash
+	ct.Id = t.Id
+	ct.Offset = t.Offset
+	ct.Count = t.Count
+	ct.Tick = t.Tick
+	for i1 := range t.Coords {
+
+		ct.Coords[i1] = t.Coords[i1]
+	}
+	if t.Ids != nil {
+		ct.Ids = make([]uint32, len(t.Ids))
+		for i1 := range t.Ids {
+
+			ct.Ids[i1] = t.Ids[i1]
+		}
+	}
+	if t.Lookup != nil {
+		ct.Lookup = make(map[uint16]int64, len(t.Lookup))
+		for k1, v1 := range t.Lookup {
+			var cv1 int64
+
+			cv1 = v1
+			ct.Lookup[k1] = cv1
+		}
+	}
+	ct.Varint = t.Varint
+	return ct
+}
+
+func (t FixedInts) CodSize() int {
+	n := 0
+
+	n += backend.SizeUint64((t.Hash))
+	n += backend.SizeUint32((t.Id))
+	n += backend.SizeInt16((t.Offset))
+	n += backend.SizeInt64(int64(t.Count))
+	n += backend.SizeUint32(uint32(t.Tick))
+	for i1 := range t.Coords {
+
+		n += backend.SizeInt32((t.Coords[i1]))
+	}
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Ids)))
+		for i1 := range t.Ids {
+
+			n += backend.SizeUint32((t.Ids[i1]))
+		}
+	}
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Lookup)))
+		for k1, v1 := range t.Lookup {
+
+			n += backend.SizeUint16((k1))
+			n += backend.SizeInt64((v1))
+		}
+	}
+	n += backend.SizeVarUint64((t.Varint))
+	return n
+}
+
+func (t FixedInts) EncodeCodDelta(bs []byte, tt FixedInts) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 2)...)
+
+	if !func() bool {
+
+		if t.Hash != tt.Hash {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
+
+		bs = backend.WriteUint64(bs, (t.Hash))
+
+	}
+
+	if !func() bool {
+
+		if t.Id != tt.Id {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 1
+
+		bs = backend.WriteUint32(bs, (t.Id))
+
+	}
+
+	if !func() bool {
+
+		if t.Offset != tt.Offset {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 2
+
+		bs = backend.WriteInt16(bs, (t.Offset))
+
+	}
+
+	if !func() bool {
+
+		if t.Count != tt.Count {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 3
+
+		bs = backend.WriteInt64(bs, int64(t.Count))
+
+	}
+
+	if !func() bool {
+
+		if t.Tick != tt.Tick {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 4
+
+		bs = backend.WriteUint32(bs, uint32(t.Tick))
+
+	}
+
+	if !func() bool {
+
+		for i1 := range t.Coords {
+
+			if t.Coords[i1] != tt.Coords[i1] {
+				return false
+			}
+
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 5
+
+		for i1 := range t.Coords {
+
+			bs = backend.WriteInt32(bs, (t.Coords[i1]))
+
+		}
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Ids) != len(tt.Ids) {
+				return false
+			}
+			for i1 := range t.Ids {
+
+				if t.Ids[i1] != tt.Ids[i1] {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 6
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Ids)))
+			for i1 := range t.Ids {
+
+				bs = backend.WriteUint32(bs, (t.Ids[i1]))
+
+			}
+		}
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Lookup) != len(tt.Lookup) {
+				return false
+			}
+			for k1, v1 := range t.Lookup {
+				tv1, ok := tt.Lookup[k1]
+				if !ok {
+					return false
+				}
+
+				if v1 != tv1 {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 7
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Lookup)))
+
+			for k1, v1 := range t.Lookup {
+
+				bs = backend.WriteUint16(bs, (k1))
+
+				bs = backend.WriteInt64(bs, (v1))
+
+			}
+
+		}
+	}
+
+	if !func() bool {
+
+		if t.Varint != tt.Varint {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+1] |= 1 << 0
+
+		bs = backend.WriteVarUint64(bs, (t.Varint))
+
+	}
+
+	return bs
+}
+
+func (t *FixedInts) DecodeCodDelta(bs []byte, tt FixedInts) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 2 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:2]
+	n += 2
+
+	if mask[0]&(1<<0) != 0 {
+
+		{
+			var decoded uint64
+			decoded, nOff, err = backend.ReadUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Hash = (decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Hash = t.Hash
+	}
+
+	if mask[0]&(1<<1) != 0 {
+
+		{
+			var decoded uint32
+			decoded, nOff, err = backend.ReadUint32(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Id = (decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Id = t.Id
+	}
+
+	if mask[0]&(1<<2) != 0 {
+
+		{
+			var decoded int16
+			decoded, nOff, err = backend.ReadInt16(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Offset = (decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Offset = t.Offset
+	}
+
+	if mask[0]&(1<<3) != 0 {
+
+		{
+			var decoded int64
+			decoded, nOff, err = backend.ReadInt64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Count = int(decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Count = t.Count
+	}
+
+	if mask[0]&(1<<4) != 0 {
+
+		{
+			var decoded uint32
+			decoded, nOff, err = backend.ReadUint32(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Tick = Tick(decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Tick = t.Tick
+	}
+
+	if mask[0]&(1<<5) != 0 {
+
+		for i1 := range t.Coords {
+
+			{
+				var decoded int32
+				decoded, nOff, err = backend.ReadInt32(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				t.Coords[i1] = (decoded)
+			}
+
+			if err != nil {
+				return 0, err
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		for i1 := range t.Coords {
+
+			ct.Coords[i1] = t.Coords[i1]
+		}
+	}
+
+	if mask[0]&(1<<6) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[uint32](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			t.Ids = backend.ResetSlice(t.Ids, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				t.Ids = backend.ExtendSlice(t.Ids)
+
+				{
+					var decoded uint32
+					decoded, nOff, err = backend.ReadUint32(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					t.Ids[i1] = (decoded)
+				}
+
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Ids != nil {
+			ct.Ids = make([]uint32, len(t.Ids))
+			for i1 := range t.Ids {
+
+				ct.Ids[i1] = t.Ids[i1]
+			}
+		}
+	}
+
+	if mask[0]&(1<<7) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitMap[uint16, int64](lim, length)
+			if err != nil {
+				return 0, err
+			}
+
+			if t.Lookup == nil {
+				t.Lookup = make(map[uint16]int64, backend.PreallocLen(length, len(bs[n:])))
+			} else {
+				clear(t.Lookup)
+			}
+
+			for i1 := 0; i1 < int(length); i1++ {
+				var key1 uint16
+				var val1 int64
+
+				{
+					var decoded uint16
+					decoded, nOff, err = backend.ReadUint16(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					key1 = (decoded)
+				}
+
+				{
+					var decoded int64
+					decoded, nOff, err = backend.ReadInt64(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					val1 = (decoded)
+				}
+
+				if err != nil {
+					return 0, err
+				}
+
+				t.Lookup[key1] = val1
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Lookup != nil {
+			ct.Lookup = make(map[uint16]int64, len(t.Lookup))
+			for k1, v1 := range t.Lookup {
+				var cv1 int64
+
+				cv1 = v1
+				ct.Lookup[k1] = cv1
+			}
+		}
+	}
+
+	if mask[1]&(1<<0) != 0 {
+
+		{
+			var decoded uint64
+			decoded, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Varint = (decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Varint = t.Varint
+	}
+
+	return n, err
+}
+
+func (t FixedInts) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *FixedInts) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t Floats) EncodeCod(bs []byte) []byte {
+
+	bs = backend.WriteFloat32(bs, (t.F32))
+
+	bs = backend.WriteFloat64(bs, (t.F64))
+
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Vals)))
+		for i1 := range t.Vals {
+
+			bs = backend.WriteFloat64(bs, (t.Vals[i1]))
+
+		}
+	}
+	return bs
+}
+
+func (t *Floats) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *Floats) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	{
+		var decoded float32
+		decoded, nOff, err = backend.ReadFloat32(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		t.F32 = (decoded)
+	}
+
+	{
+		var decoded float64
+		decoded, nOff, err = backend.ReadFloat64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		t.F64 = (decoded)
+	}
+
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitSlice[float64](lim, length)
+		if err != nil {
+			return 0, err
+		}
+		t.Vals = backend.ResetSlice(t.Vals, length, len(bs[n:]))
+
+		for i1 := 0; i1 < int(length); i1++ {
+			t.Vals = backend.ExtendSlice(t.Vals)
+
+			{
+				var decoded float64
+				decoded, nOff, err = backend.ReadFloat64(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				t.Vals[i1] = (decoded)
+			}
+
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	// println("Floats:", n)
+	return n, err
+}
+
+func (t Floats) CodEquals(tt Floats) bool {
+
+	if !backend.EqualFloat(t.F32, tt.F32) {
+		return false
+	}
+
+	if !backend.EqualFloat(t.F64, tt.F64) {
+		return false
+	}
+
+	{
+		if len(t.Vals) != len(tt.Vals) {
+			return false
+		}
+		for i1 := range t.Vals {
+
+			if !backend.EqualFloat(t.Vals[i1], tt.Vals[i1]) {
+				return false
+			}
+
+		}
+	}
+	return true
+}
+
+func (t Floats) CodClone() Floats {
+	var ct Floats
+
+	ct.F32 = t.F32
+	ct.F64 = t.F64
+	if t.Vals != nil {
+		ct.Vals = make([]float64, len(t.Vals))
+		for i1 := range t.Vals {
+
+			ct.Vals[i1] = t.Vals[i1]
+		}
+	}
+	return ct
+}
+
+func (t Floats) CodSize() int {
+	n := 0
+
+	n += backend.SizeFloat32((t.F32))
+	n += backend.SizeFloat64((t.F64))
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Vals)))
+		for i1 := range t.Vals {
+
+			n += backend.SizeFloat64((t.Vals[i1]))
+		}
+	}
+	return n
+}
+
+func (t Floats) EncodeCodDelta(bs []byte, tt Floats) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 1)...)
+
+	if !func() bool {
+
+		if !backend.EqualFloat(t.F32, tt.F32) {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
+
+		bs = backend.WriteFloat32(bs, (t.F32))
+
+	}
+
+	if !func() bool {
+
+		if !backend.EqualFloat(t.F64, tt.F64) {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 1
+
+		bs = backend.WriteFloat64(bs, (t.F64))
+
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Vals) != len(tt.Vals) {
+				return false
+			}
+			for i1 := range t.Vals {
+
+				if !backend.EqualFloat(t.Vals[i1], tt.Vals[i1]) {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 2
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Vals)))
+			for i1 := range t.Vals {
+
+				bs = backend.WriteFloat64(bs, (t.Vals[i1]))
+
+			}
+		}
+	}
+
+	return bs
+}
+
+func (t *Floats) DecodeCodDelta(bs []byte, tt Floats) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 1 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:1]
+	n += 1
+
+	if mask[0]&(1<<0) != 0 {
+
+		{
+			var decoded float32
+			decoded, nOff, err = backend.ReadFloat32(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.F32 = (decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.F32 = t.F32
+	}
+
+	if mask[0]&(1<<1) != 0 {
+
+		{
+			var decoded float64
+			decoded, nOff, err = backend.ReadFloat64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.F64 = (decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.F64 = t.F64
+	}
+
+	if mask[0]&(1<<2) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[float64](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			t.Vals = backend.ResetSlice(t.Vals, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				t.Vals = backend.ExtendSlice(t.Vals)
+
+				{
+					var decoded float64
+					decoded, nOff, err = backend.ReadFloat64(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					t.Vals[i1] = (decoded)
+				}
+
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Vals != nil {
+			ct.Vals = make([]float64, len(t.Vals))
+			for i1 := range t.Vals {
+
+				ct.Vals[i1] = t.Vals[i1]
+			}
+		}
+	}
+
+	return n, err
+}
+
+func (t Floats) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *Floats) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t Generics) EncodeCod(bs []byte) []byte {
+
+	bs = t.Opt.EncodeCod(bs)
+	bs = t.Pair.EncodeCod(bs)
+	bs = t.Pool.EncodeCod(bs)
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Opts)))
+		for i1 := range t.Opts {
+
+			bs = t.Opts[i1].EncodeCod(bs)
+		}
+	}
+	bs = t.List.EncodeCod(bs)
+	bs = t.Option.EncodeCod(bs)
+	return bs
+}
+
+func (t *Generics) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *Generics) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	nOff, err = t.Opt.DecodeCodWithLimits(bs[n:], lim)
+	if err != nil {
+		return 0, err
+	}
+	n += nOff
+
+	nOff, err = t.Pair.DecodeCodWithLimits(bs[n:], lim)
+	if err != nil {
+		return 0, err
+	}
+	n += nOff
+
+	nOff, err = t.Pool.DecodeCodWithLimits(bs[n:], lim)
+	if err != nil {
+		return 0, err
+	}
+	n += nOff
+
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitSlice[Option[Id, *Id]](lim, length)
+		if err != nil {
+			return 0, err
+		}
+		t.Opts = backend.ResetSlice(t.Opts, length, len(bs[n:]))
+
+		for i1 := 0; i1 < int(length); i1++ {
+			t.Opts = backend.ExtendSlice(t.Opts)
+
+			nOff, err = t.Opts[i1].DecodeCodWithLimits(bs[n:], lim)
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	nOff, err = t.List.DecodeCodWithLimits(bs[n:], lim)
+	if err != nil {
+		return 0, err
+	}
+	n += nOff
+
+	nOff, err = t.Option.DecodeCodWithLimits(bs[n:], lim)
+	if err != nil {
+		return 0, err
+	}
+	n += nOff
+
+	// println("Generics:", n)
+	return n, err
+}
+
+func (t Generics) CodEquals(tt Generics) bool {
+
+	if !t.Opt.CodEquals(tt.Opt) {
+		return false
+	}
+
+	if !t.Pair.CodEquals(tt.Pair) {
+		return false
+	}
+
+	if !t.Pool.CodEquals(tt.Pool) {
+		return false
+	}
+
+	{
+		if len(t.Opts) != len(tt.Opts) {
+			return false
+		}
+		for i1 := range t.Opts {
+
+			if !t.Opts[i1].CodEquals(tt.Opts[i1]) {
+				return false
+			}
+
+		}
+	}
+	if !t.List.CodEquals(tt.List) {
+		return false
+	}
+
+	if !t.Option.CodEquals(tt.Option) {
+		return false
+	}
+
+	return true
+}
+
+func (t Generics) CodClone() Generics {
+	var ct Generics
+
+	ct.Opt = t.Opt.CodClone()
+	ct.Pair = t.Pair.CodClone()
+	ct.Pool = t.Pool.CodClone()
+	if t.Opts != nil {
+		ct.Opts = make([]Option[Id, *Id], len(t.Opts))
+		for i1 := range t.Opts {
+
+			ct.Opts[i1] = t.Opts[i1].CodClone()
+		}
+	}
+	ct.List = t.List.CodClone()
+	ct.Option = t.Option.CodClone()
+	return ct
+}
+
+func (t Generics) CodSize() int {
+	n := 0
+
+	n += t.Opt.CodSize()
+	n += t.Pair.CodSize()
+	n += t.Pool.CodSize()
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Opts)))
+		for i1 := range t.Opts {
+
+			n += t.Opts[i1].CodSize()
+		}
+	}
+	n += t.List.CodSize()
+	n += t.Option.CodSize()
+	return n
+}
+
+func (t Generics) EncodeCodDelta(bs []byte, tt Generics) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 1)...)
+
+	if !func() bool {
+
+		if !t.Opt.CodEquals(tt.Opt) {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
+
+		bs = t.Opt.EncodeCodDelta(bs, tt.Opt)
+	}
+
+	if !func() bool {
+
+		if !t.Pair.CodEquals(tt.Pair) {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 1
+
+		bs = t.Pair.EncodeCodDelta(bs, tt.Pair)
+	}
+
+	if !func() bool {
+
+		if !t.Pool.CodEquals(tt.Pool) {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 2
+
+		bs = t.Pool.EncodeCodDelta(bs, tt.Pool)
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Opts) != len(tt.Opts) {
+				return false
+			}
+			for i1 := range t.Opts {
+
+				if !t.Opts[i1].CodEquals(tt.Opts[i1]) {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 3
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Opts)))
+			for i1 := range t.Opts {
+
+				bs = t.Opts[i1].EncodeCod(bs)
+			}
+		}
+	}
+
+	if !func() bool {
+
+		if !t.List.CodEquals(tt.List) {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 4
+
+		bs = t.List.EncodeCodDelta(bs, tt.List)
+	}
+
+	if !func() bool {
+
+		if !t.Option.CodEquals(tt.Option) {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 5
+
+		bs = t.Option.EncodeCodDelta(bs, tt.Option)
+	}
+
+	return bs
+}
+
+func (t *Generics) DecodeCodDelta(bs []byte, tt Generics) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 1 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:1]
+	n += 1
+
+	if mask[0]&(1<<0) != 0 {
+
+		nOff, err = t.Opt.DecodeCodDelta(bs[n:], tt.Opt)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Opt = t.Opt.CodClone()
+	}
+
+	if mask[0]&(1<<1) != 0 {
+
+		nOff, err = t.Pair.DecodeCodDelta(bs[n:], tt.Pair)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Pair = t.Pair.CodClone()
+	}
+
+	if mask[0]&(1<<2) != 0 {
+
+		nOff, err = t.Pool.DecodeCodDelta(bs[n:], tt.Pool)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Pool = t.Pool.CodClone()
+	}
+
+	if mask[0]&(1<<3) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[Option[Id, *Id]](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			t.Opts = backend.ResetSlice(t.Opts, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				t.Opts = backend.ExtendSlice(t.Opts)
+
+				nOff, err = t.Opts[i1].DecodeCodWithLimits(bs[n:], lim)
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Opts != nil {
+			ct.Opts = make([]Option[Id, *Id], len(t.Opts))
+			for i1 := range t.Opts {
+
+				ct.Opts[i1] = t.Opts[i1].CodClone()
+			}
+		}
+	}
+
+	if mask[0]&(1<<4) != 0 {
+
+		nOff, err = t.List.DecodeCodDelta(bs[n:], tt.List)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.List = t.List.CodClone()
+	}
+
+	if mask[0]&(1<<5) != 0 {
+
+		nOff, err = t.Option.DecodeCodDelta(bs[n:], tt.Option)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Option = t.Option.CodClone()
+	}
+
+	return n, err
+}
+
+func (t Generics) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *Generics) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t Id) EncodeCod(bs []byte) []byte {
+
+	bs = backend.WriteVarUint16(bs, (t.Val))
+
+	return bs
+}
+
+func (t *Id) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *Id) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	{
+		var decoded uint16
+		decoded, nOff, err = backend.ReadVarUint16(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		t.Val = (decoded)
+	}
+
+	// println("Id:", n)
+	return n, err
+}
+
+func (t Id) CodEquals(tt Id) bool {
+
+	if t.Val != tt.Val {
+		return false
+	}
+
+	return true
+}
+
+func (t Id) CodClone() Id {
+	var ct Id
+
+	ct.Val = t.Val
+	return ct
+}
+
+func (t Id) CodSize() int {
+	n := 0
+
+	n += backend.SizeVarUint16((t.Val))
+	return n
+}
+
+func (t Id) EncodeCodDelta(bs []byte, tt Id) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 1)...)
+
+	if !func() bool {
+
+		if t.Val != tt.Val {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
+
+		bs = backend.WriteVarUint16(bs, (t.Val))
+
+	}
+
+	return bs
+}
+
+func (t *Id) DecodeCodDelta(bs []byte, tt Id) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 1 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:1]
+	n += 1
+
+	if mask[0]&(1<<0) != 0 {
+
+		{
+			var decoded uint16
+			decoded, nOff, err = backend.ReadVarUint16(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Val = (decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Val = t.Val
+	}
+
+	return n, err
+}
+
+func (t Id) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *Id) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t IdList) EncodeCod(bs []byte) []byte {
+
+	{
+		value0 := []Id(t)
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(value0)))
+			for i1 := range value0 {
+
+				bs = value0[i1].EncodeCod(bs)
+			}
+		}
+
+	}
+	return bs
+}
+
+func (t *IdList) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *IdList) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	{
+		value0 := []Id(*t)
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[Id](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			value0 = backend.ResetSlice(value0, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				value0 = backend.ExtendSlice(value0)
+
+				nOff, err = value0[i1].DecodeCodWithLimits(bs[n:], lim)
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+		*t = IdList(value0)
+	}
+
+	// println("IdList:", n)
+	return n, err
+}
+
+func (t IdList) CodEquals(tt IdList) bool {
+
+	{
+		value0 := []Id(t)
+		tvalue0 := []Id(tt)
+
+		{
+			if len(value0) != len(tvalue0) {
+				return false
+			}
+			for i1 := range value0 {
+
+				if !value0[i1].CodEquals(tvalue0[i1]) {
+					return false
+				}
+
+			}
+		}
+	}
+	return true
+}
+
+func (t IdList) CodClone() IdList {
+	var ct IdList
+
+	{
+		value0 := []Id(t)
+		var cvalue0 []Id
+
+		if value0 != nil {
+			cvalue0 = make([]Id, len(value0))
+			for i1 := range value0 {
+
+				cvalue0[i1] = value0[i1].CodClone()
+			}
+		}
+		ct = IdList(cvalue0)
+	}
+	return ct
+}
+
+func (t IdList) CodSize() int {
+	n := 0
+
+	{
+		value0 := []Id(t)
+
+		{
+			n += backend.SizeVarUint64(uint64(len(value0)))
+			for i1 := range value0 {
+
+				n += value0[i1].CodSize()
+			}
+		}
+	}
+	return n
+}
+
+func (t IdList) EncodeCodDelta(bs []byte, tt IdList) []byte {
+	return t.EncodeCod(bs)
+}
+
+func (t *IdList) DecodeCodDelta(bs []byte, tt IdList) (int, error) {
+	return t.DecodeCod(bs)
+}
+
+func (t IdList) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *IdList) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t List[T, PT]) EncodeCod(bs []byte) []byte {
+
+	{
+		value0 := []T(t)
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(value0)))
+			for i1 := range value0 {
+
+				bs = value0[i1].EncodeCod(bs)
+			}
+		}
+
+	}
+	return bs
+}
+
+func (t *List[T, PT]) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *List[T, PT]) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	{
+		value0 := []T(*t)
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[T](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			value0 = backend.ResetSlice(value0, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				value0 = backend.ExtendSlice(value0)
+
+				nOff, err = PT(&value0[i1]).DecodeCodWithLimits(bs[n:], lim)
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+		*t = List[T, PT](value0)
+	}
+
+	// println("List[T, PT]:", n)
+	return n, err
+}
+
+func (t List[T, PT]) CodEquals(tt List[T, PT]) bool {
+
+	{
+		value0 := []T(t)
+		tvalue0 := []T(tt)
+
+		{
+			if len(value0) != len(tvalue0) {
+				return false
+			}
+			for i1 := range value0 {
+
+				if !value0[i1].CodEquals(tvalue0[i1]) {
+					return false
+				}
+
+			}
+		}
+	}
+	return true
+}
+
+func (t List[T, PT]) CodClone() List[T, PT] {
+	var ct List[T, PT]
+
+	{
+		value0 := []T(t)
+		var cvalue0 []T
+
+		if value0 != nil {
+			cvalue0 = make([]T, len(value0))
+			for i1 := range value0 {
+
+				cvalue0[i1] = value0[i1].CodClone()
+			}
+		}
+		ct = List[T, PT](cvalue0)
+	}
+	return ct
+}
+
+func (t List[T, PT]) CodSize() int {
+	n := 0
+
+	{
+		value0 := []T(t)
+
+		{
+			n += backend.SizeVarUint64(uint64(len(value0)))
+			for i1 := range value0 {
+
+				n += value0[i1].CodSize()
+			}
+		}
+	}
+	return n
+}
+
+func (t List[T, PT]) EncodeCodDelta(bs []byte, tt List[T, PT]) []byte {
+	return t.EncodeCod(bs)
+}
+
+func (t *List[T, PT]) DecodeCodDelta(bs []byte, tt List[T, PT]) (int, error) {
+	return t.DecodeCod(bs)
+}
+
+func (t List[T, PT]) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *List[T, PT]) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t MinSizes) EncodeCod(bs []byte) []byte {
+
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Blanks)))
+		for i1 := range t.Blanks {
+
+			bs = t.Blanks[i1].EncodeCod(bs)
+		}
+	}
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Hashes)))
+		for i1 := range t.Hashes {
+
+			bs = backend.WriteUint64(bs, (t.Hashes[i1]))
+
+		}
+	}
+	return bs
+}
+
+func (t *MinSizes) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *MinSizes) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitSlice[BlankStruct](lim, length)
+		if err != nil {
+			return 0, err
+		}
+		t.Blanks = backend.ResetSlice(t.Blanks, length, len(bs[n:]))
+
+		for i1 := 0; i1 < int(length); i1++ {
+			t.Blanks = backend.ExtendSlice(t.Blanks)
+
+			nOff, err = t.Blanks[i1].DecodeCodWithLimits(bs[n:], lim)
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitSlice[uint64](lim, length)
+		if err != nil {
+			return 0, err
+		}
+		t.Hashes = backend.ResetSlice(t.Hashes, length, len(bs[n:]))
+
+		for i1 := 0; i1 < int(length); i1++ {
+			t.Hashes = backend.ExtendSlice(t.Hashes)
+
+			{
+				var decoded uint64
+				decoded, nOff, err = backend.ReadUint64(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				t.Hashes[i1] = (decoded)
+			}
+
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	// println("MinSizes:", n)
+	return n, err
+}
+
+func (t MinSizes) CodEquals(tt MinSizes) bool {
+
+	{
+		if len(t.Blanks) != len(tt.Blanks) {
+			return false
+		}
+		for i1 := range t.Blanks {
+
+			if !t.Blanks[i1].CodEquals(tt.Blanks[i1]) {
+				return false
+			}
+
+		}
+	}
+	{
+		if len(t.Hashes) != len(tt.Hashes) {
+			return false
+		}
+		for i1 := range t.Hashes {
+
+			if t.Hashes[i1] != tt.Hashes[i1] {
+				return false
+			}
+
+		}
+	}
+	return true
+}
+
+func (t MinSizes) CodClone() MinSizes {
+	var ct MinSizes
+
+	if t.Blanks != nil {
+		ct.Blanks = make([]BlankStruct, len(t.Blanks))
+		for i1 := range t.Blanks {
+
+			ct.Blanks[i1] = t.Blanks[i1].CodClone()
+		}
+	}
+	if t.Hashes != nil {
+		ct.Hashes = make([]uint64, len(t.Hashes))
+		for i1 := range t.Hashes {
+
+			ct.Hashes[i1] = t.Hashes[i1]
+		}
+	}
+	return ct
+}
+
+func (t MinSizes) CodSize() int {
+	n := 0
+
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Blanks)))
+		for i1 := range t.Blanks {
+
+			n += t.Blanks[i1].CodSize()
+		}
+	}
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Hashes)))
+		for i1 := range t.Hashes {
+
+			n += backend.SizeUint64((t.Hashes[i1]))
+		}
+	}
+	return n
+}
+
+func (t MinSizes) EncodeCodDelta(bs []byte, tt MinSizes) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 1)...)
+
+	if !func() bool {
+
+		{
+			if len(t.Blanks) != len(tt.Blanks) {
+				return false
+			}
+			for i1 := range t.Blanks {
+
+				if !t.Blanks[i1].CodEquals(tt.Blanks[i1]) {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Blanks)))
+			for i1 := range t.Blanks {
+
+				bs = t.Blanks[i1].EncodeCod(bs)
+			}
+		}
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Hashes) != len(tt.Hashes) {
+				return false
+			}
+			for i1 := range t.Hashes {
+
+				if t.Hashes[i1] != tt.Hashes[i1] {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 1
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Hashes)))
+			for i1 := range t.Hashes {
+
+				bs = backend.WriteUint64(bs, (t.Hashes[i1]))
+
+			}
+		}
+	}
+
+	return bs
+}
+
+func (t *MinSizes) DecodeCodDelta(bs []byte, tt MinSizes) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 1 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:1]
+	n += 1
+
+	if mask[0]&(1<<0) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[BlankStruct](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			t.Blanks = backend.ResetSlice(t.Blanks, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				t.Blanks = backend.ExtendSlice(t.Blanks)
+
+				nOff, err = t.Blanks[i1].DecodeCodWithLimits(bs[n:], lim)
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Blanks != nil {
+			ct.Blanks = make([]BlankStruct, len(t.Blanks))
+			for i1 := range t.Blanks {
+
+				ct.Blanks[i1] = t.Blanks[i1].CodClone()
+			}
+		}
+	}
+
+	if mask[0]&(1<<1) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[uint64](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			t.Hashes = backend.ResetSlice(t.Hashes, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				t.Hashes = backend.ExtendSlice(t.Hashes)
+
+				{
+					var decoded uint64
+					decoded, nOff, err = backend.ReadUint64(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					t.Hashes[i1] = (decoded)
+				}
+
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Hashes != nil {
+			ct.Hashes = make([]uint64, len(t.Hashes))
+			for i1 := range t.Hashes {
+
+				ct.Hashes[i1] = t.Hashes[i1]
+			}
+		}
+	}
+
+	return n, err
+}
+
+func (t MinSizes) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *MinSizes) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t MyStruct) EncodeCod(bs []byte) []byte {
+
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Vector)))
+		for i1 := range t.Vector {
+
+			bs = t.Vector[i1].EncodeCod(bs)
+		}
+	}
+	return bs
+}
+
+func (t *MyStruct) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *MyStruct) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitSlice[subpackage.Vec](lim, length)
+		if err != nil {
+			return 0, err
+		}
+		t.Vector = backend.ResetSlice(t.Vector, length, len(bs[n:]))
+
+		for i1 := 0; i1 < int(length); i1++ {
+			t.Vector = backend.ExtendSlice(t.Vector)
+
+			nOff, err = t.Vector[i1].DecodeCodWithLimits(bs[n:], lim)
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	// println("MyStruct:", n)
+	return n, err
+}
+
+func (t MyStruct) CodEquals(tt MyStruct) bool {
+
+	{
+		if len(t.Vector) != len(tt.Vector) {
+			return false
+		}
+		for i1 := range t.Vector {
+
+			if !t.Vector[i1].CodEquals(tt.Vector[i1]) {
+				return false
+			}
+
+		}
+	}
+	return true
+}
+
+func (t MyStruct) CodClone() MyStruct {
+	var ct MyStruct
+
+	if t.Vector != nil {
+		ct.Vector = make([]subpackage.Vec, len(t.Vector))
+		for i1 := range t.Vector {
+
+			ct.Vector[i1] = t.Vector[i1].CodClone()
+		}
+	}
+	return ct
+}
+
+func (t MyStruct) CodSize() int {
+	n := 0
+
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Vector)))
+		for i1 := range t.Vector {
+
+			n += t.Vector[i1].CodSize()
+		}
+	}
+	return n
+}
+
+func (t MyStruct) EncodeCodDelta(bs []byte, tt MyStruct) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 1)...)
+
+	if !func() bool {
+
+		{
+			if len(t.Vector) != len(tt.Vector) {
+				return false
+			}
+			for i1 := range t.Vector {
+
+				if !t.Vector[i1].CodEquals(tt.Vector[i1]) {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Vector)))
+			for i1 := range t.Vector {
+
+				bs = t.Vector[i1].EncodeCod(bs)
+			}
+		}
+	}
+
+	return bs
+}
+
+func (t *MyStruct) DecodeCodDelta(bs []byte, tt MyStruct) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 1 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:1]
+	n += 1
+
+	if mask[0]&(1<<0) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[subpackage.Vec](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			t.Vector = backend.ResetSlice(t.Vector, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				t.Vector = backend.ExtendSlice(t.Vector)
+
+				nOff, err = t.Vector[i1].DecodeCodWithLimits(bs[n:], lim)
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Vector != nil {
+			ct.Vector = make([]subpackage.Vec, len(t.Vector))
+			for i1 := range t.Vector {
+
+				ct.Vector[i1] = t.Vector[i1].CodClone()
+			}
+		}
+	}
+
+	return n, err
+}
+
+func (t MyStruct) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *MyStruct) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t MyUnion) EncodeCod(bs []byte) []byte {
+
+	tag := t.Tag()
+	bs = backend.WriteUint8(bs, tag)
+	if tag == 0 {
+		// Zero tag indicates nil, so write nothing else
+		return bs
+	}
+
+	rawVal := t.Get()
+	bs = rawVal.EncodeCod(bs)
+
+	return bs
+}
+
+func (t *MyUnion) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *MyUnion) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	var tagVal uint8
+
+	tagVal, nOff, err = backend.ReadUint8(bs[n:])
+	if err != nil {
+		return 0, err
+	}
+	n += nOff
+
+	switch tagVal {
+	case 0: // Zero tag indicates nil
+		*t = MyUnion{}
+		return n, nil
+
+	case 1:
+		var decoded Id
+		nOff, err = decoded.DecodeCodWithLimits(bs[n:], lim)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+		t.SetId(decoded)
+
+	case 2:
+		var decoded SpecialMap
+		nOff, err = decoded.DecodeCodWithLimits(bs[n:], lim)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+		t.SetSpecialMap(decoded)
+
+	case 3:
+		var decoded subpackage.Vec
+		nOff, err = decoded.DecodeCodWithLimits(bs[n:], lim)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+		t.SetVec(decoded)
+
+	default:
+		return 0, backend.ErrUnknownUnionType
+	}
+
+	// println("MyUnion:", n)
+	return n, err
+}
+
+func (t MyUnion) Tag() uint8 {
+	rawVal := t.Get()
+	if rawVal == nil {
+		// Zero tag indicates nil
+		return 0
+	}
+
+	switch rawVal.(type) {
+
+	case Id:
+		return 1
+
+	case SpecialMap:
+		return 2
+
+	case subpackage.Vec:
+		return 3
+
+	default:
+		panic(fmt.Sprintf("unknown type placed in union: %T", rawVal))
+	}
+}
+
+func (t MyUnion) Size() int {
+	return 4
+}
+
+func (t MyUnion) CodEquals(tt MyUnion) bool {
+	if t.Tag() != tt.Tag() {
+		return false
+	}
+
+	rawVal := t.Get()
+	switch sv := rawVal.(type) {
+	case nil:
+		return true
+
+	case Id:
+		sv2 := tt.Get().(Id)
+		return sv.CodEquals(sv2)
+
+	case SpecialMap:
+		sv2 := tt.Get().(SpecialMap)
+		return sv.CodEquals(sv2)
+
+	case subpackage.Vec:
+		sv2 := tt.Get().(subpackage.Vec)
+		return sv.CodEquals(sv2)
+
+	default:
+		panic(fmt.Sprintf("unknown type placed in union: %T", rawVal))
+	}
+}
+
+func (t MyUnion) CodClone() MyUnion {
+	var ct MyUnion
+
+	rawVal := t.Get()
+	switch sv := rawVal.(type) {
+	case nil:
+
+	case Id:
+		ct.SetId(sv.CodClone())
+
+	case SpecialMap:
+		ct.SetSpecialMap(sv.CodClone())
+
+	case subpackage.Vec:
+		ct.SetVec(sv.CodClone())
+
+	default:
+		panic(fmt.Sprintf("unknown type placed in union: %T", rawVal))
+	}
+	return ct
+}
+
+func (t MyUnion) EncodeCodDelta(bs []byte, tt MyUnion) []byte {
+	tag := t.Tag()
+	if tag == 0 || tag != tt.Tag() {
+		// The type changed, so write the full value
+		return t.EncodeCod(bs)
+	}
+
+	bs = backend.WriteUint8(bs, tag)
+	switch sv := t.Get().(type) {
+	case Id:
+		bs = sv.EncodeCodDelta(bs, tt.Get().(Id))
+	case SpecialMap:
+		bs = sv.EncodeCodDelta(bs, tt.Get().(SpecialMap))
+	case subpackage.Vec:
+		bs = sv.EncodeCodDelta(bs, tt.Get().(subpackage.Vec))
+	}
+	return bs
+}
+
+func (t *MyUnion) DecodeCodDelta(bs []byte, tt MyUnion) (int, error) {
+	tagVal, n, err := backend.ReadUint8(bs)
+	if err != nil {
+		return 0, err
+	}
+	if tagVal == 0 || tagVal != tt.Tag() {
+		return t.DecodeCod(bs)
+	}
+
+	var nOff int
+	switch tagVal {
+	case 1:
+		var decoded Id
+		nOff, err = decoded.DecodeCodDelta(bs[n:], tt.Get().(Id))
+		if err != nil {
+			return 0, err
+		}
+		t.SetId(decoded)
+	case 2:
+		var decoded SpecialMap
+		nOff, err = decoded.DecodeCodDelta(bs[n:], tt.Get().(SpecialMap))
+		if err != nil {
+			return 0, err
+		}
+		t.SetSpecialMap(decoded)
+	case 3:
+		var decoded subpackage.Vec
+		nOff, err = decoded.DecodeCodDelta(bs[n:], tt.Get().(subpackage.Vec))
+		if err != nil {
+			return 0, err
+		}
+		t.SetVec(decoded)
+	}
+	return n + nOff, nil
+}
+
+func (t MyUnion) CodSize() int {
+	n := backend.SizeUint8(t.Tag())
+
+	rawVal := t.Get()
+	switch sv := rawVal.(type) {
+
+	case Id:
+		n += sv.CodSize()
+
+	case SpecialMap:
+		n += sv.CodSize()
+
+	case subpackage.Vec:
+		n += sv.CodSize()
+
+	}
+	return n
+}
+
+func (t MyUnion) Get() cod.EncoderDecoder {
+	codUnion := cod.Union(t)
+	rawVal := codUnion.GetRawValue()
+	return rawVal
+
+	// switch rawVal.(type) {
+	// <no value>
+	// default:
+	//    panic("unknown type placed in union")
+	// }
+}
+
+func (t *MyUnion) Set(v cod.EncoderDecoder) {
+	switch v.(type) {
+	case nil, Id, SpecialMap, subpackage.Vec:
+	default:
+		panic(fmt.Sprintf("%T is not a member of union MyUnion", v))
+	}
+
+	codUnion := cod.Union(*t)
+	codUnion.PutRawValue(v)
+	*t = MyUnion(codUnion)
+}
+
+func NewMyUnion(v cod.EncoderDecoder) MyUnion {
+	var ret MyUnion
+	ret.Set(v)
+	return ret
+}
+
+func NewMyUnionFromId(v Id) MyUnion {
+	var ret MyUnion
+	ret.SetId(v)
+	return ret
+}
+
+func (t MyUnion) GetId() (Id, bool) {
+	v, ok := t.Get().(Id)
+	return v, ok
+}
+
+func (t *MyUnion) SetId(v Id) {
+	codUnion := cod.Union(*t)
+	codUnion.PutRawValue(v)
+	*t = MyUnion(codUnion)
+}
+
+func NewMyUnionFromSpecialMap(v SpecialMap) MyUnion {
+	var ret MyUnion
+	ret.SetSpecialMap(v)
+	return ret
+}
+
+func (t MyUnion) GetSpecialMap() (SpecialMap, bool) {
+	v, ok := t.Get().(SpecialMap)
+	return v, ok
+}
+
+func (t *MyUnion) SetSpecialMap(v SpecialMap) {
+	codUnion := cod.Union(*t)
+	codUnion.PutRawValue(v)
+	*t = MyUnion(codUnion)
+}
+
+func NewMyUnionFromVec(v subpackage.Vec) MyUnion {
+	var ret MyUnion
+	ret.SetVec(v)
+	return ret
+}
+
+func (t MyUnion) GetVec() (subpackage.Vec, bool) {
+	v, ok := t.Get().(subpackage.Vec)
+	return v, ok
+}
+
+func (t *MyUnion) SetVec(v subpackage.Vec) {
+	codUnion := cod.Union(*t)
+	codUnion.PutRawValue(v)
+	*t = MyUnion(codUnion)
+}
+
+func (t MyUnion) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *MyUnion) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t NamedBasics) EncodeCod(bs []byte) []byte {
+
+	bs = backend.WriteVarUint32(bs, uint32(t.Tick))
+
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Ticks)))
+		for i1 := range t.Ticks {
+
+			bs = backend.WriteVarUint32(bs, uint32(t.Ticks[i1]))
+
+		}
+	}
+	bs = backend.WriteUint8(bs, uint8(t.Byte))
+
+	bs = backend.WriteVarInt32(bs, int32(t.Rune))
+
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Basics)))
+
+		for k1, v1 := range t.Basics {
+
+			bs = backend.WriteVarUint16(bs, uint16(k1))
+
+			bs = backend.WriteVarUint32(bs, uint32(v1))
+
+		}
+
+	}
+	bs = backend.WriteVarUint16(bs, uint16(t.Basic))
+
+	return bs
+}
+
+func (t *NamedBasics) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *NamedBasics) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	{
+		var decoded uint32
+		decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		t.Tick = Tick(decoded)
+	}
+
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitSlice[Tick](lim, length)
+		if err != nil {
+			return 0, err
+		}
+		t.Ticks = backend.ResetSlice(t.Ticks, length, len(bs[n:]))
+
+		for i1 := 0; i1 < int(length); i1++ {
+			t.Ticks = backend.ExtendSlice(t.Ticks)
+
+			{
+				var decoded uint32
+				decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				t.Ticks[i1] = Tick(decoded)
+			}
+
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	{
+		var decoded uint8
+		decoded, nOff, err = backend.ReadUint8(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		t.Byte = byte(decoded)
+	}
+
+	{
+		var decoded int32
+		decoded, nOff, err = backend.ReadVarInt32(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		t.Rune = rune(decoded)
+	}
+
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitMap[blocked.Basic, Tick](lim, length)
+		if err != nil {
+			return 0, err
+		}
+
+		if t.Basics == nil {
+			t.Basics = make(map[blocked.Basic]Tick, backend.PreallocLen(length, len(bs[n:])))
+		} else {
+			clear(t.Basics)
+		}
+
+		for i1 := 0; i1 < int(length); i1++ {
+			var key1 blocked.Basic
+			var val1 Tick
+
+			{
+				var decoded uint16
+				decoded, nOff, err = backend.ReadVarUint16(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				key1 = blocked.Basic(decoded)
+			}
+
+			{
+				var decoded uint32
+				decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				val1 = Tick(decoded)
+			}
+
+			if err != nil {
+				return 0, err
+			}
+
+			t.Basics[key1] = val1
+		}
+	}
+	{
+		var decoded uint16
+		decoded, nOff, err = backend.ReadVarUint16(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		t.Basic = blocked.Basic(decoded)
+	}
+
+	// println("NamedBasics:", n)
+	return n, err
+}
+
+func (t NamedBasics) CodEquals(tt NamedBasics) bool {
+
+	if t.Tick != tt.Tick {
+		return false
+	}
+
+	{
+		if len(t.Ticks) != len(tt.Ticks) {
+			return false
+		}
+		for i1 := range t.Ticks {
+
+			if t.Ticks[i1] != tt.Ticks[i1] {
+				return false
+			}
+
+		}
+	}
+	if t.Byte != tt.Byte {
+		return false
+	}
+
+	if t.Rune != tt.Rune {
+		return false
+	}
+
+	{
+		if len(t.Basics) != len(tt.Basics) {
+			return false
+		}
+		for k1, v1 := range t.Basics {
+			tv1, ok := tt.Basics[k1]
+			if !ok {
+				return false
+			}
+
+			if v1 != tv1 {
+				return false
+			}
+
+		}
+	}
+	if t.Basic != tt.Basic {
+		return false
+	}
+
+	return true
+}
+
+func (t NamedBasics) CodClone() NamedBasics {
+	var ct NamedBasics
+
+	ct.Tick = t.Tick
+	if t.Ticks != nil {
+		ct.Ticks = make([]Tick, len(t.Ticks))
+		for i1 := range t.Ticks {
+
+			ct.Ticks[i1] = t.Ticks[i1]
+		}
+	}
+	ct.Byte = t.Byte
+	ct.Rune = t.Rune
+	if t.Basics != nil {
+		ct.Basics = make(map[blocked.Basic]Tick, len(t.Basics))
+		for k1, v1 := range t.Basics {
+			var cv1 Tick
+
+			cv1 = v1
+			ct.Basics[k1] = cv1
+		}
+	}
+	ct.Basic = t.Basic
+	return ct
+}
+
+func (t NamedBasics) CodSize() int {
+	n := 0
+
+	n += backend.SizeVarUint32(uint32(t.Tick))
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Ticks)))
+		for i1 := range t.Ticks {
+
+			n += backend.SizeVarUint32(uint32(t.Ticks[i1]))
+		}
+	}
+	n += backend.SizeUint8(uint8(t.Byte))
+	n += backend.SizeVarInt32(int32(t.Rune))
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Basics)))
+		for k1, v1 := range t.Basics {
+
+			n += backend.SizeVarUint16(uint16(k1))
+			n += backend.SizeVarUint32(uint32(v1))
+		}
+	}
+	n += backend.SizeVarUint16(uint16(t.Basic))
+	return n
+}
+
+func (t NamedBasics) EncodeCodDelta(bs []byte, tt NamedBasics) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 1)...)
+
+	if !func() bool {
+
+		if t.Tick != tt.Tick {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
+
+		bs = backend.WriteVarUint32(bs, uint32(t.Tick))
+
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Ticks) != len(tt.Ticks) {
+				return false
+			}
+			for i1 := range t.Ticks {
+
+				if t.Ticks[i1] != tt.Ticks[i1] {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 1
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Ticks)))
+			for i1 := range t.Ticks {
+
+				bs = backend.WriteVarUint32(bs, uint32(t.Ticks[i1]))
+
+			}
+		}
+	}
+
+	if !func() bool {
+
+		if t.Byte != tt.Byte {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 2
+
+		bs = backend.WriteUint8(bs, uint8(t.Byte))
+
+	}
+
+	if !func() bool {
+
+		if t.Rune != tt.Rune {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 3
+
+		bs = backend.WriteVarInt32(bs, int32(t.Rune))
+
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Basics) != len(tt.Basics) {
+				return false
+			}
+			for k1, v1 := range t.Basics {
+				tv1, ok := tt.Basics[k1]
+				if !ok {
+					return false
+				}
+
+				if v1 != tv1 {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 4
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Basics)))
+
+			for k1, v1 := range t.Basics {
+
+				bs = backend.WriteVarUint16(bs, uint16(k1))
+
+				bs = backend.WriteVarUint32(bs, uint32(v1))
+
+			}
+
+		}
+	}
+
+	if !func() bool {
+
+		if t.Basic != tt.Basic {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 5
+
+		bs = backend.WriteVarUint16(bs, uint16(t.Basic))
+
+	}
+
+	return bs
+}
+
+func (t *NamedBasics) DecodeCodDelta(bs []byte, tt NamedBasics) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 1 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:1]
+	n += 1
+
+	if mask[0]&(1<<0) != 0 {
+
+		{
+			var decoded uint32
+			decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Tick = Tick(decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Tick = t.Tick
+	}
+
+	if mask[0]&(1<<1) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[Tick](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			t.Ticks = backend.ResetSlice(t.Ticks, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				t.Ticks = backend.ExtendSlice(t.Ticks)
+
+				{
+					var decoded uint32
+					decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					t.Ticks[i1] = Tick(decoded)
+				}
+
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Ticks != nil {
+			ct.Ticks = make([]Tick, len(t.Ticks))
+			for i1 := range t.Ticks {
+
+				ct.Ticks[i1] = t.Ticks[i1]
+			}
+		}
+	}
+
+	if mask[0]&(1<<2) != 0 {
+
+		{
+			var decoded uint8
+			decoded, nOff, err = backend.ReadUint8(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Byte = byte(decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Byte = t.Byte
+	}
+
+	if mask[0]&(1<<3) != 0 {
+
+		{
+			var decoded int32
+			decoded, nOff, err = backend.ReadVarInt32(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Rune = rune(decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Rune = t.Rune
+	}
+
+	if mask[0]&(1<<4) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitMap[blocked.Basic, Tick](lim, length)
+			if err != nil {
+				return 0, err
+			}
+
+			if t.Basics == nil {
+				t.Basics = make(map[blocked.Basic]Tick, backend.PreallocLen(length, len(bs[n:])))
+			} else {
+				clear(t.Basics)
+			}
+
+			for i1 := 0; i1 < int(length); i1++ {
+				var key1 blocked.Basic
+				var val1 Tick
+
+				{
+					var decoded uint16
+					decoded, nOff, err = backend.ReadVarUint16(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					key1 = blocked.Basic(decoded)
+				}
+
+				{
+					var decoded uint32
+					decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					val1 = Tick(decoded)
+				}
+
+				if err != nil {
+					return 0, err
+				}
+
+				t.Basics[key1] = val1
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Basics != nil {
+			ct.Basics = make(map[blocked.Basic]Tick, len(t.Basics))
+			for k1, v1 := range t.Basics {
+				var cv1 Tick
+
+				cv1 = v1
+				ct.Basics[k1] = cv1
+			}
+		}
+	}
+
+	if mask[0]&(1<<5) != 0 {
+
+		{
+			var decoded uint16
+			decoded, nOff, err = backend.ReadVarUint16(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Basic = blocked.Basic(decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Basic = t.Basic
+	}
+
+	return n, err
+}
+
+func (t NamedBasics) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *NamedBasics) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t OnlyBools) EncodeCod(bs []byte) []byte {
+
+	{
+		var bits [1]byte
+		if t.A {
+			bits[0] |= 1 << 0
+		}
+		if t.B {
+			bits[0] |= 1 << 1
+		}
+		if t.C {
+			bits[0] |= 1 << 2
+		}
+		bs = append(bs, bits[:]...)
+	}
+
+	return bs
+}
+
+func (t *OnlyBools) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *OnlyBools) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	{
+		if len(bs[n:]) < 1 {
+			return 0, backend.ErrTruncatedData
+		}
+		bits := bs[n : n+1]
+		t.A = bool(bits[0]&(1<<0) != 0)
+		t.B = bool(bits[0]&(1<<1) != 0)
+		t.C = bool(bits[0]&(1<<2) != 0)
+		n += 1
+	}
+
+	// println("OnlyBools:", n)
+	return n, err
+}
+
+func (t OnlyBools) CodEquals(tt OnlyBools) bool {
+
+	if t.A != tt.A {
+		return false
+	}
+
+	if t.B != tt.B {
+		return false
+	}
+
+	if t.C != tt.C {
+		return false
+	}
+
+	return true
+}
+
+func (t OnlyBools) CodClone() OnlyBools {
+	var ct OnlyBools
+
+	ct.A = t.A
+	ct.B = t.B
+	ct.C = t.C
+	return ct
+}
+
+func (t OnlyBools) CodSize() int {
+	n := 0
+
+	n += 1
+	return n
+}
+
+func (t OnlyBools) EncodeCodDelta(bs []byte, tt OnlyBools) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 1)...)
+
+	if !func() bool {
+
+		if t.A != tt.A {
+			return false
+		}
+
+		if t.B != tt.B {
+			return false
+		}
+
+		if t.C != tt.C {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
+
+		{
+			var bits [1]byte
+			if t.A {
+				bits[0] |= 1 << 0
+			}
+			if t.B {
+				bits[0] |= 1 << 1
+			}
+			if t.C {
+				bits[0] |= 1 << 2
+			}
+			bs = append(bs, bits[:]...)
+		}
+
+	}
+
+	return bs
+}
+
+func (t *OnlyBools) DecodeCodDelta(bs []byte, tt OnlyBools) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 1 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:1]
+	n += 1
+
+	if mask[0]&(1<<0) != 0 {
+
+		{
+			if len(bs[n:]) < 1 {
+				return 0, backend.ErrTruncatedData
+			}
+			bits := bs[n : n+1]
+			t.A = bool(bits[0]&(1<<0) != 0)
+			t.B = bool(bits[0]&(1<<1) != 0)
+			t.C = bool(bits[0]&(1<<2) != 0)
+			n += 1
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.A = t.A
+		ct.B = t.B
+		ct.C = t.C
+	}
+
+	return n, err
+}
+
+func (t OnlyBools) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *OnlyBools) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t Option[T, PT]) EncodeCod(bs []byte) []byte {
+
+	bs = t.Val.EncodeCod(bs)
+	bs = backend.WriteBool(bs, (t.Ok))
+
+	return bs
+}
+
+func (t *Option[T, PT]) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *Option[T, PT]) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	nOff, err = PT(&t.Val).DecodeCodWithLimits(bs[n:], lim)
+	if err != nil {
+		return 0, err
+	}
+	n += nOff
+
+	{
+		var decoded bool
+		decoded, nOff, err = backend.ReadBool(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		t.Ok = (decoded)
+	}
+
+	// println("Option[T, PT]:", n)
+	return n, err
+}
+
+func (t Option[T, PT]) CodEquals(tt Option[T, PT]) bool {
+
+	if !t.Val.CodEquals(tt.Val) {
+		return false
+	}
+
+	if t.Ok != tt.Ok {
+		return false
+	}
+
+	return true
+}
+
+func (t Option[T, PT]) CodClone() Option[T, PT] {
+	var ct Option[T, PT]
+
+	ct.Val = t.Val.CodClone()
+	ct.Ok = t.Ok
+	return ct
+}
+
+func (t Option[T, PT]) CodSize() int {
+	n := 0
+
+	n += t.Val.CodSize()
+	n += backend.SizeBool((t.Ok))
+	return n
+}
+
+func (t Option[T, PT]) EncodeCodDelta(bs []byte, tt Option[T, PT]) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 1)...)
+
+	if !func() bool {
+
+		if !t.Val.CodEquals(tt.Val) {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
+
+		bs = t.Val.EncodeCod(bs)
+	}
+
+	if !func() bool {
+
+		if t.Ok != tt.Ok {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 1
+
+		bs = backend.WriteBool(bs, (t.Ok))
+
+	}
+
+	return bs
+}
+
+func (t *Option[T, PT]) DecodeCodDelta(bs []byte, tt Option[T, PT]) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 1 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:1]
+	n += 1
+
+	if mask[0]&(1<<0) != 0 {
+
+		nOff, err = PT(&t.Val).DecodeCodWithLimits(bs[n:], lim)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Val = t.Val.CodClone()
+	}
+
+	if mask[0]&(1<<1) != 0 {
+
+		{
+			var decoded bool
+			decoded, nOff, err = backend.ReadBool(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Ok = (decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Ok = t.Ok
+	}
+
+	return n, err
+}
+
+func (t Option[T, PT]) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *Option[T, PT]) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t Pair[K, PK, V, PV]) EncodeCod(bs []byte) []byte {
+
+	bs = t.Key.EncodeCod(bs)
+	bs = t.Val.EncodeCod(bs)
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Vals)))
+		for i1 := range t.Vals {
+
+			bs = t.Vals[i1].EncodeCod(bs)
+		}
+	}
+	bs = t.Next.EncodeCod(bs)
+	return bs
+}
+
+func (t *Pair[K, PK, V, PV]) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *Pair[K, PK, V, PV]) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	nOff, err = PK(&t.Key).DecodeCodWithLimits(bs[n:], lim)
+	if err != nil {
+		return 0, err
+	}
+	n += nOff
+
+	nOff, err = PV(&t.Val).DecodeCodWithLimits(bs[n:], lim)
+	if err != nil {
+		return 0, err
+	}
+	n += nOff
+
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitSlice[V](lim, length)
+		if err != nil {
+			return 0, err
+		}
+		t.Vals = backend.ResetSlice(t.Vals, length, len(bs[n:]))
+
+		for i1 := 0; i1 < int(length); i1++ {
+			t.Vals = backend.ExtendSlice(t.Vals)
+
+			nOff, err = PV(&t.Vals[i1]).DecodeCodWithLimits(bs[n:], lim)
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	nOff, err = t.Next.DecodeCodWithLimits(bs[n:], lim)
+	if err != nil {
+		return 0, err
+	}
+	n += nOff
+
+	// println("Pair[K, PK, V, PV]:", n)
+	return n, err
+}
+
+func (t Pair[K, PK, V, PV]) CodEquals(tt Pair[K, PK, V, PV]) bool {
+
+	if !t.Key.CodEquals(tt.Key) {
+		return false
+	}
+
+	if !t.Val.CodEquals(tt.Val) {
+		return false
+	}
+
+	{
+		if len(t.Vals) != len(tt.Vals) {
+			return false
+		}
+		for i1 := range t.Vals {
+
+			if !t.Vals[i1].CodEquals(tt.Vals[i1]) {
+				return false
+			}
+
+		}
+	}
+	if !t.Next.CodEquals(tt.Next) {
+		return false
+	}
+
+	return true
+}
+
+func (t Pair[K, PK, V, PV]) CodClone() Pair[K, PK, V, PV] {
+	var ct Pair[K, PK, V, PV]
+
+	ct.Key = t.Key.CodClone()
+	ct.Val = t.Val.CodClone()
+	if t.Vals != nil {
+		ct.Vals = make([]V, len(t.Vals))
+		for i1 := range t.Vals {
+
+			ct.Vals[i1] = t.Vals[i1].CodClone()
+		}
+	}
+	ct.Next = t.Next.CodClone()
+	return ct
+}
+
+func (t Pair[K, PK, V, PV]) CodSize() int {
+	n := 0
+
+	n += t.Key.CodSize()
+	n += t.Val.CodSize()
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Vals)))
+		for i1 := range t.Vals {
+
+			n += t.Vals[i1].CodSize()
+		}
+	}
+	n += t.Next.CodSize()
+	return n
+}
+
+func (t Pair[K, PK, V, PV]) EncodeCodDelta(bs []byte, tt Pair[K, PK, V, PV]) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 1)...)
+
+	if !func() bool {
+
+		if !t.Key.CodEquals(tt.Key) {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
+
+		bs = t.Key.EncodeCod(bs)
+	}
+
+	if !func() bool {
+
+		if !t.Val.CodEquals(tt.Val) {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 1
+
+		bs = t.Val.EncodeCod(bs)
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Vals) != len(tt.Vals) {
+				return false
+			}
+			for i1 := range t.Vals {
+
+				if !t.Vals[i1].CodEquals(tt.Vals[i1]) {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 2
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Vals)))
+			for i1 := range t.Vals {
+
+				bs = t.Vals[i1].EncodeCod(bs)
+			}
+		}
+	}
+
+	if !func() bool {
+
+		if !t.Next.CodEquals(tt.Next) {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 3
+
+		bs = t.Next.EncodeCodDelta(bs, tt.Next)
+	}
+
+	return bs
+}
+
+func (t *Pair[K, PK, V, PV]) DecodeCodDelta(bs []byte, tt Pair[K, PK, V, PV]) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 1 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:1]
+	n += 1
+
+	if mask[0]&(1<<0) != 0 {
+
+		nOff, err = PK(&t.Key).DecodeCodWithLimits(bs[n:], lim)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Key = t.Key.CodClone()
+	}
+
+	if mask[0]&(1<<1) != 0 {
+
+		nOff, err = PV(&t.Val).DecodeCodWithLimits(bs[n:], lim)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Val = t.Val.CodClone()
+	}
+
+	if mask[0]&(1<<2) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[V](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			t.Vals = backend.ResetSlice(t.Vals, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				t.Vals = backend.ExtendSlice(t.Vals)
+
+				nOff, err = PV(&t.Vals[i1]).DecodeCodWithLimits(bs[n:], lim)
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Vals != nil {
+			ct.Vals = make([]V, len(t.Vals))
+			for i1 := range t.Vals {
+
+				ct.Vals[i1] = t.Vals[i1].CodClone()
+			}
+		}
+	}
+
+	if mask[0]&(1<<3) != 0 {
+
+		nOff, err = t.Next.DecodeCodDelta(bs[n:], tt.Next)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Next = t.Next.CodClone()
+	}
+
+	return n, err
+}
+
+func (t Pair[K, PK, V, PV]) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *Pair[K, PK, V, PV]) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t Person) EncodeCod(bs []byte) []byte {
+
+	bs = backend.WriteString(bs, (t.Name))
+
+	bs = backend.WriteUint8(bs, (t.Age))
+
+	bs = t.Id.EncodeCod(bs)
+	for i1 := range t.Array {
+
+		bs = backend.WriteVarUint16(bs, (t.Array[i1]))
+
+	}
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Slice)))
+		for i1 := range t.Slice {
+
+			bs = backend.WriteVarUint32(bs, (t.Slice[i1]))
+
+		}
+	}
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.DoubleSlice)))
+		for i1 := range t.DoubleSlice {
+
+			{
+				bs = backend.WriteVarUint64(bs, uint64(len(t.DoubleSlice[i1])))
+				for i2 := range t.DoubleSlice[i1] {
+
+					bs = backend.WriteUint8(bs, (t.DoubleSlice[i1][i2]))
+
+				}
+			}
+		}
+	}
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Map)))
+
+		for k1, v1 := range t.Map {
+
+			bs = backend.WriteString(bs, (k1))
+
+			{
+				bs = backend.WriteVarUint64(bs, uint64(len(v1)))
+				for i2 := range v1 {
+
+					bs = backend.WriteVarUint64(bs, (v1[i2]))
+
+				}
+			}
+		}
+
+	}
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.MultiMap)))
+
+		for k1, v1 := range t.MultiMap {
+
+			bs = backend.WriteString(bs, (k1))
+
+			{
+				bs = backend.WriteVarUint64(bs, uint64(len(v1)))
+
+				for k2, v2 := range v1 {
+
+					bs = backend.WriteVarUint32(bs, (k2))
+
+					{
+						bs = backend.WriteVarUint64(bs, uint64(len(v2)))
+						for i3 := range v2 {
+
+							bs = backend.WriteUint8(bs, (v2[i3]))
+
+						}
+					}
+				}
+
+			}
+		}
+
+	}
+	bs = t.MyUnion.EncodeCod(bs)
+	{
+		if t.Pointer == nil {
+			// Zero tag indicates nil
+			bs = backend.WriteUint8(bs, 0)
+		} else {
+			bs = backend.WriteUint8(bs, 1)
+			value1 := *t.Pointer
+
+			bs = value1.EncodeCod(bs)
+		}
+	}
+	return bs
+}
+
+func (t *Person) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *Person) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	{
+		var decoded string
+		decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		t.Name = (decoded)
+	}
+
+	{
+		var decoded uint8
+		decoded, nOff, err = backend.ReadUint8(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		t.Age = (decoded)
+	}
+
+	nOff, err = t.Id.DecodeCodWithLimits(bs[n:], lim)
+	if err != nil {
+		return 0, err
+	}
+	n += nOff
+
+	for i1 := range t.Array {
+
+		{
+			var decoded uint16
+			decoded, nOff, err = backend.ReadVarUint16(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Array[i1] = (decoded)
+		}
+
+		if err != nil {
+			return 0, err
+		}
+	}
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitSlice[uint32](lim, length)
+		if err != nil {
+			return 0, err
+		}
+		t.Slice = backend.ResetSlice(t.Slice, length, len(bs[n:]))
+
+		for i1 := 0; i1 < int(length); i1++ {
+			t.Slice = backend.ExtendSlice(t.Slice)
+
+			{
+				var decoded uint32
+				decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				t.Slice[i1] = (decoded)
+			}
+
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitSlice[[]uint8](lim, length)
+		if err != nil {
+			return 0, err
+		}
+		t.DoubleSlice = backend.ResetSlice(t.DoubleSlice, length, len(bs[n:]))
+
+		for i1 := 0; i1 < int(length); i1++ {
+			t.DoubleSlice = backend.ExtendSlice(t.DoubleSlice)
+
+			{
+				var length uint64
+				length, nOff, err = backend.ReadVarUint64(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				err = backend.LimitSlice[uint8](lim, length)
+				if err != nil {
+					return 0, err
+				}
+				t.DoubleSlice[i1] = backend.ResetSlice(t.DoubleSlice[i1], length, len(bs[n:]))
+
+				for i2 := 0; i2 < int(length); i2++ {
+					t.DoubleSlice[i1] = backend.ExtendSlice(t.DoubleSlice[i1])
+
+					{
+						var decoded uint8
+						decoded, nOff, err = backend.ReadUint8(bs[n:])
+						if err != nil {
+							return 0, err
+						}
+						n += nOff
+						t.DoubleSlice[i1][i2] = (decoded)
+					}
+
+					if err != nil {
+						return 0, err
+					}
+				}
+			}
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitMap[string, []uint64](lim, length)
+		if err != nil {
+			return 0, err
+		}
+
+		if t.Map == nil {
+			t.Map = make(map[string][]uint64, backend.PreallocLen(length, len(bs[n:])))
+		} else {
+			clear(t.Map)
+		}
+
+		for i1 := 0; i1 < int(length); i1++ {
+			var key1 string
+			var val1 []uint64
+
+			{
+				var decoded string
+				decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				key1 = (decoded)
+			}
+
+			{
+				var length uint64
+				length, nOff, err = backend.ReadVarUint64(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				err = backend.LimitSlice[uint64](lim, length)
+				if err != nil {
+					return 0, err
+				}
+				val1 = backend.ResetSlice(val1, length, len(bs[n:]))
+
+				for i2 := 0; i2 < int(length); i2++ {
+					val1 = backend.ExtendSlice(val1)
+
+					{
+						var decoded uint64
+						decoded, nOff, err = backend.ReadVarUint64(bs[n:])
+						if err != nil {
+							return 0, err
+						}
+						n += nOff
+						val1[i2] = (decoded)
+					}
+
+					if err != nil {
+						return 0, err
+					}
+				}
+			}
+			if err != nil {
+				return 0, err
+			}
+
+			t.Map[key1] = val1
+		}
+	}
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitMap[string, map[uint32][]uint8](lim, length)
+		if err != nil {
+			return 0, err
+		}
+
+		if t.MultiMap == nil {
+			t.MultiMap = make(map[string]map[uint32][]uint8, backend.PreallocLen(length, len(bs[n:])))
+		} else {
+			clear(t.MultiMap)
+		}
+
+		for i1 := 0; i1 < int(length); i1++ {
+			var key1 string
+			var val1 map[uint32][]uint8
+
+			{
+				var decoded string
+				decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				key1 = (decoded)
+			}
+
+			{
+				var length uint64
+				length, nOff, err = backend.ReadVarUint64(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				err = backend.LimitMap[uint32, []uint8](lim, length)
+				if err != nil {
+					return 0, err
+				}
+
+				if val1 == nil {
+					val1 = make(map[uint32][]uint8, backend.PreallocLen(length, len(bs[n:])))
+				} else {
+					clear(val1)
+				}
+
+				for i2 := 0; i2 < int(length); i2++ {
+					var key2 uint32
+					var val2 []uint8
+
+					{
+						var decoded uint32
+						decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+						if err != nil {
+							return 0, err
+						}
+						n += nOff
+						key2 = (decoded)
+					}
+
+					{
+						var length uint64
+						length, nOff, err = backend.ReadVarUint64(bs[n:])
+						if err != nil {
+							return 0, err
+						}
+						n += nOff
+						err = backend.LimitSlice[uint8](lim, length)
+						if err != nil {
+							return 0, err
+						}
+						val2 = backend.ResetSlice(val2, length, len(bs[n:]))
+
+						for i3 := 0; i3 < int(length); i3++ {
+							val2 = backend.ExtendSlice(val2)
+
+							{
+								var decoded uint8
+								decoded, nOff, err = backend.ReadUint8(bs[n:])
+								if err != nil {
+									return 0, err
+								}
+								n += nOff
+								val2[i3] = (decoded)
+							}
+
+							if err != nil {
+								return 0, err
+							}
+						}
+					}
+					if err != nil {
+						return 0, err
+					}
+
+					val1[key2] = val2
+				}
+			}
+			if err != nil {
+				return 0, err
+			}
+
+			t.MultiMap[key1] = val1
+		}
+	}
+	nOff, err = t.MyUnion.DecodeCodWithLimits(bs[n:], lim)
+	if err != nil {
+		return 0, err
+	}
+	n += nOff
+
+	{
+		var tagVal uint8
+		tagVal, nOff, err = backend.ReadUint8(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+		if tagVal == 0 {
+			// Zero tag indicates nil
+			t.Pointer = nil
+		} else {
+			var value1 BlockedStruct
+
+			nOff, err = value1.DecodeCodWithLimits(bs[n:], lim)
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+
+			t.Pointer = &value1
+		}
+	}
+
+	// println("Person:", n)
+	return n, err
+}
+
+func (t Person) CodEquals(tt Person) bool {
+
+	if t.Name != tt.Name {
+		return false
+	}
+
+	if t.Age != tt.Age {
+		return false
+	}
+
+	if !t.Id.CodEquals(tt.Id) {
+		return false
+	}
+
+	for i1 := range t.Array {
+
+		if t.Array[i1] != tt.Array[i1] {
+			return false
+		}
+
+	}
+	{
+		if len(t.Slice) != len(tt.Slice) {
+			return false
+		}
+		for i1 := range t.Slice {
+
+			if t.Slice[i1] != tt.Slice[i1] {
+				return false
+			}
+
+		}
+	}
+	{
+		if len(t.DoubleSlice) != len(tt.DoubleSlice) {
+			return false
+		}
+		for i1 := range t.DoubleSlice {
+
+			{
+				if len(t.DoubleSlice[i1]) != len(tt.DoubleSlice[i1]) {
+					return false
+				}
+				for i2 := range t.DoubleSlice[i1] {
+
+					if t.DoubleSlice[i1][i2] != tt.DoubleSlice[i1][i2] {
+						return false
+					}
+
+				}
+			}
+		}
+	}
+	{
+		if len(t.Map) != len(tt.Map) {
+			return false
+		}
+		for k1, v1 := range t.Map {
+			tv1, ok := tt.Map[k1]
+			if !ok {
+				return false
+			}
+
+			{
+				if len(v1) != len(tv1) {
+					return false
+				}
+				for i2 := range v1 {
+
+					if v1[i2] != tv1[i2] {
+						return false
+					}
+
+				}
+			}
+		}
+	}
+	{
+		if len(t.MultiMap) != len(tt.MultiMap) {
+			return false
+		}
+		for k1, v1 := range t.MultiMap {
+			tv1, ok := tt.MultiMap[k1]
+			if !ok {
+				return false
+			}
+
+			{
+				if len(v1) != len(tv1) {
+					return false
+				}
+				for k2, v2 := range v1 {
+					tv2, ok := tv1[k2]
+					if !ok {
+						return false
+					}
+
+					{
+						if len(v2) != len(tv2) {
+							return false
+						}
+						for i3 := range v2 {
+
+							if v2[i3] != tv2[i3] {
+								return false
+							}
+
+						}
+					}
+				}
+			}
+		}
+	}
+	if !t.MyUnion.CodEquals(tt.MyUnion) {
+		return false
+	}
+
+	{
+		tNil := (t.Pointer == nil)
+		ttNil := (tt.Pointer == nil)
+		if tNil != ttNil {
+			return false
+		}
+		if !tNil && !ttNil {
+			value1 := *t.Pointer
+			tvalue1 := *tt.Pointer
+
+			if !value1.CodEquals(tvalue1) {
+				return false
+			}
+
+		}
+	}
+	return true
+}
+
+func (t Person) CodClone() Person {
+	var ct Person
+
+	ct.Name = t.Name
+	ct.Age = t.Age
+	ct.Id = t.Id.CodClone()
+	for i1 := range t.Array {
+
+		ct.Array[i1] = t.Array[i1]
+	}
+	if t.Slice != nil {
+		ct.Slice = make([]uint32, len(t.Slice))
+		for i1 := range t.Slice {
+
+			ct.Slice[i1] = t.Slice[i1]
+		}
+	}
+	if t.DoubleSlice != nil {
+		ct.DoubleSlice = make([][]uint8, len(t.DoubleSlice))
+		for i1 := range t.DoubleSlice {
+
+			if t.DoubleSlice[i1] != nil {
+				ct.DoubleSlice[i1] = make([]uint8, len(t.DoubleSlice[i1]))
+				for i2 := range t.DoubleSlice[i1] {
+
+					ct.DoubleSlice[i1][i2] = t.DoubleSlice[i1][i2]
+				}
+			}
+		}
+	}
+	if t.Map != nil {
+		ct.Map = make(map[string][]uint64, len(t.Map))
+		for k1, v1 := range t.Map {
+			var cv1 []uint64
+
+			if v1 != nil {
+				cv1 = make([]uint64, len(v1))
+				for i2 := range v1 {
+
+					cv1[i2] = v1[i2]
+				}
+			}
+			ct.Map[k1] = cv1
+		}
+	}
+	if t.MultiMap != nil {
+		ct.MultiMap = make(map[string]map[uint32][]uint8, len(t.MultiMap))
+		for k1, v1 := range t.MultiMap {
+			var cv1 map[uint32][]uint8
+
+			if v1 != nil {
+				cv1 = make(map[uint32][]uint8, len(v1))
+				for k2, v2 := range v1 {
+					var cv2 []uint8
+
+					if v2 != nil {
+						cv2 = make([]uint8, len(v2))
+						for i3 := range v2 {
+
+							cv2[i3] = v2[i3]
+						}
+					}
+					cv1[k2] = cv2
+				}
+			}
+			ct.MultiMap[k1] = cv1
+		}
+	}
+	ct.MyUnion = t.MyUnion.CodClone()
+	if t.Pointer != nil {
+		value1 := *t.Pointer
+		var cvalue1 BlockedStruct
+
+		cvalue1 = value1.CodClone()
+		ct.Pointer = &cvalue1
+	}
+	return ct
+}
+
+func (t Person) CodSize() int {
+	n := 0
+
+	n += backend.SizeString((t.Name))
+	n += backend.SizeUint8((t.Age))
+	n += t.Id.CodSize()
+	for i1 := range t.Array {
+
+		n += backend.SizeVarUint16((t.Array[i1]))
+	}
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Slice)))
+		for i1 := range t.Slice {
+
+			n += backend.SizeVarUint32((t.Slice[i1]))
+		}
+	}
+	{
+		n += backend.SizeVarUint64(uint64(len(t.DoubleSlice)))
+		for i1 := range t.DoubleSlice {
+
+			{
+				n += backend.SizeVarUint64(uint64(len(t.DoubleSlice[i1])))
+				for i2 := range t.DoubleSlice[i1] {
+
+					n += backend.SizeUint8((t.DoubleSlice[i1][i2]))
+				}
+			}
+		}
+	}
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Map)))
+		for k1, v1 := range t.Map {
+
+			n += backend.SizeString((k1))
+			{
+				n += backend.SizeVarUint64(uint64(len(v1)))
+				for i2 := range v1 {
+
+					n += backend.SizeVarUint64((v1[i2]))
+				}
+			}
+		}
+	}
+	{
+		n += backend.SizeVarUint64(uint64(len(t.MultiMap)))
+		for k1, v1 := range t.MultiMap {
+
+			n += backend.SizeString((k1))
+			{
+				n += backend.SizeVarUint64(uint64(len(v1)))
+				for k2, v2 := range v1 {
+
+					n += backend.SizeVarUint32((k2))
+					{
+						n += backend.SizeVarUint64(uint64(len(v2)))
+						for i3 := range v2 {
+
+							n += backend.SizeUint8((v2[i3]))
+						}
+					}
+				}
+			}
+		}
+	}
+	n += t.MyUnion.CodSize()
+	{
+		n += backend.SizeUint8(0) // The nil tag
+		if t.Pointer != nil {
+			value1 := *t.Pointer
+
+			n += value1.CodSize()
+		}
+	}
+	return n
+}
+
+func (t Person) EncodeCodDelta(bs []byte, tt Person) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 2)...)
+
+	if !func() bool {
+
+		if t.Name != tt.Name {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
+
+		bs = backend.WriteString(bs, (t.Name))
+
+	}
+
+	if !func() bool {
+
+		if t.Age != tt.Age {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 1
+
+		bs = backend.WriteUint8(bs, (t.Age))
+
+	}
+
+	if !func() bool {
+
+		if !t.Id.CodEquals(tt.Id) {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 2
+
+		bs = t.Id.EncodeCodDelta(bs, tt.Id)
+	}
+
+	if !func() bool {
+
+		for i1 := range t.Array {
+
+			if t.Array[i1] != tt.Array[i1] {
+				return false
+			}
+
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 3
+
+		for i1 := range t.Array {
+
+			bs = backend.WriteVarUint16(bs, (t.Array[i1]))
+
+		}
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Slice) != len(tt.Slice) {
+				return false
+			}
+			for i1 := range t.Slice {
+
+				if t.Slice[i1] != tt.Slice[i1] {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 4
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Slice)))
+			for i1 := range t.Slice {
+
+				bs = backend.WriteVarUint32(bs, (t.Slice[i1]))
+
+			}
+		}
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.DoubleSlice) != len(tt.DoubleSlice) {
+				return false
+			}
+			for i1 := range t.DoubleSlice {
+
+				{
+					if len(t.DoubleSlice[i1]) != len(tt.DoubleSlice[i1]) {
+						return false
+					}
+					for i2 := range t.DoubleSlice[i1] {
+
+						if t.DoubleSlice[i1][i2] != tt.DoubleSlice[i1][i2] {
+							return false
+						}
+
+					}
+				}
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 5
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.DoubleSlice)))
+			for i1 := range t.DoubleSlice {
+
+				{
+					bs = backend.WriteVarUint64(bs, uint64(len(t.DoubleSlice[i1])))
+					for i2 := range t.DoubleSlice[i1] {
+
+						bs = backend.WriteUint8(bs, (t.DoubleSlice[i1][i2]))
+
+					}
+				}
+			}
+		}
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Map) != len(tt.Map) {
+				return false
+			}
+			for k1, v1 := range t.Map {
+				tv1, ok := tt.Map[k1]
+				if !ok {
+					return false
+				}
+
+				{
+					if len(v1) != len(tv1) {
+						return false
+					}
+					for i2 := range v1 {
+
+						if v1[i2] != tv1[i2] {
+							return false
+						}
+
+					}
+				}
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 6
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Map)))
+
+			for k1, v1 := range t.Map {
+
+				bs = backend.WriteString(bs, (k1))
+
+				{
+					bs = backend.WriteVarUint64(bs, uint64(len(v1)))
+					for i2 := range v1 {
+
+						bs = backend.WriteVarUint64(bs, (v1[i2]))
+
+					}
+				}
+			}
+
+		}
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.MultiMap) != len(tt.MultiMap) {
+				return false
+			}
+			for k1, v1 := range t.MultiMap {
+				tv1, ok := tt.MultiMap[k1]
+				if !ok {
+					return false
+				}
+
+				{
+					if len(v1) != len(tv1) {
+						return false
+					}
+					for k2, v2 := range v1 {
+						tv2, ok := tv1[k2]
+						if !ok {
+							return false
+						}
+
+						{
+							if len(v2) != len(tv2) {
+								return false
+							}
+							for i3 := range v2 {
+
+								if v2[i3] != tv2[i3] {
+									return false
+								}
+
+							}
+						}
+					}
+				}
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 7
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.MultiMap)))
+
+			for k1, v1 := range t.MultiMap {
+
+				bs = backend.WriteString(bs, (k1))
+
+				{
+					bs = backend.WriteVarUint64(bs, uint64(len(v1)))
+
+					for k2, v2 := range v1 {
+
+						bs = backend.WriteVarUint32(bs, (k2))
+
+						{
+							bs = backend.WriteVarUint64(bs, uint64(len(v2)))
+							for i3 := range v2 {
+
+								bs = backend.WriteUint8(bs, (v2[i3]))
+
+							}
+						}
+					}
+
+				}
+			}
+
+		}
+	}
+
+	if !func() bool {
+
+		if !t.MyUnion.CodEquals(tt.MyUnion) {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+1] |= 1 << 0
+
+		bs = t.MyUnion.EncodeCodDelta(bs, tt.MyUnion)
+	}
+
+	if !func() bool {
+
+		{
+			tNil := (t.Pointer == nil)
+			ttNil := (tt.Pointer == nil)
+			if tNil != ttNil {
+				return false
+			}
+			if !tNil && !ttNil {
+				value1 := *t.Pointer
+				tvalue1 := *tt.Pointer
+
+				if !value1.CodEquals(tvalue1) {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+1] |= 1 << 1
+
+		{
+			if t.Pointer == nil {
+				// Zero tag indicates nil
+				bs = backend.WriteUint8(bs, 0)
+			} else {
+				bs = backend.WriteUint8(bs, 1)
+				value1 := *t.Pointer
+
+				bs = value1.EncodeCod(bs)
+			}
+		}
+	}
+
+	return bs
+}
+
+func (t *Person) DecodeCodDelta(bs []byte, tt Person) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 2 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:2]
+	n += 2
+
+	if mask[0]&(1<<0) != 0 {
+
+		{
+			var decoded string
+			decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Name = (decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Name = t.Name
+	}
+
+	if mask[0]&(1<<1) != 0 {
+
+		{
+			var decoded uint8
+			decoded, nOff, err = backend.ReadUint8(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Age = (decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Age = t.Age
+	}
+
+	if mask[0]&(1<<2) != 0 {
+
+		nOff, err = t.Id.DecodeCodDelta(bs[n:], tt.Id)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Id = t.Id.CodClone()
+	}
+
+	if mask[0]&(1<<3) != 0 {
+
+		for i1 := range t.Array {
+
+			{
+				var decoded uint16
+				decoded, nOff, err = backend.ReadVarUint16(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				t.Array[i1] = (decoded)
+			}
+
+			if err != nil {
+				return 0, err
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		for i1 := range t.Array {
+
+			ct.Array[i1] = t.Array[i1]
+		}
+	}
+
+	if mask[0]&(1<<4) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[uint32](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			t.Slice = backend.ResetSlice(t.Slice, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				t.Slice = backend.ExtendSlice(t.Slice)
+
+				{
+					var decoded uint32
+					decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					t.Slice[i1] = (decoded)
+				}
+
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Slice != nil {
+			ct.Slice = make([]uint32, len(t.Slice))
+			for i1 := range t.Slice {
+
+				ct.Slice[i1] = t.Slice[i1]
+			}
+		}
+	}
+
+	if mask[0]&(1<<5) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[[]uint8](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			t.DoubleSlice = backend.ResetSlice(t.DoubleSlice, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				t.DoubleSlice = backend.ExtendSlice(t.DoubleSlice)
+
+				{
+					var length uint64
+					length, nOff, err = backend.ReadVarUint64(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					err = backend.LimitSlice[uint8](lim, length)
+					if err != nil {
+						return 0, err
+					}
+					t.DoubleSlice[i1] = backend.ResetSlice(t.DoubleSlice[i1], length, len(bs[n:]))
+
+					for i2 := 0; i2 < int(length); i2++ {
+						t.DoubleSlice[i1] = backend.ExtendSlice(t.DoubleSlice[i1])
+
+						{
+							var decoded uint8
+							decoded, nOff, err = backend.ReadUint8(bs[n:])
+							if err != nil {
+								return 0, err
+							}
+							n += nOff
+							t.DoubleSlice[i1][i2] = (decoded)
+						}
+
+						if err != nil {
+							return 0, err
+						}
+					}
+				}
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.DoubleSlice != nil {
+			ct.DoubleSlice = make([][]uint8, len(t.DoubleSlice))
+			for i1 := range t.DoubleSlice {
+
+				if t.DoubleSlice[i1] != nil {
+					ct.DoubleSlice[i1] = make([]uint8, len(t.DoubleSlice[i1]))
+					for i2 := range t.DoubleSlice[i1] {
+
+						ct.DoubleSlice[i1][i2] = t.DoubleSlice[i1][i2]
+					}
+				}
+			}
+		}
+	}
+
+	if mask[0]&(1<<6) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitMap[string, []uint64](lim, length)
+			if err != nil {
+				return 0, err
+			}
+
+			if t.Map == nil {
+				t.Map = make(map[string][]uint64, backend.PreallocLen(length, len(bs[n:])))
+			} else {
+				clear(t.Map)
+			}
+
+			for i1 := 0; i1 < int(length); i1++ {
+				var key1 string
+				var val1 []uint64
+
+				{
+					var decoded string
+					decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					key1 = (decoded)
+				}
+
+				{
+					var length uint64
+					length, nOff, err = backend.ReadVarUint64(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					err = backend.LimitSlice[uint64](lim, length)
+					if err != nil {
+						return 0, err
+					}
+					val1 = backend.ResetSlice(val1, length, len(bs[n:]))
+
+					for i2 := 0; i2 < int(length); i2++ {
+						val1 = backend.ExtendSlice(val1)
+
+						{
+							var decoded uint64
+							decoded, nOff, err = backend.ReadVarUint64(bs[n:])
+							if err != nil {
+								return 0, err
+							}
+							n += nOff
+							val1[i2] = (decoded)
+						}
+
+						if err != nil {
+							return 0, err
+						}
+					}
+				}
+				if err != nil {
+					return 0, err
+				}
+
+				t.Map[key1] = val1
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Map != nil {
+			ct.Map = make(map[string][]uint64, len(t.Map))
+			for k1, v1 := range t.Map {
+				var cv1 []uint64
+
+				if v1 != nil {
+					cv1 = make([]uint64, len(v1))
+					for i2 := range v1 {
+
+						cv1[i2] = v1[i2]
+					}
+				}
+				ct.Map[k1] = cv1
+			}
+		}
+	}
+
+	if mask[0]&(1<<7) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitMap[string, map[uint32][]uint8](lim, length)
+			if err != nil {
+				return 0, err
+			}
+
+			if t.MultiMap == nil {
+				t.MultiMap = make(map[string]map[uint32][]uint8, backend.PreallocLen(length, len(bs[n:])))
+			} else {
+				clear(t.MultiMap)
+			}
+
+			for i1 := 0; i1 < int(length); i1++ {
+				var key1 string
+				var val1 map[uint32][]uint8
+
+				{
+					var decoded string
+					decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					key1 = (decoded)
+				}
+
+				{
+					var length uint64
+					length, nOff, err = backend.ReadVarUint64(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					err = backend.LimitMap[uint32, []uint8](lim, length)
+					if err != nil {
+						return 0, err
+					}
+
+					if val1 == nil {
+						val1 = make(map[uint32][]uint8, backend.PreallocLen(length, len(bs[n:])))
+					} else {
+						clear(val1)
+					}
+
+					for i2 := 0; i2 < int(length); i2++ {
+						var key2 uint32
+						var val2 []uint8
+
+						{
+							var decoded uint32
+							decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+							if err != nil {
+								return 0, err
+							}
+							n += nOff
+							key2 = (decoded)
+						}
+
+						{
+							var length uint64
+							length, nOff, err = backend.ReadVarUint64(bs[n:])
+							if err != nil {
+								return 0, err
+							}
+							n += nOff
+							err = backend.LimitSlice[uint8](lim, length)
+							if err != nil {
+								return 0, err
+							}
+							val2 = backend.ResetSlice(val2, length, len(bs[n:]))
+
+							for i3 := 0; i3 < int(length); i3++ {
+								val2 = backend.ExtendSlice(val2)
+
+								{
+									var decoded uint8
+									decoded, nOff, err = backend.ReadUint8(bs[n:])
+									if err != nil {
+										return 0, err
+									}
+									n += nOff
+									val2[i3] = (decoded)
+								}
+
+								if err != nil {
+									return 0, err
+								}
+							}
+						}
+						if err != nil {
+							return 0, err
+						}
+
+						val1[key2] = val2
+					}
+				}
+				if err != nil {
+					return 0, err
+				}
+
+				t.MultiMap[key1] = val1
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.MultiMap != nil {
+			ct.MultiMap = make(map[string]map[uint32][]uint8, len(t.MultiMap))
+			for k1, v1 := range t.MultiMap {
+				var cv1 map[uint32][]uint8
+
+				if v1 != nil {
+					cv1 = make(map[uint32][]uint8, len(v1))
+					for k2, v2 := range v1 {
+						var cv2 []uint8
+
+						if v2 != nil {
+							cv2 = make([]uint8, len(v2))
+							for i3 := range v2 {
+
+								cv2[i3] = v2[i3]
+							}
+						}
+						cv1[k2] = cv2
+					}
+				}
+				ct.MultiMap[k1] = cv1
+			}
+		}
+	}
+
+	if mask[1]&(1<<0) != 0 {
+
+		nOff, err = t.MyUnion.DecodeCodDelta(bs[n:], tt.MyUnion)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.MyUnion = t.MyUnion.CodClone()
+	}
+
+	if mask[1]&(1<<1) != 0 {
+
+		{
+			var tagVal uint8
+			tagVal, nOff, err = backend.ReadUint8(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+
+			if tagVal == 0 {
+				// Zero tag indicates nil
+				t.Pointer = nil
+			} else {
+				var value1 BlockedStruct
+
+				nOff, err = value1.DecodeCodWithLimits(bs[n:], lim)
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+
+				t.Pointer = &value1
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Pointer != nil {
+			value1 := *t.Pointer
+			var cvalue1 BlockedStruct
+
+			cvalue1 = value1.CodClone()
+			ct.Pointer = &cvalue1
+		}
+	}
+
+	return n, err
+}
+
+func (t Person) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *Person) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t PinnedUnion) EncodeCod(bs []byte) []byte {
+
+	tag := t.Tag()
+	bs = backend.WriteUint8(bs, tag)
+	if tag == 0 {
+		// Zero tag indicates nil, so write nothing else
+		return bs
+	}
+
+	rawVal := t.Get()
+	bs = rawVal.EncodeCod(bs)
+
+	return bs
+}
+
+func (t *PinnedUnion) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *PinnedUnion) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	var tagVal uint8
+
+	tagVal, nOff, err = backend.ReadUint8(bs[n:])
+	if err != nil {
+		return 0, err
+	}
+	n += nOff
+
+	switch tagVal {
+	case 0: // Zero tag indicates nil
+		*t = PinnedUnion{}
+		return n, nil
+
+	case 7:
+		var decoded Id
+		nOff, err = decoded.DecodeCodWithLimits(bs[n:], lim)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+		t.SetId(decoded)
+
+	case 2:
+		var decoded SpecialMap
+		nOff, err = decoded.DecodeCodWithLimits(bs[n:], lim)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+		t.SetSpecialMap(decoded)
+
+	case 200:
+		var decoded subpackage.Vec
+		nOff, err = decoded.DecodeCodWithLimits(bs[n:], lim)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+		t.SetVec(decoded)
+
+	default:
+		return 0, backend.ErrUnknownUnionType
+	}
+
+	// println("PinnedUnion:", n)
+	return n, err
+}
+
+func (t PinnedUnion) Tag() uint8 {
+	rawVal := t.Get()
+	if rawVal == nil {
+		// Zero tag indicates nil
+		return 0
+	}
+
+	switch rawVal.(type) {
+
+	case Id:
+		return 7
+
+	case SpecialMap:
+		return 2
+
+	case subpackage.Vec:
+		return 200
+
+	default:
+		panic(fmt.Sprintf("unknown type placed in union: %T", rawVal))
+	}
+}
+
+func (t PinnedUnion) Size() int {
+	return 201
+}
+
+func (t PinnedUnion) CodEquals(tt PinnedUnion) bool {
+	if t.Tag() != tt.Tag() {
+		return false
+	}
+
+	rawVal := t.Get()
+	switch sv := rawVal.(type) {
+	case nil:
+		return true
+
+	case Id:
+		sv2 := tt.Get().(Id)
+		return sv.CodEquals(sv2)
+
+	case SpecialMap:
+		sv2 := tt.Get().(SpecialMap)
+		return sv.CodEquals(sv2)
+
+	case subpackage.Vec:
+		sv2 := tt.Get().(subpackage.Vec)
+		return sv.CodEquals(sv2)
+
+	default:
+		panic(fmt.Sprintf("unknown type placed in union: %T", rawVal))
+	}
+}
+
+func (t PinnedUnion) CodClone() PinnedUnion {
+	var ct PinnedUnion
+
+	rawVal := t.Get()
+	switch sv := rawVal.(type) {
+	case nil:
+
+	case Id:
+		ct.SetId(sv.CodClone())
+
+	case SpecialMap:
+		ct.SetSpecialMap(sv.CodClone())
+
+	case subpackage.Vec:
+		ct.SetVec(sv.CodClone())
+
+	default:
+		panic(fmt.Sprintf("unknown type placed in union: %T", rawVal))
+	}
+	return ct
+}
+
+func (t PinnedUnion) EncodeCodDelta(bs []byte, tt PinnedUnion) []byte {
+	tag := t.Tag()
+	if tag == 0 || tag != tt.Tag() {
+		// The type changed, so write the full value
+		return t.EncodeCod(bs)
+	}
+
+	bs = backend.WriteUint8(bs, tag)
+	switch sv := t.Get().(type) {
+	case Id:
+		bs = sv.EncodeCodDelta(bs, tt.Get().(Id))
+	case SpecialMap:
+		bs = sv.EncodeCodDelta(bs, tt.Get().(SpecialMap))
+	case subpackage.Vec:
+		bs = sv.EncodeCodDelta(bs, tt.Get().(subpackage.Vec))
+	}
+	return bs
+}
+
+func (t *PinnedUnion) DecodeCodDelta(bs []byte, tt PinnedUnion) (int, error) {
+	tagVal, n, err := backend.ReadUint8(bs)
+	if err != nil {
+		return 0, err
+	}
+	if tagVal == 0 || tagVal != tt.Tag() {
+		return t.DecodeCod(bs)
+	}
+
+	var nOff int
+	switch tagVal {
+	case 7:
+		var decoded Id
+		nOff, err = decoded.DecodeCodDelta(bs[n:], tt.Get().(Id))
+		if err != nil {
+			return 0, err
+		}
+		t.SetId(decoded)
+	case 2:
+		var decoded SpecialMap
+		nOff, err = decoded.DecodeCodDelta(bs[n:], tt.Get().(SpecialMap))
+		if err != nil {
+			return 0, err
+		}
+		t.SetSpecialMap(decoded)
+	case 200:
+		var decoded subpackage.Vec
+		nOff, err = decoded.DecodeCodDelta(bs[n:], tt.Get().(subpackage.Vec))
+		if err != nil {
+			return 0, err
+		}
+		t.SetVec(decoded)
+	}
+	return n + nOff, nil
+}
+
+func (t PinnedUnion) CodSize() int {
+	n := backend.SizeUint8(t.Tag())
+
+	rawVal := t.Get()
+	switch sv := rawVal.(type) {
+
+	case Id:
+		n += sv.CodSize()
+
+	case SpecialMap:
+		n += sv.CodSize()
+
+	case subpackage.Vec:
+		n += sv.CodSize()
+
+	}
+	return n
+}
+
+func (t PinnedUnion) Get() cod.EncoderDecoder {
+	codUnion := cod.Union(t)
+	rawVal := codUnion.GetRawValue()
+	return rawVal
+
+	// switch rawVal.(type) {
+	// <no value>
+	// default:
+	//    panic("unknown type placed in union")
+	// }
+}
+
+func (t *PinnedUnion) Set(v cod.EncoderDecoder) {
+	switch v.(type) {
+	case nil, Id, SpecialMap, subpackage.Vec:
+	default:
+		panic(fmt.Sprintf("%T is not a member of union PinnedUnion", v))
+	}
+
+	codUnion := cod.Union(*t)
+	codUnion.PutRawValue(v)
+	*t = PinnedUnion(codUnion)
+}
+
+func NewPinnedUnion(v cod.EncoderDecoder) PinnedUnion {
+	var ret PinnedUnion
+	ret.Set(v)
+	return ret
+}
+
+func NewPinnedUnionFromId(v Id) PinnedUnion {
+	var ret PinnedUnion
+	ret.SetId(v)
+	return ret
+}
+
+func (t PinnedUnion) GetId() (Id, bool) {
+	v, ok := t.Get().(Id)
+	return v, ok
+}
+
+func (t *PinnedUnion) SetId(v Id) {
+	codUnion := cod.Union(*t)
+	codUnion.PutRawValue(v)
+	*t = PinnedUnion(codUnion)
+}
+
+func NewPinnedUnionFromSpecialMap(v SpecialMap) PinnedUnion {
+	var ret PinnedUnion
+	ret.SetSpecialMap(v)
+	return ret
+}
+
+func (t PinnedUnion) GetSpecialMap() (SpecialMap, bool) {
+	v, ok := t.Get().(SpecialMap)
+	return v, ok
+}
+
+func (t *PinnedUnion) SetSpecialMap(v SpecialMap) {
+	codUnion := cod.Union(*t)
+	codUnion.PutRawValue(v)
+	*t = PinnedUnion(codUnion)
+}
+
+func NewPinnedUnionFromVec(v subpackage.Vec) PinnedUnion {
+	var ret PinnedUnion
+	ret.SetVec(v)
+	return ret
+}
+
+func (t PinnedUnion) GetVec() (subpackage.Vec, bool) {
+	v, ok := t.Get().(subpackage.Vec)
+	return v, ok
+}
+
+func (t *PinnedUnion) SetVec(v subpackage.Vec) {
+	codUnion := cod.Union(*t)
+	codUnion.PutRawValue(v)
+	*t = PinnedUnion(codUnion)
+}
+
+func (t PinnedUnion) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *PinnedUnion) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t Pooled) EncodeCod(bs []byte) []byte {
+
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Ids)))
+		for i1 := range t.Ids {
+
+			bs = backend.WriteVarUint32(bs, (t.Ids[i1]))
+
+		}
+	}
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Positions)))
+		for i1 := range t.Positions {
+
+			bs = t.Positions[i1].EncodeCod(bs)
+		}
+	}
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Counts)))
+
+		for k1, v1 := range t.Counts {
+
+			bs = backend.WriteVarUint32(bs, (k1))
+
+			bs = backend.WriteVarUint16(bs, (v1))
+
+		}
+
+	}
+	bs = t.List.EncodeCod(bs)
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Groups)))
+		for i1 := range t.Groups {
+
+			{
+				bs = backend.WriteVarUint64(bs, uint64(len(t.Groups[i1])))
+				for i2 := range t.Groups[i1] {
+
+					bs = backend.WriteVarUint32(bs, (t.Groups[i1][i2]))
+
+				}
+			}
+		}
+	}
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Lists)))
+		for i1 := range t.Lists {
+
+			bs = t.Lists[i1].EncodeCod(bs)
+		}
+	}
+	return bs
+}
+
+func (t *Pooled) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *Pooled) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitSlice[uint32](lim, length)
+		if err != nil {
+			return 0, err
+		}
+		t.Ids = backend.ResetSlice(t.Ids, length, len(bs[n:]))
+
+		for i1 := 0; i1 < int(length); i1++ {
+			t.Ids = backend.ExtendSlice(t.Ids)
+
+			{
+				var decoded uint32
+				decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				t.Ids[i1] = (decoded)
+			}
+
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitSlice[subpackage.Vec](lim, length)
+		if err != nil {
+			return 0, err
+		}
+		t.Positions = backend.ResetSlice(t.Positions, length, len(bs[n:]))
+
+		for i1 := 0; i1 < int(length); i1++ {
+			t.Positions = backend.ExtendSlice(t.Positions)
+
+			nOff, err = t.Positions[i1].DecodeCodWithLimits(bs[n:], lim)
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitMap[uint32, uint16](lim, length)
+		if err != nil {
+			return 0, err
+		}
+
+		if t.Counts == nil {
+			t.Counts = make(map[uint32]uint16, backend.PreallocLen(length, len(bs[n:])))
+		} else {
+			clear(t.Counts)
+		}
+
+		for i1 := 0; i1 < int(length); i1++ {
+			var key1 uint32
+			var val1 uint16
+
+			{
+				var decoded uint32
+				decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				key1 = (decoded)
+			}
+
+			{
+				var decoded uint16
+				decoded, nOff, err = backend.ReadVarUint16(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				val1 = (decoded)
+			}
+
+			if err != nil {
+				return 0, err
+			}
+
+			t.Counts[key1] = val1
+		}
+	}
+	nOff, err = t.List.DecodeCodWithLimits(bs[n:], lim)
+	if err != nil {
+		return 0, err
+	}
+	n += nOff
+
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitSlice[[]uint32](lim, length)
+		if err != nil {
+			return 0, err
+		}
+		t.Groups = backend.ResetSlice(t.Groups, length, len(bs[n:]))
+
+		for i1 := 0; i1 < int(length); i1++ {
+			t.Groups = backend.ExtendSlice(t.Groups)
+
+			{
+				var length uint64
+				length, nOff, err = backend.ReadVarUint64(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				err = backend.LimitSlice[uint32](lim, length)
+				if err != nil {
+					return 0, err
+				}
+				t.Groups[i1] = backend.ResetSlice(t.Groups[i1], length, len(bs[n:]))
+
+				for i2 := 0; i2 < int(length); i2++ {
+					t.Groups[i1] = backend.ExtendSlice(t.Groups[i1])
+
+					{
+						var decoded uint32
+						decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+						if err != nil {
+							return 0, err
+						}
+						n += nOff
+						t.Groups[i1][i2] = (decoded)
+					}
+
+					if err != nil {
+						return 0, err
+					}
+				}
+			}
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitSlice[IdList](lim, length)
+		if err != nil {
+			return 0, err
+		}
+		t.Lists = backend.ResetSlice(t.Lists, length, len(bs[n:]))
+
+		for i1 := 0; i1 < int(length); i1++ {
+			t.Lists = backend.ExtendSlice(t.Lists)
+
+			nOff, err = t.Lists[i1].DecodeCodWithLimits(bs[n:], lim)
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	// println("Pooled:", n)
+	return n, err
+}
+
+func (t Pooled) CodEquals(tt Pooled) bool {
+
+	{
+		if len(t.Ids) != len(tt.Ids) {
+			return false
+		}
+		for i1 := range t.Ids {
+
+			if t.Ids[i1] != tt.Ids[i1] {
+				return false
+			}
+
+		}
+	}
+	{
+		if len(t.Positions) != len(tt.Positions) {
+			return false
+		}
+		for i1 := range t.Positions {
+
+			if !t.Positions[i1].CodEquals(tt.Positions[i1]) {
+				return false
+			}
+
+		}
+	}
+	{
+		if len(t.Counts) != len(tt.Counts) {
+			return false
+		}
+		for k1, v1 := range t.Counts {
+			tv1, ok := tt.Counts[k1]
+			if !ok {
+				return false
+			}
+
+			if v1 != tv1 {
+				return false
+			}
+
+		}
+	}
+	if !t.List.CodEquals(tt.List) {
+		return false
+	}
+
+	{
+		if len(t.Groups) != len(tt.Groups) {
+			return false
+		}
+		for i1 := range t.Groups {
+
+			{
+				if len(t.Groups[i1]) != len(tt.Groups[i1]) {
+					return false
+				}
+				for i2 := range t.Groups[i1] {
+
+					if t.Groups[i1][i2] != tt.Groups[i1][i2] {
+						return false
+					}
+
+				}
+			}
+		}
+	}
+	{
+		if len(t.Lists) != len(tt.Lists) {
+			return false
+		}
+		for i1 := range t.Lists {
+
+			if !t.Lists[i1].CodEquals(tt.Lists[i1]) {
+				return false
+			}
+
+		}
+	}
+	return true
+}
+
+func (t Pooled) CodClone() Pooled {
+	var ct Pooled
+
+	if t.Ids != nil {
+		ct.Ids = make([]uint32, len(t.Ids))
+		for i1 := range t.Ids {
+
+			ct.Ids[i1] = t.Ids[i1]
+		}
+	}
+	if t.Positions != nil {
+		ct.Positions = make([]subpackage.Vec, len(t.Positions))
+		for i1 := range t.Positions {
+
+			ct.Positions[i1] = t.Positions[i1].CodClone()
+		}
+	}
+	if t.Counts != nil {
+		ct.Counts = make(map[uint32]uint16, len(t.Counts))
+		for k1, v1 := range t.Counts {
+			var cv1 uint16
+
+			cv1 = v1
+			ct.Counts[k1] = cv1
+		}
+	}
+	ct.List = t.List.CodClone()
+	if t.Groups != nil {
+		ct.Groups = make([][]uint32, len(t.Groups))
+		for i1 := range t.Groups {
+
+			if t.Groups[i1] != nil {
+				ct.Groups[i1] = make([]uint32, len(t.Groups[i1]))
+				for i2 := range t.Groups[i1] {
+
+					ct.Groups[i1][i2] = t.Groups[i1][i2]
+				}
+			}
+		}
+	}
+	if t.Lists != nil {
+		ct.Lists = make([]IdList, len(t.Lists))
+		for i1 := range t.Lists {
+
+			ct.Lists[i1] = t.Lists[i1].CodClone()
+		}
+	}
+	return ct
+}
+
+func (t Pooled) CodSize() int {
+	n := 0
+
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Ids)))
+		for i1 := range t.Ids {
+
+			n += backend.SizeVarUint32((t.Ids[i1]))
+		}
+	}
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Positions)))
+		for i1 := range t.Positions {
+
+			n += t.Positions[i1].CodSize()
+		}
+	}
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Counts)))
+		for k1, v1 := range t.Counts {
+
+			n += backend.SizeVarUint32((k1))
+			n += backend.SizeVarUint16((v1))
+		}
+	}
+	n += t.List.CodSize()
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Groups)))
+		for i1 := range t.Groups {
+
+			{
+				n += backend.SizeVarUint64(uint64(len(t.Groups[i1])))
+				for i2 := range t.Groups[i1] {
+
+					n += backend.SizeVarUint32((t.Groups[i1][i2]))
+				}
+			}
+		}
+	}
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Lists)))
+		for i1 := range t.Lists {
+
+			n += t.Lists[i1].CodSize()
+		}
+	}
+	return n
+}
+
+func (t Pooled) EncodeCodDelta(bs []byte, tt Pooled) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 1)...)
+
+	if !func() bool {
+
+		{
+			if len(t.Ids) != len(tt.Ids) {
+				return false
+			}
+			for i1 := range t.Ids {
+
+				if t.Ids[i1] != tt.Ids[i1] {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Ids)))
+			for i1 := range t.Ids {
+
+				bs = backend.WriteVarUint32(bs, (t.Ids[i1]))
+
+			}
+		}
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Positions) != len(tt.Positions) {
+				return false
+			}
+			for i1 := range t.Positions {
+
+				if !t.Positions[i1].CodEquals(tt.Positions[i1]) {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 1
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Positions)))
+			for i1 := range t.Positions {
+
+				bs = t.Positions[i1].EncodeCod(bs)
+			}
+		}
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Counts) != len(tt.Counts) {
+				return false
+			}
+			for k1, v1 := range t.Counts {
+				tv1, ok := tt.Counts[k1]
+				if !ok {
+					return false
+				}
+
+				if v1 != tv1 {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 2
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Counts)))
+
+			for k1, v1 := range t.Counts {
+
+				bs = backend.WriteVarUint32(bs, (k1))
+
+				bs = backend.WriteVarUint16(bs, (v1))
+
+			}
+
+		}
+	}
+
+	if !func() bool {
+
+		if !t.List.CodEquals(tt.List) {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 3
+
+		bs = t.List.EncodeCodDelta(bs, tt.List)
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Groups) != len(tt.Groups) {
+				return false
+			}
+			for i1 := range t.Groups {
+
+				{
+					if len(t.Groups[i1]) != len(tt.Groups[i1]) {
+						return false
+					}
+					for i2 := range t.Groups[i1] {
+
+						if t.Groups[i1][i2] != tt.Groups[i1][i2] {
+							return false
+						}
+
+					}
+				}
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 4
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Groups)))
+			for i1 := range t.Groups {
+
+				{
+					bs = backend.WriteVarUint64(bs, uint64(len(t.Groups[i1])))
+					for i2 := range t.Groups[i1] {
+
+						bs = backend.WriteVarUint32(bs, (t.Groups[i1][i2]))
+
+					}
+				}
+			}
+		}
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Lists) != len(tt.Lists) {
+				return false
+			}
+			for i1 := range t.Lists {
+
+				if !t.Lists[i1].CodEquals(tt.Lists[i1]) {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 5
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Lists)))
+			for i1 := range t.Lists {
+
+				bs = t.Lists[i1].EncodeCod(bs)
+			}
+		}
+	}
+
+	return bs
+}
+
+func (t *Pooled) DecodeCodDelta(bs []byte, tt Pooled) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 1 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:1]
+	n += 1
+
+	if mask[0]&(1<<0) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[uint32](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			t.Ids = backend.ResetSlice(t.Ids, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				t.Ids = backend.ExtendSlice(t.Ids)
+
+				{
+					var decoded uint32
+					decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					t.Ids[i1] = (decoded)
+				}
+
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Ids != nil {
+			ct.Ids = make([]uint32, len(t.Ids))
+			for i1 := range t.Ids {
+
+				ct.Ids[i1] = t.Ids[i1]
+			}
+		}
+	}
+
+	if mask[0]&(1<<1) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[subpackage.Vec](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			t.Positions = backend.ResetSlice(t.Positions, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				t.Positions = backend.ExtendSlice(t.Positions)
+
+				nOff, err = t.Positions[i1].DecodeCodWithLimits(bs[n:], lim)
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Positions != nil {
+			ct.Positions = make([]subpackage.Vec, len(t.Positions))
+			for i1 := range t.Positions {
+
+				ct.Positions[i1] = t.Positions[i1].CodClone()
+			}
+		}
+	}
+
+	if mask[0]&(1<<2) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitMap[uint32, uint16](lim, length)
+			if err != nil {
+				return 0, err
+			}
+
+			if t.Counts == nil {
+				t.Counts = make(map[uint32]uint16, backend.PreallocLen(length, len(bs[n:])))
+			} else {
+				clear(t.Counts)
+			}
+
+			for i1 := 0; i1 < int(length); i1++ {
+				var key1 uint32
+				var val1 uint16
+
+				{
+					var decoded uint32
+					decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					key1 = (decoded)
+				}
+
+				{
+					var decoded uint16
+					decoded, nOff, err = backend.ReadVarUint16(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					val1 = (decoded)
+				}
+
+				if err != nil {
+					return 0, err
+				}
+
+				t.Counts[key1] = val1
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Counts != nil {
+			ct.Counts = make(map[uint32]uint16, len(t.Counts))
+			for k1, v1 := range t.Counts {
+				var cv1 uint16
+
+				cv1 = v1
+				ct.Counts[k1] = cv1
+			}
+		}
+	}
+
+	if mask[0]&(1<<3) != 0 {
+
+		nOff, err = t.List.DecodeCodDelta(bs[n:], tt.List)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.List = t.List.CodClone()
+	}
+
+	if mask[0]&(1<<4) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[[]uint32](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			t.Groups = backend.ResetSlice(t.Groups, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				t.Groups = backend.ExtendSlice(t.Groups)
+
+				{
+					var length uint64
+					length, nOff, err = backend.ReadVarUint64(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					err = backend.LimitSlice[uint32](lim, length)
+					if err != nil {
+						return 0, err
+					}
+					t.Groups[i1] = backend.ResetSlice(t.Groups[i1], length, len(bs[n:]))
+
+					for i2 := 0; i2 < int(length); i2++ {
+						t.Groups[i1] = backend.ExtendSlice(t.Groups[i1])
+
+						{
+							var decoded uint32
+							decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+							if err != nil {
+								return 0, err
+							}
+							n += nOff
+							t.Groups[i1][i2] = (decoded)
+						}
+
+						if err != nil {
+							return 0, err
+						}
+					}
+				}
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Groups != nil {
+			ct.Groups = make([][]uint32, len(t.Groups))
+			for i1 := range t.Groups {
+
+				if t.Groups[i1] != nil {
+					ct.Groups[i1] = make([]uint32, len(t.Groups[i1]))
+					for i2 := range t.Groups[i1] {
+
+						ct.Groups[i1][i2] = t.Groups[i1][i2]
+					}
+				}
+			}
+		}
+	}
+
+	if mask[0]&(1<<5) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[IdList](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			t.Lists = backend.ResetSlice(t.Lists, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				t.Lists = backend.ExtendSlice(t.Lists)
+
+				nOff, err = t.Lists[i1].DecodeCodWithLimits(bs[n:], lim)
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Lists != nil {
+			ct.Lists = make([]IdList, len(t.Lists))
+			for i1 := range t.Lists {
+
+				ct.Lists[i1] = t.Lists[i1].CodClone()
+			}
+		}
+	}
+
+	return n, err
+}
+
+func (t Pooled) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *Pooled) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t PooledSave) EncodeCod(bs []byte) []byte {
+
+	{
+		n := 0
+
+		{
+			n += backend.SizeVarUint64(uint64(len(t.Ids)))
+			for i1 := range t.Ids {
+
+				n += backend.SizeVarUint32((t.Ids[i1]))
+			}
+		}
+		bs = backend.WriteVarUint64(bs, 1)
+		bs = backend.WriteVarUint64(bs, uint64(n))
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Ids)))
+			for i1 := range t.Ids {
+
+				bs = backend.WriteVarUint32(bs, (t.Ids[i1]))
+
+			}
+		}
+	}
+
+	{
+		n := 0
+
+		{
+			n += backend.SizeVarUint64(uint64(len(t.Groups)))
+			for i1 := range t.Groups {
+
+				{
+					n += backend.SizeVarUint64(uint64(len(t.Groups[i1])))
+					for i2 := range t.Groups[i1] {
+
+						n += backend.SizeVarUint32((t.Groups[i1][i2]))
+					}
+				}
+			}
+		}
+		bs = backend.WriteVarUint64(bs, 2)
+		bs = backend.WriteVarUint64(bs, uint64(n))
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Groups)))
+			for i1 := range t.Groups {
+
+				{
+					bs = backend.WriteVarUint64(bs, uint64(len(t.Groups[i1])))
+					for i2 := range t.Groups[i1] {
+
+						bs = backend.WriteVarUint32(bs, (t.Groups[i1][i2]))
+
+					}
+				}
+			}
+		}
+	}
+
+	{
+		n := 0
+
+		{
+			n += backend.SizeVarUint64(uint64(len(t.Counts)))
+			for k1, v1 := range t.Counts {
+
+				n += backend.SizeVarUint32((k1))
+				n += backend.SizeVarUint16((v1))
+			}
+		}
+		bs = backend.WriteVarUint64(bs, 3)
+		bs = backend.WriteVarUint64(bs, uint64(n))
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Counts)))
+
+			for k1, v1 := range t.Counts {
+
+				bs = backend.WriteVarUint32(bs, (k1))
+
+				bs = backend.WriteVarUint16(bs, (v1))
+
+			}
+
+		}
+	}
+
+	{
+		n := 0
+
+		n += t.Pos.CodSize()
+		bs = backend.WriteVarUint64(bs, 4)
+		bs = backend.WriteVarUint64(bs, uint64(n))
+
+		bs = t.Pos.EncodeCod(bs)
+	}
+
+	bs = backend.WriteVarUint64(bs, 0) // Zero marks the end of the struct
+	return bs
+}
+
+func (t *PooledSave) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *PooledSave) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+	// Fields that are missing from the data are reset after the loop. The other fields are decoded in place, so that they reuse their slices and maps
+	var seen [4]bool
+	for {
+		var fieldNum uint64
+		fieldNum, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		if fieldNum == 0 {
+			break // Zero marks the end of the struct
+		}
+
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		if length > uint64(len(bs)-n) {
+			return 0, backend.ErrTruncatedData
+		}
+		end := n + int(length)
+
+		switch fieldNum {
+
+		case 1:
+			seen[0] = true
+			bs := bs[:end]
+
+			{
+				var length uint64
+				length, nOff, err = backend.ReadVarUint64(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				err = backend.LimitSlice[uint32](lim, length)
+				if err != nil {
+					return 0, err
+				}
+				t.Ids = backend.ResetSlice(t.Ids, length, len(bs[n:]))
+
+				for i1 := 0; i1 < int(length); i1++ {
+					t.Ids = backend.ExtendSlice(t.Ids)
+
+					{
+						var decoded uint32
+						decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+						if err != nil {
+							return 0, err
+						}
+						n += nOff
+						t.Ids[i1] = (decoded)
+					}
+
+					if err != nil {
+						return 0, err
+					}
+				}
+			}
+
+		case 2:
+			seen[1] = true
+			bs := bs[:end]
+
+			{
+				var length uint64
+				length, nOff, err = backend.ReadVarUint64(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				err = backend.LimitSlice[[]uint32](lim, length)
+				if err != nil {
+					return 0, err
+				}
+				t.Groups = backend.ResetSlice(t.Groups, length, len(bs[n:]))
+
+				for i1 := 0; i1 < int(length); i1++ {
+					t.Groups = backend.ExtendSlice(t.Groups)
+
+					{
+						var length uint64
+						length, nOff, err = backend.ReadVarUint64(bs[n:])
+						if err != nil {
+							return 0, err
+						}
+						n += nOff
+						err = backend.LimitSlice[uint32](lim, length)
+						if err != nil {
+							return 0, err
+						}
+						t.Groups[i1] = backend.ResetSlice(t.Groups[i1], length, len(bs[n:]))
+
+						for i2 := 0; i2 < int(length); i2++ {
+							t.Groups[i1] = backend.ExtendSlice(t.Groups[i1])
+
+							{
+								var decoded uint32
+								decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+								if err != nil {
+									return 0, err
+								}
+								n += nOff
+								t.Groups[i1][i2] = (decoded)
+							}
+
+							if err != nil {
+								return 0, err
+							}
+						}
+					}
+					if err != nil {
+						return 0, err
+					}
+				}
+			}
+
+		case 3:
+			seen[2] = true
+			bs := bs[:end]
+
+			{
+				var length uint64
+				length, nOff, err = backend.ReadVarUint64(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				err = backend.LimitMap[uint32, uint16](lim, length)
+				if err != nil {
+					return 0, err
+				}
+
+				if t.Counts == nil {
+					t.Counts = make(map[uint32]uint16, backend.PreallocLen(length, len(bs[n:])))
+				} else {
+					clear(t.Counts)
+				}
+
+				for i1 := 0; i1 < int(length); i1++ {
+					var key1 uint32
+					var val1 uint16
+
+					{
+						var decoded uint32
+						decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+						if err != nil {
+							return 0, err
+						}
+						n += nOff
+						key1 = (decoded)
+					}
+
+					{
+						var decoded uint16
+						decoded, nOff, err = backend.ReadVarUint16(bs[n:])
+						if err != nil {
+							return 0, err
+						}
+						n += nOff
+						val1 = (decoded)
+					}
+
+					if err != nil {
+						return 0, err
+					}
+
+					t.Counts[key1] = val1
+				}
+			}
+
+		case 4:
+			seen[3] = true
+			bs := bs[:end]
+
+			nOff, err = t.Pos.DecodeCodWithLimits(bs[n:], lim)
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+
+		}
+		n = end // Skips unknown fields and any unread field data
+	}
+
+	if !seen[0] {
+		t.Ids = t.Ids[:0]
+	}
+	if !seen[1] {
+		t.Groups = t.Groups[:0]
+	}
+	if !seen[2] {
+		clear(t.Counts)
+	}
+	if !seen[3] {
+		var zero subpackage.Vec
+		t.Pos = zero
+	}
+
+	return n, err
+}
+
+func (t PooledSave) CodEquals(tt PooledSave) bool {
+
+	{
+		if len(t.Ids) != len(tt.Ids) {
+			return false
+		}
+		for i1 := range t.Ids {
+
+			if t.Ids[i1] != tt.Ids[i1] {
+				return false
+			}
+
+		}
+	}
+	{
+		if len(t.Groups) != len(tt.Groups) {
+			return false
+		}
+		for i1 := range t.Groups {
+
+			{
+				if len(t.Groups[i1]) != len(tt.Groups[i1]) {
+					return false
+				}
+				for i2 := range t.Groups[i1] {
+
+					if t.Groups[i1][i2] != tt.Groups[i1][i2] {
+						return false
+					}
+
+				}
+			}
+		}
+	}
+	{
+		if len(t.Counts) != len(tt.Counts) {
+			return false
+		}
+		for k1, v1 := range t.Counts {
+			tv1, ok := tt.Counts[k1]
+			if !ok {
+				return false
+			}
+
+			if v1 != tv1 {
+				return false
+			}
+
+		}
+	}
+	if !t.Pos.CodEquals(tt.Pos) {
+		return false
+	}
+
+	return true
+}
+
+func (t PooledSave) CodClone() PooledSave {
+	var ct PooledSave
+
+	if t.Ids != nil {
+		ct.Ids = make([]uint32, len(t.Ids))
+		for i1 := range t.Ids {
+
+			ct.Ids[i1] = t.Ids[i1]
+		}
+	}
+	if t.Groups != nil {
+		ct.Groups = make([][]uint32, len(t.Groups))
+		for i1 := range t.Groups {
+
+			if t.Groups[i1] != nil {
+				ct.Groups[i1] = make([]uint32, len(t.Groups[i1]))
+				for i2 := range t.Groups[i1] {
+
+					ct.Groups[i1][i2] = t.Groups[i1][i2]
+				}
+			}
+		}
+	}
+	if t.Counts != nil {
+		ct.Counts = make(map[uint32]uint16, len(t.Counts))
+		for k1, v1 := range t.Counts {
+			var cv1 uint16
+
+			cv1 = v1
+			ct.Counts[k1] = cv1
+		}
+	}
+	ct.Pos = t.Pos.CodClone()
+	return ct
+}
+
+func (t PooledSave) CodSize() int {
+	n := 0
+
+	{
+		start := n
+
+		{
+			n += backend.SizeVarUint64(uint64(len(t.Ids)))
+			for i1 := range t.Ids {
+
+				n += backend.SizeVarUint32((t.Ids[i1]))
+			}
+		}
+		fieldSize := n - start
+		n += backend.SizeVarUint64(1) + backend.SizeVarUint64(uint64(fieldSize))
+	}
+
+	{
+		start := n
+
+		{
+			n += backend.SizeVarUint64(uint64(len(t.Groups)))
+			for i1 := range t.Groups {
+
+				{
+					n += backend.SizeVarUint64(uint64(len(t.Groups[i1])))
+					for i2 := range t.Groups[i1] {
+
+						n += backend.SizeVarUint32((t.Groups[i1][i2]))
+					}
+				}
+			}
+		}
+		fieldSize := n - start
+		n += backend.SizeVarUint64(2) + backend.SizeVarUint64(uint64(fieldSize))
+	}
+
+	{
+		start := n
+
+		{
+			n += backend.SizeVarUint64(uint64(len(t.Counts)))
+			for k1, v1 := range t.Counts {
+
+				n += backend.SizeVarUint32((k1))
+				n += backend.SizeVarUint16((v1))
+			}
+		}
+		fieldSize := n - start
+		n += backend.SizeVarUint64(3) + backend.SizeVarUint64(uint64(fieldSize))
+	}
+
+	{
+		start := n
+
+		n += t.Pos.CodSize()
+		fieldSize := n - start
+		n += backend.SizeVarUint64(4) + backend.SizeVarUint64(uint64(fieldSize))
+	}
+
+	n += backend.SizeVarUint64(0)
+	return n
+}
+
+func (t PooledSave) EncodeCodDelta(bs []byte, tt PooledSave) []byte {
+	return t.EncodeCod(bs)
+}
+
+func (t *PooledSave) DecodeCodDelta(bs []byte, tt PooledSave) (int, error) {
+	return t.DecodeCod(bs)
+}
+
+func (t PooledSave) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *PooledSave) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t ReflectedStruct) EncodeCod(bs []byte) []byte {
+
+	bs = backend.WriteVarUint16(bs, uint16(t.Basic))
+
+	bs = t.Struct.EncodeCod(bs)
+	return bs
+}
+
+func (t *ReflectedStruct) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *ReflectedStruct) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	{
+		var decoded uint16
+		decoded, nOff, err = backend.ReadVarUint16(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		t.Basic = blocked.Basic(decoded)
+	}
+
+	nOff, err = t.Struct.DecodeCod(bs[n:])
+	if err != nil {
+		return 0, err
+	}
+	n += nOff
+
+	// println("ReflectedStruct:", n)
+	return n, err
+}
+
+func (t ReflectedStruct) CodEquals(tt ReflectedStruct) bool {
+
+	if t.Basic != tt.Basic {
+		return false
+	}
+
+	if !t.Struct.CodEquals(tt.Struct) {
+		return false
+	}
+
+	return true
+}
+
+func (t ReflectedStruct) CodClone() ReflectedStruct {
+	var ct ReflectedStruct
+
+	ct.Basic = t.Basic
+	ct.Struct = t.Struct.CodClone()
+	return ct
+}
+
+func (t ReflectedStruct) CodSize() int {
+	n := 0
+
+	n += backend.SizeVarUint16(uint16(t.Basic))
+	n += t.Struct.CodSize()
+	return n
+}
+
+func (t ReflectedStruct) EncodeCodDelta(bs []byte, tt ReflectedStruct) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 1)...)
+
+	if !func() bool {
+
+		if t.Basic != tt.Basic {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
+
+		bs = backend.WriteVarUint16(bs, uint16(t.Basic))
+
+	}
+
+	if !func() bool {
+
+		if !t.Struct.CodEquals(tt.Struct) {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 1
+
+		bs = t.Struct.EncodeCodDelta(bs, tt.Struct)
+	}
+
+	return bs
+}
+
+func (t *ReflectedStruct) DecodeCodDelta(bs []byte, tt ReflectedStruct) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 1 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:1]
+	n += 1
+
+	if mask[0]&(1<<0) != 0 {
+
+		{
+			var decoded uint16
+			decoded, nOff, err = backend.ReadVarUint16(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Basic = blocked.Basic(decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Basic = t.Basic
+	}
+
+	if mask[0]&(1<<1) != 0 {
+
+		nOff, err = t.Struct.DecodeCodDelta(bs[n:], tt.Struct)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Struct = t.Struct.CodClone()
+	}
+
+	return n, err
+}
+
+func (t ReflectedStruct) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *ReflectedStruct) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t SaveFile) EncodeCod(bs []byte) []byte {
+
+	bs = t.Save.EncodeCod(bs)
+	bs = backend.WriteVarUint32(bs, (t.Checksum))
+
+	return bs
+}
+
+func (t *SaveFile) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *SaveFile) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	nOff, err = t.Save.DecodeCodWithLimits(bs[n:], lim)
+	if err != nil {
+		return 0, err
+	}
+	n += nOff
+
+	{
+		var decoded uint32
+		decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		t.Checksum = (decoded)
+	}
+
+	// println("SaveFile:", n)
+	return n, err
+}
+
+func (t SaveFile) CodEquals(tt SaveFile) bool {
+
+	if !t.Save.CodEquals(tt.Save) {
+		return false
+	}
+
+	if t.Checksum != tt.Checksum {
+		return false
+	}
+
+	return true
+}
+
+func (t SaveFile) CodClone() SaveFile {
+	var ct SaveFile
+
+	ct.Save = t.Save.CodClone()
+	ct.Checksum = t.Checksum
+	return ct
+}
+
+func (t SaveFile) CodSize() int {
+	n := 0
+
+	n += t.Save.CodSize()
+	n += backend.SizeVarUint32((t.Checksum))
+	return n
+}
+
+func (t SaveFile) EncodeCodDelta(bs []byte, tt SaveFile) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 1)...)
+
+	if !func() bool {
+
+		if !t.Save.CodEquals(tt.Save) {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
+
+		bs = t.Save.EncodeCodDelta(bs, tt.Save)
+	}
+
+	if !func() bool {
+
+		if t.Checksum != tt.Checksum {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 1
+
+		bs = backend.WriteVarUint32(bs, (t.Checksum))
+
+	}
+
+	return bs
+}
+
+func (t *SaveFile) DecodeCodDelta(bs []byte, tt SaveFile) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 1 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:1]
+	n += 1
+
+	if mask[0]&(1<<0) != 0 {
+
+		nOff, err = t.Save.DecodeCodDelta(bs[n:], tt.Save)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Save = t.Save.CodClone()
+	}
+
+	if mask[0]&(1<<1) != 0 {
+
+		{
+			var decoded uint32
+			decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Checksum = (decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Checksum = t.Checksum
+	}
+
+	return n, err
+}
+
+func (t SaveFile) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *SaveFile) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t SaveV1) EncodeCod(bs []byte) []byte {
+
+	{
+		n := 0
+
+		n += backend.SizeString((t.Name))
+		bs = backend.WriteVarUint64(bs, 1)
+		bs = backend.WriteVarUint64(bs, uint64(n))
+
+		bs = backend.WriteString(bs, (t.Name))
+
+	}
+
+	{
+		n := 0
+
+		n += backend.SizeVarUint32((t.Level))
+		bs = backend.WriteVarUint64(bs, 2)
+		bs = backend.WriteVarUint64(bs, uint64(n))
+
+		bs = backend.WriteVarUint32(bs, (t.Level))
+
+	}
+
+	{
+		n := 0
+
+		{
+			n += backend.SizeVarUint64(uint64(len(t.Items)))
+			for i1 := range t.Items {
+
+				n += backend.SizeString((t.Items[i1]))
+			}
+		}
+		bs = backend.WriteVarUint64(bs, 3)
+		bs = backend.WriteVarUint64(bs, uint64(n))
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Items)))
+			for i1 := range t.Items {
+
+				bs = backend.WriteString(bs, (t.Items[i1]))
+
+			}
+		}
+	}
+
+	{
+		n := 0
+
+		n += t.Pos.CodSize()
+		bs = backend.WriteVarUint64(bs, 4)
+		bs = backend.WriteVarUint64(bs, uint64(n))
+
+		bs = t.Pos.EncodeCod(bs)
+	}
+
+	bs = backend.WriteVarUint64(bs, 0) // Zero marks the end of the struct
+	return bs
+}
+
+func (t *SaveV1) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *SaveV1) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+	// Fields that are missing from the data are reset after the loop. The other fields are decoded in place, so that they reuse their slices and maps
+	var seen [4]bool
+	for {
+		var fieldNum uint64
+		fieldNum, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		if fieldNum == 0 {
+			break // Zero marks the end of the struct
+		}
+
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		if length > uint64(len(bs)-n) {
+			return 0, backend.ErrTruncatedData
+		}
+		end := n + int(length)
+
+		switch fieldNum {
+
+		case 1:
+			seen[0] = true
+			bs := bs[:end]
+
+			{
+				var decoded string
+				decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				t.Name = (decoded)
+			}
+
+		case 2:
+			seen[1] = true
+			bs := bs[:end]
+
+			{
+				var decoded uint32
+				decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				t.Level = (decoded)
+			}
+
+		case 3:
+			seen[2] = true
+			bs := bs[:end]
+
+			{
+				var length uint64
+				length, nOff, err = backend.ReadVarUint64(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				err = backend.LimitSlice[string](lim, length)
+				if err != nil {
+					return 0, err
+				}
+				t.Items = backend.ResetSlice(t.Items, length, len(bs[n:]))
+
+				for i1 := 0; i1 < int(length); i1++ {
+					t.Items = backend.ExtendSlice(t.Items)
+
+					{
+						var decoded string
+						decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+						if err != nil {
+							return 0, err
+						}
+						n += nOff
+						t.Items[i1] = (decoded)
+					}
+
+					if err != nil {
+						return 0, err
+					}
+				}
+			}
+
+		case 4:
+			seen[3] = true
+			bs := bs[:end]
+
+			nOff, err = t.Pos.DecodeCodWithLimits(bs[n:], lim)
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+
+		}
+		n = end // Skips unknown fields and any unread field data
+	}
+
+	if !seen[0] {
+		var zero string
+		t.Name = zero
+	}
+	if !seen[1] {
+		var zero uint32
+		t.Level = zero
+	}
+	if !seen[2] {
+		t.Items = t.Items[:0]
+	}
+	if !seen[3] {
+		var zero subpackage.Vec
+		t.Pos = zero
+	}
+
+	return n, err
+}
+
+func (t SaveV1) CodEquals(tt SaveV1) bool {
+
+	if t.Name != tt.Name {
+		return false
+	}
+
+	if t.Level != tt.Level {
+		return false
+	}
+
+	{
+		if len(t.Items) != len(tt.Items) {
+			return false
+		}
+		for i1 := range t.Items {
+
+			if t.Items[i1] != tt.Items[i1] {
+				return false
+			}
+
+		}
+	}
+	if !t.Pos.CodEquals(tt.Pos) {
+		return false
+	}
+
+	return true
+}
+
+func (t SaveV1) CodClone() SaveV1 {
+	var ct SaveV1
+
+	ct.Name = t.Name
+	ct.Level = t.Level
+	if t.Items != nil {
+		ct.Items = make([]string, len(t.Items))
+		for i1 := range t.Items {
+
+			ct.Items[i1] = t.Items[i1]
+		}
+	}
+	ct.Pos = t.Pos.CodClone()
+	return ct
+}
+
+func (t SaveV1) CodSize() int {
+	n := 0
+
+	{
+		start := n
+
+		n += backend.SizeString((t.Name))
+		fieldSize := n - start
+		n += backend.SizeVarUint64(1) + backend.SizeVarUint64(uint64(fieldSize))
+	}
+
+	{
+		start := n
+
+		n += backend.SizeVarUint32((t.Level))
+		fieldSize := n - start
+		n += backend.SizeVarUint64(2) + backend.SizeVarUint64(uint64(fieldSize))
+	}
+
+	{
+		start := n
+
+		{
+			n += backend.SizeVarUint64(uint64(len(t.Items)))
+			for i1 := range t.Items {
+
+				n += backend.SizeString((t.Items[i1]))
+			}
+		}
+		fieldSize := n - start
+		n += backend.SizeVarUint64(3) + backend.SizeVarUint64(uint64(fieldSize))
+	}
+
+	{
+		start := n
+
+		n += t.Pos.CodSize()
+		fieldSize := n - start
+		n += backend.SizeVarUint64(4) + backend.SizeVarUint64(uint64(fieldSize))
+	}
+
+	n += backend.SizeVarUint64(0)
+	return n
+}
+
+func (t SaveV1) EncodeCodDelta(bs []byte, tt SaveV1) []byte {
+	return t.EncodeCod(bs)
+}
+
+func (t *SaveV1) DecodeCodDelta(bs []byte, tt SaveV1) (int, error) {
+	return t.DecodeCod(bs)
+}
+
+func (t SaveV1) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *SaveV1) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t SaveV2) EncodeCod(bs []byte) []byte {
+
+	{
+		n := 0
+
+		n += backend.SizeString((t.Name))
+		bs = backend.WriteVarUint64(bs, 1)
+		bs = backend.WriteVarUint64(bs, uint64(n))
+
+		bs = backend.WriteString(bs, (t.Name))
+
+	}
+
+	{
+		n := 0
+
+		{
+			n += backend.SizeVarUint64(uint64(len(t.Items)))
+			for i1 := range t.Items {
+
+				n += backend.SizeString((t.Items[i1]))
+			}
+		}
+		bs = backend.WriteVarUint64(bs, 3)
+		bs = backend.WriteVarUint64(bs, uint64(n))
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Items)))
+			for i1 := range t.Items {
+
+				bs = backend.WriteString(bs, (t.Items[i1]))
+
+			}
+		}
+	}
+
+	{
+		n := 0
+
+		n += t.Pos.CodSize()
+		bs = backend.WriteVarUint64(bs, 4)
+		bs = backend.WriteVarUint64(bs, uint64(n))
+
+		bs = t.Pos.EncodeCod(bs)
+	}
+
+	{
+		n := 0
+
+		n += backend.SizeVarUint64((t.Gold))
+		bs = backend.WriteVarUint64(bs, 5)
+		bs = backend.WriteVarUint64(bs, uint64(n))
+
+		bs = backend.WriteVarUint64(bs, (t.Gold))
+
+	}
+
+	{
+		n := 0
+
+		{
+			n += backend.SizeVarUint64(uint64(len(t.Inventory)))
+			for k1, v1 := range t.Inventory {
+
+				n += backend.SizeString((k1))
+				n += backend.SizeUint8((v1))
+			}
+		}
+		bs = backend.WriteVarUint64(bs, 6)
+		bs = backend.WriteVarUint64(bs, uint64(n))
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Inventory)))
+
+			for k1, v1 := range t.Inventory {
+
+				bs = backend.WriteString(bs, (k1))
+
+				bs = backend.WriteUint8(bs, (v1))
+
+			}
+
+		}
+	}
+
+	bs = backend.WriteVarUint64(bs, 0) // Zero marks the end of the struct
+	return bs
+}
+
+func (t *SaveV2) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *SaveV2) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+	// Fields that are missing from the data are reset after the loop. The other fields are decoded in place, so that they reuse their slices and maps
+	var seen [5]bool
+	for {
+		var fieldNum uint64
+		fieldNum, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		if fieldNum == 0 {
+			break // Zero marks the end of the struct
+		}
+
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		if length > uint64(len(bs)-n) {
+			return 0, backend.ErrTruncatedData
+		}
+		end := n + int(length)
+
+		switch fieldNum {
+
+		case 1:
+			seen[0] = true
+			bs := bs[:end]
+
+			{
+				var decoded string
+				decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				t.Name = (decoded)
+			}
+
+		case 3:
+			seen[1] = true
+			bs := bs[:end]
+
+			{
+				var length uint64
+				length, nOff, err = backend.ReadVarUint64(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				err = backend.LimitSlice[string](lim, length)
+				if err != nil {
+					return 0, err
+				}
+				t.Items = backend.ResetSlice(t.Items, length, len(bs[n:]))
+
+				for i1 := 0; i1 < int(length); i1++ {
+					t.Items = backend.ExtendSlice(t.Items)
+
+					{
+						var decoded string
+						decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+						if err != nil {
+							return 0, err
+						}
+						n += nOff
+						t.Items[i1] = (decoded)
+					}
+
+					if err != nil {
+						return 0, err
+					}
+				}
+			}
+
+		case 4:
+			seen[2] = true
+			bs := bs[:end]
+
+			nOff, err = t.Pos.DecodeCodWithLimits(bs[n:], lim)
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+
+		case 5:
+			seen[3] = true
+			bs := bs[:end]
+
+			{
+				var decoded uint64
+				decoded, nOff, err = backend.ReadVarUint64(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				t.Gold = (decoded)
+			}
+
+		case 6:
+			seen[4] = true
+			bs := bs[:end]
+
+			{
+				var length uint64
+				length, nOff, err = backend.ReadVarUint64(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				err = backend.LimitMap[string, uint8](lim, length)
+				if err != nil {
+					return 0, err
+				}
+
+				if t.Inventory == nil {
+					t.Inventory = make(map[string]uint8, backend.PreallocLen(length, len(bs[n:])))
+				} else {
+					clear(t.Inventory)
+				}
+
+				for i1 := 0; i1 < int(length); i1++ {
+					var key1 string
+					var val1 uint8
+
+					{
+						var decoded string
+						decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+						if err != nil {
+							return 0, err
+						}
+						n += nOff
+						key1 = (decoded)
+					}
+
+					{
+						var decoded uint8
+						decoded, nOff, err = backend.ReadUint8(bs[n:])
+						if err != nil {
+							return 0, err
+						}
+						n += nOff
+						val1 = (decoded)
+					}
+
+					if err != nil {
+						return 0, err
+					}
+
+					t.Inventory[key1] = val1
+				}
+			}
+
+		}
+		n = end // Skips unknown fields and any unread field data
+	}
+
+	if !seen[0] {
+		var zero string
+		t.Name = zero
+	}
+	if !seen[1] {
+		t.Items = t.Items[:0]
+	}
+	if !seen[2] {
+		var zero subpackage.Vec
+		t.Pos = zero
+	}
+	if !seen[3] {
+		var zero uint64
+		t.Gold = zero
+	}
+	if !seen[4] {
+		clear(t.Inventory)
+	}
+
+	return n, err
+}
+
+func (t SaveV2) CodEquals(tt SaveV2) bool {
+
+	if t.Name != tt.Name {
+		return false
+	}
+
+	{
+		if len(t.Items) != len(tt.Items) {
+			return false
+		}
+		for i1 := range t.Items {
+
+			if t.Items[i1] != tt.Items[i1] {
+				return false
+			}
+
+		}
+	}
+	if !t.Pos.CodEquals(tt.Pos) {
+		return false
+	}
+
+	if t.Gold != tt.Gold {
+		return false
+	}
+
+	{
+		if len(t.Inventory) != len(tt.Inventory) {
+			return false
+		}
+		for k1, v1 := range t.Inventory {
+			tv1, ok := tt.Inventory[k1]
+			if !ok {
+				return false
+			}
+
+			if v1 != tv1 {
+				return false
+			}
+
+		}
+	}
+	return true
+}
+
+func (t SaveV2) CodClone() SaveV2 {
+	var ct SaveV2
+
+	ct.Name = t.Name
+	if t.Items != nil {
+		ct.Items = make([]string, len(t.Items))
+		for i1 := range t.Items {
+
+			ct.Items[i1] = t.Items[i1]
+		}
+	}
+	ct.Pos = t.Pos.CodClone()
+	ct.Gold = t.Gold
+	if t.Inventory != nil {
+		ct.Inventory = make(map[string]uint8, len(t.Inventory))
+		for k1, v1 := range t.Inventory {
+			var cv1 uint8
+
+			cv1 = v1
+			ct.Inventory[k1] = cv1
+		}
+	}
+	return ct
+}
+
+func (t SaveV2) CodSize() int {
+	n := 0
+
+	{
+		start := n
+
+		n += backend.SizeString((t.Name))
+		fieldSize := n - start
+		n += backend.SizeVarUint64(1) + backend.SizeVarUint64(uint64(fieldSize))
+	}
+
+	{
+		start := n
+
+		{
+			n += backend.SizeVarUint64(uint64(len(t.Items)))
+			for i1 := range t.Items {
+
+				n += backend.SizeString((t.Items[i1]))
+			}
+		}
+		fieldSize := n - start
+		n += backend.SizeVarUint64(3) + backend.SizeVarUint64(uint64(fieldSize))
+	}
+
+	{
+		start := n
+
+		n += t.Pos.CodSize()
+		fieldSize := n - start
+		n += backend.SizeVarUint64(4) + backend.SizeVarUint64(uint64(fieldSize))
+	}
+
+	{
+		start := n
+
+		n += backend.SizeVarUint64((t.Gold))
+		fieldSize := n - start
+		n += backend.SizeVarUint64(5) + backend.SizeVarUint64(uint64(fieldSize))
+	}
+
+	{
+		start := n
+
+		{
+			n += backend.SizeVarUint64(uint64(len(t.Inventory)))
+			for k1, v1 := range t.Inventory {
+
+				n += backend.SizeString((k1))
+				n += backend.SizeUint8((v1))
+			}
+		}
+		fieldSize := n - start
+		n += backend.SizeVarUint64(6) + backend.SizeVarUint64(uint64(fieldSize))
+	}
+
+	n += backend.SizeVarUint64(0)
+	return n
+}
+
+func (t SaveV2) EncodeCodDelta(bs []byte, tt SaveV2) []byte {
+	return t.EncodeCod(bs)
+}
+
+func (t *SaveV2) DecodeCodDelta(bs []byte, tt SaveV2) (int, error) {
+	return t.DecodeCod(bs)
+}
+
+func (t SaveV2) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *SaveV2) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t Snapshot) EncodeCod(bs []byte) []byte {
+
+	bs = backend.WriteVarUint32(bs, uint32(t.Tick))
+
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Entities)))
+		for i1 := range t.Entities {
+
+			bs = t.Entities[i1].EncodeCod(bs)
+		}
+	}
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Names)))
+
+		for k1, v1 := range t.Names {
+
+			bs = backend.WriteVarUint32(bs, (k1))
+
+			bs = backend.WriteString(bs, (v1))
+
+		}
+
+	}
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Cache)))
+		for i1 := range t.Cache {
+
+			bs = backend.WriteUint8(bs, (t.Cache[i1]))
+
+		}
+	}
+	return bs
+}
+
+func (t *Snapshot) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *Snapshot) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	{
+		var decoded uint32
+		decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		t.Tick = Tick(decoded)
+	}
+
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitSlice[EntityFlags](lim, length)
+		if err != nil {
+			return 0, err
+		}
+		t.Entities = backend.ResetSlice(t.Entities, length, len(bs[n:]))
+
+		for i1 := 0; i1 < int(length); i1++ {
+			t.Entities = backend.ExtendSlice(t.Entities)
+
+			nOff, err = t.Entities[i1].DecodeCodWithLimits(bs[n:], lim)
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitMap[uint32, string](lim, length)
+		if err != nil {
+			return 0, err
+		}
+
+		if t.Names == nil {
+			t.Names = make(map[uint32]string, backend.PreallocLen(length, len(bs[n:])))
+		} else {
+			clear(t.Names)
+		}
+
+		for i1 := 0; i1 < int(length); i1++ {
+			var key1 uint32
+			var val1 string
+
+			{
+				var decoded uint32
+				decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				key1 = (decoded)
+			}
+
+			{
+				var decoded string
+				decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				val1 = (decoded)
+			}
+
+			if err != nil {
+				return 0, err
+			}
+
+			t.Names[key1] = val1
+		}
+	}
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitSlice[uint8](lim, length)
+		if err != nil {
+			return 0, err
+		}
+		t.Cache = backend.ResetSlice(t.Cache, length, len(bs[n:]))
+
+		for i1 := 0; i1 < int(length); i1++ {
+			t.Cache = backend.ExtendSlice(t.Cache)
+
+			{
+				var decoded uint8
+				decoded, nOff, err = backend.ReadUint8(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				t.Cache[i1] = (decoded)
+			}
+
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	// println("Snapshot:", n)
+	return n, err
+}
+
+func (t Snapshot) CodEquals(tt Snapshot) bool {
+
+	if t.Tick != tt.Tick {
+		return false
+	}
+
+	{
+		if len(t.Entities) != len(tt.Entities) {
+			return false
+		}
+		for i1 := range t.Entities {
+
+			if !t.Entities[i1].CodEquals(tt.Entities[i1]) {
+				return false
+			}
+
+		}
+	}
+	{
+		if len(t.Names) != len(tt.Names) {
+			return false
+		}
+		for k1, v1 := range t.Names {
+			tv1, ok := tt.Names[k1]
+			if !ok {
+				return false
+			}
+
+			if v1 != tv1 {
+				return false
+			}
+
+		}
+	}
+	return true
+}
+
+func (t Snapshot) CodClone() Snapshot {
+	var ct Snapshot
+
+	ct.Tick = t.Tick
+	if t.Entities != nil {
+		ct.Entities = make([]EntityFlags, len(t.Entities))
+		for i1 := range t.Entities {
+
+			ct.Entities[i1] = t.Entities[i1].CodClone()
+		}
+	}
+	if t.Names != nil {
+		ct.Names = make(map[uint32]string, len(t.Names))
+		for k1, v1 := range t.Names {
+			var cv1 string
+
+			cv1 = v1
+			ct.Names[k1] = cv1
+		}
+	}
+	return ct
+}
+
+func (t Snapshot) CodSize() int {
+	n := 0
+
+	n += backend.SizeVarUint32(uint32(t.Tick))
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Entities)))
+		for i1 := range t.Entities {
+
+			n += t.Entities[i1].CodSize()
+		}
+	}
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Names)))
+		for k1, v1 := range t.Names {
+
+			n += backend.SizeVarUint32((k1))
+			n += backend.SizeString((v1))
+		}
+	}
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Cache)))
+		for i1 := range t.Cache {
+
+			n += backend.SizeUint8((t.Cache[i1]))
+		}
+	}
+	return n
+}
+
+func (t Snapshot) EncodeCodDelta(bs []byte, tt Snapshot) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 1)...)
+
+	if !func() bool {
+
+		if t.Tick != tt.Tick {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
+
+		bs = backend.WriteVarUint32(bs, uint32(t.Tick))
+
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Entities) != len(tt.Entities) {
+				return false
+			}
+			for i1 := range t.Entities {
+
+				if !t.Entities[i1].CodEquals(tt.Entities[i1]) {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 1
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Entities)))
+			for i1 := range t.Entities {
+
+				bs = t.Entities[i1].EncodeCod(bs)
+			}
+		}
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Names) != len(tt.Names) {
+				return false
+			}
+			for k1, v1 := range t.Names {
+				tv1, ok := tt.Names[k1]
+				if !ok {
+					return false
+				}
+
+				if v1 != tv1 {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 2
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Names)))
+
+			for k1, v1 := range t.Names {
+
+				bs = backend.WriteVarUint32(bs, (k1))
+
+				bs = backend.WriteString(bs, (v1))
+
+			}
+
+		}
+	}
+
+	{
+		bs[maskStart+0] |= 1 << 3
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Cache)))
+			for i1 := range t.Cache {
+
+				bs = backend.WriteUint8(bs, (t.Cache[i1]))
+
+			}
+		}
+	}
+
+	return bs
+}
+
+func (t *Snapshot) DecodeCodDelta(bs []byte, tt Snapshot) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 1 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:1]
+	n += 1
+
+	if mask[0]&(1<<0) != 0 {
+
+		{
+			var decoded uint32
+			decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Tick = Tick(decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Tick = t.Tick
+	}
+
+	if mask[0]&(1<<1) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[EntityFlags](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			t.Entities = backend.ResetSlice(t.Entities, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				t.Entities = backend.ExtendSlice(t.Entities)
+
+				nOff, err = t.Entities[i1].DecodeCodWithLimits(bs[n:], lim)
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Entities != nil {
+			ct.Entities = make([]EntityFlags, len(t.Entities))
+			for i1 := range t.Entities {
+
+				ct.Entities[i1] = t.Entities[i1].CodClone()
+			}
+		}
+	}
+
+	if mask[0]&(1<<2) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitMap[uint32, string](lim, length)
+			if err != nil {
+				return 0, err
+			}
+
+			if t.Names == nil {
+				t.Names = make(map[uint32]string, backend.PreallocLen(length, len(bs[n:])))
+			} else {
+				clear(t.Names)
+			}
+
+			for i1 := 0; i1 < int(length); i1++ {
+				var key1 uint32
+				var val1 string
+
+				{
+					var decoded uint32
+					decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					key1 = (decoded)
+				}
+
+				{
+					var decoded string
+					decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					val1 = (decoded)
+				}
+
+				if err != nil {
+					return 0, err
+				}
+
+				t.Names[key1] = val1
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Names != nil {
+			ct.Names = make(map[uint32]string, len(t.Names))
+			for k1, v1 := range t.Names {
+				var cv1 string
+
+				cv1 = v1
+				ct.Names[k1] = cv1
+			}
+		}
+	}
+
+	if mask[0]&(1<<3) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[uint8](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			t.Cache = backend.ResetSlice(t.Cache, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				t.Cache = backend.ExtendSlice(t.Cache)
+
+				{
+					var decoded uint8
+					decoded, nOff, err = backend.ReadUint8(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					t.Cache[i1] = (decoded)
+				}
+
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+	}
+
+	return n, err
+}
+
+func (t Snapshot) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *Snapshot) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t SortedMaps) EncodeCod(bs []byte) []byte {
+
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Names)))
+
+		for _, k1 := range backend.SortedKeys(t.Names) {
+			v1 := t.Names[k1]
+
+			bs = backend.WriteString(bs, (k1))
+
+			bs = backend.WriteUint8(bs, (v1))
+
+		}
+
+	}
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Ticks)))
+
+		for _, k1 := range backend.SortedKeys(t.Ticks) {
+			v1 := t.Ticks[k1]
+
+			bs = backend.WriteVarUint32(bs, uint32(k1))
+
+			{
+				bs = backend.WriteVarUint64(bs, uint64(len(v1)))
+
+				for _, k2 := range backend.SortedKeys(v1) {
+					v2 := v1[k2]
+
+					bs = backend.WriteInt8(bs, (k2))
+
+					bs = backend.WriteString(bs, (v2))
+
+				}
+
+			}
+		}
+
+	}
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Positions)))
+
+		bs = backend.AppendSortedEntries(bs, t.Positions, func(bs []byte, k1 Id, v1 uint8) []byte {
+
+			bs = k1.EncodeCod(bs)
+			bs = backend.WriteUint8(bs, (v1))
+
+			return bs
+		})
+
+	}
+	bs = t.Inventory.EncodeCod(bs)
+	return bs
+}
+
+func (t *SortedMaps) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *SortedMaps) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitMap[string, uint8](lim, length)
+		if err != nil {
+			return 0, err
+		}
+
+		if t.Names == nil {
+			t.Names = make(map[string]uint8, backend.PreallocLen(length, len(bs[n:])))
+		} else {
+			clear(t.Names)
+		}
+
+		for i1 := 0; i1 < int(length); i1++ {
+			var key1 string
+			var val1 uint8
+
+			{
+				var decoded string
+				decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				key1 = (decoded)
+			}
+
+			{
+				var decoded uint8
+				decoded, nOff, err = backend.ReadUint8(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				val1 = (decoded)
+			}
+
+			if err != nil {
+				return 0, err
+			}
+
+			t.Names[key1] = val1
+		}
+	}
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitMap[Tick, map[int8]string](lim, length)
+		if err != nil {
+			return 0, err
+		}
+
+		if t.Ticks == nil {
+			t.Ticks = make(map[Tick]map[int8]string, backend.PreallocLen(length, len(bs[n:])))
+		} else {
+			clear(t.Ticks)
+		}
+
+		for i1 := 0; i1 < int(length); i1++ {
+			var key1 Tick
+			var val1 map[int8]string
+
+			{
+				var decoded uint32
+				decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				key1 = Tick(decoded)
+			}
+
+			{
+				var length uint64
+				length, nOff, err = backend.ReadVarUint64(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				err = backend.LimitMap[int8, string](lim, length)
+				if err != nil {
+					return 0, err
+				}
+
+				if val1 == nil {
+					val1 = make(map[int8]string, backend.PreallocLen(length, len(bs[n:])))
+				} else {
+					clear(val1)
+				}
+
+				for i2 := 0; i2 < int(length); i2++ {
+					var key2 int8
+					var val2 string
+
+					{
+						var decoded int8
+						decoded, nOff, err = backend.ReadInt8(bs[n:])
+						if err != nil {
+							return 0, err
+						}
+						n += nOff
+						key2 = (decoded)
+					}
+
+					{
+						var decoded string
+						decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+						if err != nil {
+							return 0, err
+						}
+						n += nOff
+						val2 = (decoded)
+					}
+
+					if err != nil {
+						return 0, err
+					}
+
+					val1[key2] = val2
+				}
+			}
+			if err != nil {
+				return 0, err
+			}
+
+			t.Ticks[key1] = val1
+		}
+	}
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitMap[Id, uint8](lim, length)
+		if err != nil {
+			return 0, err
+		}
+
+		if t.Positions == nil {
+			t.Positions = make(map[Id]uint8, backend.PreallocLen(length, len(bs[n:])))
+		} else {
+			clear(t.Positions)
+		}
+
+		for i1 := 0; i1 < int(length); i1++ {
+			var key1 Id
+			var val1 uint8
+
+			nOff, err = key1.DecodeCodWithLimits(bs[n:], lim)
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+
+			{
+				var decoded uint8
+				decoded, nOff, err = backend.ReadUint8(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				val1 = (decoded)
+			}
+
+			if err != nil {
+				return 0, err
+			}
+
+			t.Positions[key1] = val1
+		}
+	}
+	nOff, err = t.Inventory.DecodeCodWithLimits(bs[n:], lim)
+	if err != nil {
+		return 0, err
+	}
+	n += nOff
+
+	// println("SortedMaps:", n)
+	return n, err
+}
+
+func (t SortedMaps) CodEquals(tt SortedMaps) bool {
+
+	{
+		if len(t.Names) != len(tt.Names) {
+			return false
+		}
+		for k1, v1 := range t.Names {
+			tv1, ok := tt.Names[k1]
+			if !ok {
+				return false
+			}
+
+			if v1 != tv1 {
+				return false
+			}
+
+		}
+	}
+	{
+		if len(t.Ticks) != len(tt.Ticks) {
+			return false
+		}
+		for k1, v1 := range t.Ticks {
+			tv1, ok := tt.Ticks[k1]
+			if !ok {
+				return false
+			}
+
+			{
+				if len(v1) != len(tv1) {
+					return false
+				}
+				for k2, v2 := range v1 {
+					tv2, ok := tv1[k2]
+					if !ok {
+						return false
+					}
+
+					if v2 != tv2 {
+						return false
+					}
+
+				}
+			}
+		}
+	}
+	{
+		if len(t.Positions) != len(tt.Positions) {
+			return false
+		}
+		for k1, v1 := range t.Positions {
+			tv1, ok := tt.Positions[k1]
+			if !ok {
+				return false
+			}
+
+			if v1 != tv1 {
+				return false
+			}
+
+		}
+	}
+	if !t.Inventory.CodEquals(tt.Inventory) {
+		return false
+	}
+
+	return true
+}
+
+func (t SortedMaps) CodClone() SortedMaps {
+	var ct SortedMaps
+
+	if t.Names != nil {
+		ct.Names = make(map[string]uint8, len(t.Names))
+		for k1, v1 := range t.Names {
+			var cv1 uint8
+
+			cv1 = v1
+			ct.Names[k1] = cv1
+		}
+	}
+	if t.Ticks != nil {
+		ct.Ticks = make(map[Tick]map[int8]string, len(t.Ticks))
+		for k1, v1 := range t.Ticks {
+			var cv1 map[int8]string
+
+			if v1 != nil {
+				cv1 = make(map[int8]string, len(v1))
+				for k2, v2 := range v1 {
+					var cv2 string
+
+					cv2 = v2
+					cv1[k2] = cv2
+				}
+			}
+			ct.Ticks[k1] = cv1
+		}
+	}
+	if t.Positions != nil {
+		ct.Positions = make(map[Id]uint8, len(t.Positions))
+		for k1, v1 := range t.Positions {
+			var cv1 uint8
+
+			cv1 = v1
+			ct.Positions[k1] = cv1
+		}
+	}
+	ct.Inventory = t.Inventory.CodClone()
+	return ct
+}
+
+func (t SortedMaps) CodSize() int {
+	n := 0
+
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Names)))
+		for k1, v1 := range t.Names {
+
+			n += backend.SizeString((k1))
+			n += backend.SizeUint8((v1))
+		}
+	}
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Ticks)))
+		for k1, v1 := range t.Ticks {
+
+			n += backend.SizeVarUint32(uint32(k1))
+			{
+				n += backend.SizeVarUint64(uint64(len(v1)))
+				for k2, v2 := range v1 {
+
+					n += backend.SizeInt8((k2))
+					n += backend.SizeString((v2))
+				}
+			}
+		}
+	}
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Positions)))
+		for k1, v1 := range t.Positions {
+
+			n += k1.CodSize()
+			n += backend.SizeUint8((v1))
+		}
+	}
+	n += t.Inventory.CodSize()
+	return n
+}
+
+func (t SortedMaps) EncodeCodDelta(bs []byte, tt SortedMaps) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 1)...)
+
+	if !func() bool {
+
+		{
+			if len(t.Names) != len(tt.Names) {
+				return false
+			}
+			for k1, v1 := range t.Names {
+				tv1, ok := tt.Names[k1]
+				if !ok {
+					return false
+				}
+
+				if v1 != tv1 {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Names)))
+
+			for _, k1 := range backend.SortedKeys(t.Names) {
+				v1 := t.Names[k1]
+
+				bs = backend.WriteString(bs, (k1))
+
+				bs = backend.WriteUint8(bs, (v1))
+
+			}
+
+		}
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Ticks) != len(tt.Ticks) {
+				return false
+			}
+			for k1, v1 := range t.Ticks {
+				tv1, ok := tt.Ticks[k1]
+				if !ok {
+					return false
+				}
+
+				{
+					if len(v1) != len(tv1) {
+						return false
+					}
+					for k2, v2 := range v1 {
+						tv2, ok := tv1[k2]
+						if !ok {
+							return false
+						}
+
+						if v2 != tv2 {
+							return false
+						}
+
+					}
+				}
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 1
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Ticks)))
+
+			for _, k1 := range backend.SortedKeys(t.Ticks) {
+				v1 := t.Ticks[k1]
+
+				bs = backend.WriteVarUint32(bs, uint32(k1))
+
+				{
+					bs = backend.WriteVarUint64(bs, uint64(len(v1)))
+
+					for _, k2 := range backend.SortedKeys(v1) {
+						v2 := v1[k2]
+
+						bs = backend.WriteInt8(bs, (k2))
+
+						bs = backend.WriteString(bs, (v2))
+
+					}
+
+				}
+			}
+
+		}
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Positions) != len(tt.Positions) {
+				return false
+			}
+			for k1, v1 := range t.Positions {
+				tv1, ok := tt.Positions[k1]
+				if !ok {
+					return false
+				}
+
+				if v1 != tv1 {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 2
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Positions)))
+
+			bs = backend.AppendSortedEntries(bs, t.Positions, func(bs []byte, k1 Id, v1 uint8) []byte {
+
+				bs = k1.EncodeCod(bs)
+				bs = backend.WriteUint8(bs, (v1))
+
+				return bs
+			})
+
+		}
+	}
+
+	if !func() bool {
+
+		if !t.Inventory.CodEquals(tt.Inventory) {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 3
+
+		bs = t.Inventory.EncodeCodDelta(bs, tt.Inventory)
+	}
+
+	return bs
+}
+
+func (t *SortedMaps) DecodeCodDelta(bs []byte, tt SortedMaps) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 1 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:1]
+	n += 1
+
+	if mask[0]&(1<<0) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitMap[string, uint8](lim, length)
+			if err != nil {
+				return 0, err
+			}
+
+			if t.Names == nil {
+				t.Names = make(map[string]uint8, backend.PreallocLen(length, len(bs[n:])))
+			} else {
+				clear(t.Names)
+			}
+
+			for i1 := 0; i1 < int(length); i1++ {
+				var key1 string
+				var val1 uint8
+
+				{
+					var decoded string
+					decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					key1 = (decoded)
+				}
+
+				{
+					var decoded uint8
+					decoded, nOff, err = backend.ReadUint8(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					val1 = (decoded)
+				}
+
+				if err != nil {
+					return 0, err
+				}
+
+				t.Names[key1] = val1
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Names != nil {
+			ct.Names = make(map[string]uint8, len(t.Names))
+			for k1, v1 := range t.Names {
+				var cv1 uint8
+
+				cv1 = v1
+				ct.Names[k1] = cv1
+			}
+		}
+	}
+
+	if mask[0]&(1<<1) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitMap[Tick, map[int8]string](lim, length)
+			if err != nil {
+				return 0, err
+			}
+
+			if t.Ticks == nil {
+				t.Ticks = make(map[Tick]map[int8]string, backend.PreallocLen(length, len(bs[n:])))
+			} else {
+				clear(t.Ticks)
+			}
+
+			for i1 := 0; i1 < int(length); i1++ {
+				var key1 Tick
+				var val1 map[int8]string
+
+				{
+					var decoded uint32
+					decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					key1 = Tick(decoded)
+				}
+
+				{
+					var length uint64
+					length, nOff, err = backend.ReadVarUint64(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					err = backend.LimitMap[int8, string](lim, length)
+					if err != nil {
+						return 0, err
+					}
+
+					if val1 == nil {
+						val1 = make(map[int8]string, backend.PreallocLen(length, len(bs[n:])))
+					} else {
+						clear(val1)
+					}
+
+					for i2 := 0; i2 < int(length); i2++ {
+						var key2 int8
+						var val2 string
+
+						{
+							var decoded int8
+							decoded, nOff, err = backend.ReadInt8(bs[n:])
+							if err != nil {
+								return 0, err
+							}
+							n += nOff
+							key2 = (decoded)
+						}
+
+						{
+							var decoded string
+							decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+							if err != nil {
+								return 0, err
+							}
+							n += nOff
+							val2 = (decoded)
+						}
+
+						if err != nil {
+							return 0, err
+						}
+
+						val1[key2] = val2
+					}
+				}
+				if err != nil {
+					return 0, err
+				}
+
+				t.Ticks[key1] = val1
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Ticks != nil {
+			ct.Ticks = make(map[Tick]map[int8]string, len(t.Ticks))
+			for k1, v1 := range t.Ticks {
+				var cv1 map[int8]string
+
+				if v1 != nil {
+					cv1 = make(map[int8]string, len(v1))
+					for k2, v2 := range v1 {
+						var cv2 string
+
+						cv2 = v2
+						cv1[k2] = cv2
+					}
+				}
+				ct.Ticks[k1] = cv1
+			}
+		}
+	}
+
+	if mask[0]&(1<<2) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitMap[Id, uint8](lim, length)
+			if err != nil {
+				return 0, err
+			}
+
+			if t.Positions == nil {
+				t.Positions = make(map[Id]uint8, backend.PreallocLen(length, len(bs[n:])))
+			} else {
+				clear(t.Positions)
+			}
+
+			for i1 := 0; i1 < int(length); i1++ {
+				var key1 Id
+				var val1 uint8
+
+				nOff, err = key1.DecodeCodWithLimits(bs[n:], lim)
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+
+				{
+					var decoded uint8
+					decoded, nOff, err = backend.ReadUint8(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					val1 = (decoded)
+				}
+
+				if err != nil {
+					return 0, err
+				}
+
+				t.Positions[key1] = val1
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Positions != nil {
+			ct.Positions = make(map[Id]uint8, len(t.Positions))
+			for k1, v1 := range t.Positions {
+				var cv1 uint8
+
+				cv1 = v1
+				ct.Positions[k1] = cv1
+			}
+		}
+	}
+
+	if mask[0]&(1<<3) != 0 {
+
+		nOff, err = t.Inventory.DecodeCodDelta(bs[n:], tt.Inventory)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Inventory = t.Inventory.CodClone()
+	}
+
+	return n, err
+}
+
+func (t SortedMaps) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *SortedMaps) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t SpecialMap) EncodeCod(bs []byte) []byte {
+
+	{
+		value0 := map[string][]uint8(t)
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(value0)))
+
+			for k1, v1 := range value0 {
+
+				bs = backend.WriteString(bs, (k1))
+
+				{
+					bs = backend.WriteVarUint64(bs, uint64(len(v1)))
+					for i2 := range v1 {
+
+						bs = backend.WriteUint8(bs, (v1[i2]))
+
+					}
+				}
+			}
+
+		}
+
+	}
+	return bs
+}
+
+func (t *SpecialMap) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *SpecialMap) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	{
+		value0 := map[string][]uint8(*t)
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitMap[string, []uint8](lim, length)
+			if err != nil {
+				return 0, err
+			}
+
+			if value0 == nil {
+				value0 = make(map[string][]uint8, backend.PreallocLen(length, len(bs[n:])))
+			} else {
+				clear(value0)
+			}
+
+			for i1 := 0; i1 < int(length); i1++ {
+				var key1 string
+				var val1 []uint8
+
+				{
+					var decoded string
+					decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					key1 = (decoded)
+				}
+
+				{
+					var length uint64
+					length, nOff, err = backend.ReadVarUint64(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					err = backend.LimitSlice[uint8](lim, length)
+					if err != nil {
+						return 0, err
+					}
+					val1 = backend.ResetSlice(val1, length, len(bs[n:]))
+
+					for i2 := 0; i2 < int(length); i2++ {
+						val1 = backend.ExtendSlice(val1)
+
+						{
+							var decoded uint8
+							decoded, nOff, err = backend.ReadUint8(bs[n:])
+							if err != nil {
+								return 0, err
+							}
+							n += nOff
+							val1[i2] = (decoded)
+						}
+
+						if err != nil {
+							return 0, err
+						}
+					}
+				}
+				if err != nil {
+					return 0, err
+				}
+
+				value0[key1] = val1
+			}
+		}
+		*t = SpecialMap(value0)
+	}
+
+	// println("SpecialMap:", n)
+	return n, err
+}
+
+func (t SpecialMap) CodEquals(tt SpecialMap) bool {
+
+	{
+		value0 := map[string][]uint8(t)
+		tvalue0 := map[string][]uint8(tt)
+
+		{
+			if len(value0) != len(tvalue0) {
+				return false
+			}
+			for k1, v1 := range value0 {
+				tv1, ok := tvalue0[k1]
+				if !ok {
+					return false
+				}
+
+				{
+					if len(v1) != len(tv1) {
+						return false
+					}
+					for i2 := range v1 {
+
+						if v1[i2] != tv1[i2] {
+							return false
+						}
+
+					}
+				}
+			}
+		}
+	}
+	return true
+}
+
+func (t SpecialMap) CodClone() SpecialMap {
+	var ct SpecialMap
+
+	{
+		value0 := map[string][]uint8(t)
+		var cvalue0 map[string][]uint8
+
+		if value0 != nil {
+			cvalue0 = make(map[string][]uint8, len(value0))
+			for k1, v1 := range value0 {
+				var cv1 []uint8
+
+				if v1 != nil {
+					cv1 = make([]uint8, len(v1))
+					for i2 := range v1 {
+
+						cv1[i2] = v1[i2]
+					}
+				}
+				cvalue0[k1] = cv1
+			}
+		}
+		ct = SpecialMap(cvalue0)
+	}
+	return ct
+}
+
+func (t SpecialMap) CodSize() int {
+	n := 0
+
+	{
+		value0 := map[string][]uint8(t)
+
+		{
+			n += backend.SizeVarUint64(uint64(len(value0)))
+			for k1, v1 := range value0 {
+
+				n += backend.SizeString((k1))
+				{
+					n += backend.SizeVarUint64(uint64(len(v1)))
+					for i2 := range v1 {
+
+						n += backend.SizeUint8((v1[i2]))
+					}
+				}
+			}
+		}
+	}
+	return n
+}
+
+func (t SpecialMap) EncodeCodDelta(bs []byte, tt SpecialMap) []byte {
+	return t.EncodeCod(bs)
+}
+
+func (t *SpecialMap) DecodeCodDelta(bs []byte, tt SpecialMap) (int, error) {
+	return t.DecodeCod(bs)
+}
+
+func (t SpecialMap) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *SpecialMap) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t Untrusted) EncodeCod(bs []byte) []byte {
+
+	bs = backend.WriteString(bs, (t.Name))
+
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Tags)))
+		for i1 := range t.Tags {
+
+			bs = backend.WriteString(bs, (t.Tags[i1]))
+
+		}
+	}
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Scores)))
+
+		for k1, v1 := range t.Scores {
+
+			bs = backend.WriteString(bs, (k1))
+
+			bs = backend.WriteVarUint32(bs, (v1))
+
+		}
+
+	}
+	{
+		if t.Child == nil {
+			// Zero tag indicates nil
+			bs = backend.WriteUint8(bs, 0)
+		} else {
+			bs = backend.WriteUint8(bs, 1)
+			value1 := *t.Child
+
+			bs = value1.EncodeCod(bs)
+		}
+	}
+	return bs
+}
+
+func (t *Untrusted) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *Untrusted) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	{
+		var decoded string
+		decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		t.Name = (decoded)
+	}
+
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitSlice[string](lim, length)
+		if err != nil {
+			return 0, err
+		}
+		t.Tags = backend.ResetSlice(t.Tags, length, len(bs[n:]))
 
-					if v1[i2] != tv1[i2] {
-						return false
-					}
+		for i1 := 0; i1 < int(length); i1++ {
+			t.Tags = backend.ExtendSlice(t.Tags)
 
+			{
+				var decoded string
+				decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+				if err != nil {
+					return 0, err
 				}
+				n += nOff
+				t.Tags[i1] = (decoded)
+			}
+
+			if err != nil {
+				return 0, err
 			}
 		}
 	}
 	{
-		if len(t.MultiMap) != len(tt.MultiMap) {
-			return false
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
 		}
-		for k1, v1 := range t.MultiMap {
-			tv1, ok := tt.MultiMap[k1]
-			if !ok {
-				return false
+		n += nOff
+		err = backend.LimitMap[string, uint32](lim, length)
+		if err != nil {
+			return 0, err
+		}
+
+		if t.Scores == nil {
+			t.Scores = make(map[string]uint32, backend.PreallocLen(length, len(bs[n:])))
+		} else {
+			clear(t.Scores)
+		}
+
+		for i1 := 0; i1 < int(length); i1++ {
+			var key1 string
+			var val1 uint32
+
+			{
+				var decoded string
+				decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				key1 = (decoded)
 			}
 
 			{
-				if len(v1) != len(tv1) {
-					return false
+				var decoded uint32
+				decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+				if err != nil {
+					return 0, err
 				}
-				for k2, v2 := range v1 {
-					tv2, ok := tv1[k2]
-					if !ok {
-						return false
-					}
+				n += nOff
+				val1 = (decoded)
+			}
 
-					{
-						if len(v2) != len(tv2) {
-							return false
-						}
-						for i3 := range v2 {
+			if err != nil {
+				return 0, err
+			}
 
-							if v2[i3] != tv2[i3] {
-								return false
-							}
+			t.Scores[key1] = val1
+		}
+	}
+	{
+		var tagVal uint8
+		tagVal, nOff, err = backend.ReadUint8(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
 
-						}
-					}
-				}
+		if tagVal == 0 {
+			// Zero tag indicates nil
+			t.Child = nil
+		} else {
+			var value1 Untrusted
+
+			nOff, err = value1.DecodeCodWithLimits(bs[n:], lim)
+			if err != nil {
+				return 0, err
 			}
+			n += nOff
+
+			t.Child = &value1
 		}
 	}
-	if !t.MyUnion.CodEquals(tt.MyUnion) {
+
+	// println("Untrusted:", n)
+	return n, err
+}
+
+func (t Untrusted) CodEquals(tt Untrusted) bool {
+
+	if t.Name != tt.Name {
 		return false
 	}
 
 	{
-		tNil := (t.Pointer == nil)
-		ttNil := (tt.Pointer == nil)
+		if len(t.Tags) != len(tt.Tags) {
+			return false
+		}
+		for i1 := range t.Tags {
+
+			if t.Tags[i1] != tt.Tags[i1] {
+				return false
+			}
+
+		}
+	}
+	{
+		if len(t.Scores) != len(tt.Scores) {
+			return false
+		}
+		for k1, v1 := range t.Scores {
+			tv1, ok := tt.Scores[k1]
+			if !ok {
+				return false
+			}
+
+			if v1 != tv1 {
+				return false
+			}
+
+		}
+	}
+	{
+		tNil := (t.Child == nil)
+		ttNil := (tt.Child == nil)
 		if tNil != ttNil {
 			return false
 		}
 		if !tNil && !ttNil {
-			value1 := *t.Pointer
-			tvalue1 := *tt.Pointer
+			value1 := *t.Child
+			tvalue1 := *tt.Child
 
 			if !value1.CodEquals(tvalue1) {
 				return false
@@ -943,41 +12136,269 @@ func (t Person) CodEquals(tt Person) bool {
 	return true
 }
 
-func (t SpecialMap) EncodeCod(bs []byte) []byte {
+func (t Untrusted) CodClone() Untrusted {
+	var ct Untrusted
+
+	ct.Name = t.Name
+	if t.Tags != nil {
+		ct.Tags = make([]string, len(t.Tags))
+		for i1 := range t.Tags {
+
+			ct.Tags[i1] = t.Tags[i1]
+		}
+	}
+	if t.Scores != nil {
+		ct.Scores = make(map[string]uint32, len(t.Scores))
+		for k1, v1 := range t.Scores {
+			var cv1 uint32
+
+			cv1 = v1
+			ct.Scores[k1] = cv1
+		}
+	}
+	if t.Child != nil {
+		value1 := *t.Child
+		var cvalue1 Untrusted
+
+		cvalue1 = value1.CodClone()
+		ct.Child = &cvalue1
+	}
+	return ct
+}
 
+func (t Untrusted) CodSize() int {
+	n := 0
+
+	n += backend.SizeString((t.Name))
 	{
-		value0 := map[string][]uint8(t)
+		n += backend.SizeVarUint64(uint64(len(t.Tags)))
+		for i1 := range t.Tags {
+
+			n += backend.SizeString((t.Tags[i1]))
+		}
+	}
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Scores)))
+		for k1, v1 := range t.Scores {
+
+			n += backend.SizeString((k1))
+			n += backend.SizeVarUint32((v1))
+		}
+	}
+	{
+		n += backend.SizeUint8(0) // The nil tag
+		if t.Child != nil {
+			value1 := *t.Child
+
+			n += value1.CodSize()
+		}
+	}
+	return n
+}
+
+func (t Untrusted) EncodeCodDelta(bs []byte, tt Untrusted) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 1)...)
+
+	if !func() bool {
+
+		if t.Name != tt.Name {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
+
+		bs = backend.WriteString(bs, (t.Name))
+
+	}
+
+	if !func() bool {
 
 		{
-			bs = backend.WriteVarUint64(bs, uint64(len(value0)))
+			if len(t.Tags) != len(tt.Tags) {
+				return false
+			}
+			for i1 := range t.Tags {
 
-			for k1, v1 := range value0 {
+				if t.Tags[i1] != tt.Tags[i1] {
+					return false
+				}
 
-				bs = backend.WriteString(bs, (k1))
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 1
 
-				{
-					bs = backend.WriteVarUint64(bs, uint64(len(v1)))
-					for i2 := range v1 {
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Tags)))
+			for i1 := range t.Tags {
 
-						bs = backend.WriteUint8(bs, (v1[i2]))
+				bs = backend.WriteString(bs, (t.Tags[i1]))
 
-					}
+			}
+		}
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Scores) != len(tt.Scores) {
+				return false
+			}
+			for k1, v1 := range t.Scores {
+				tv1, ok := tt.Scores[k1]
+				if !ok {
+					return false
+				}
+
+				if v1 != tv1 {
+					return false
 				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 2
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Scores)))
+
+			for k1, v1 := range t.Scores {
+
+				bs = backend.WriteString(bs, (k1))
+
+				bs = backend.WriteVarUint32(bs, (v1))
+
+			}
+
+		}
+	}
+
+	if !func() bool {
+
+		{
+			tNil := (t.Child == nil)
+			ttNil := (tt.Child == nil)
+			if tNil != ttNil {
+				return false
 			}
+			if !tNil && !ttNil {
+				value1 := *t.Child
+				tvalue1 := *tt.Child
+
+				if !value1.CodEquals(tvalue1) {
+					return false
+				}
 
+			}
 		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 3
 
+		{
+			if t.Child == nil {
+				// Zero tag indicates nil
+				bs = backend.WriteUint8(bs, 0)
+			} else {
+				bs = backend.WriteUint8(bs, 1)
+				value1 := *t.Child
+
+				bs = value1.EncodeCod(bs)
+			}
+		}
 	}
+
 	return bs
 }
 
-func (t *SpecialMap) DecodeCod(bs []byte) (int, error) {
+func (t *Untrusted) DecodeCodDelta(bs []byte, tt Untrusted) (int, error) {
 	var err error
 	var n int
 	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 1 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:1]
+	n += 1
+
+	if mask[0]&(1<<0) != 0 {
+
+		{
+			var decoded string
+			decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Name = (decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Name = t.Name
+	}
+
+	if mask[0]&(1<<1) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[string](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			t.Tags = backend.ResetSlice(t.Tags, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				t.Tags = backend.ExtendSlice(t.Tags)
+
+				{
+					var decoded string
+					decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					t.Tags[i1] = (decoded)
+				}
+
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Tags != nil {
+			ct.Tags = make([]string, len(t.Tags))
+			for i1 := range t.Tags {
+
+				ct.Tags[i1] = t.Tags[i1]
+			}
+		}
+	}
 
-	{
-		var value0 map[string][]uint8
+	if mask[0]&(1<<2) != 0 {
 
 		{
 			var length uint64
@@ -986,18 +12407,24 @@ func (t *SpecialMap) DecodeCod(bs []byte) (int, error) {
 				return 0, err
 			}
 			n += nOff
+			err = backend.LimitMap[string, uint32](lim, length)
+			if err != nil {
+				return 0, err
+			}
 
-			if value0 == nil {
-				value0 = make(map[string][]uint8)
+			if t.Scores == nil {
+				t.Scores = make(map[string]uint32, backend.PreallocLen(length, len(bs[n:])))
+			} else {
+				clear(t.Scores)
 			}
 
 			for i1 := 0; i1 < int(length); i1++ {
 				var key1 string
-				var val1 []uint8
+				var val1 uint32
 
 				{
 					var decoded string
-					decoded, nOff, err = backend.ReadString(bs[n:])
+					decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
 					if err != nil {
 						return 0, err
 					}
@@ -1006,77 +12433,358 @@ func (t *SpecialMap) DecodeCod(bs []byte) (int, error) {
 				}
 
 				{
-					var length uint64
-					length, nOff, err = backend.ReadVarUint64(bs[n:])
+					var decoded uint32
+					decoded, nOff, err = backend.ReadVarUint32(bs[n:])
 					if err != nil {
 						return 0, err
 					}
 					n += nOff
+					val1 = (decoded)
+				}
 
-					for i2 := 0; i2 < int(length); i2++ {
-						var value2 uint8
+				if err != nil {
+					return 0, err
+				}
 
-						{
-							var decoded uint8
-							decoded, nOff, err = backend.ReadUint8(bs[n:])
-							if err != nil {
-								return 0, err
-							}
-							n += nOff
-							value2 = (decoded)
-						}
+				t.Scores[key1] = val1
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Scores != nil {
+			ct.Scores = make(map[string]uint32, len(t.Scores))
+			for k1, v1 := range t.Scores {
+				var cv1 uint32
+
+				cv1 = v1
+				ct.Scores[k1] = cv1
+			}
+		}
+	}
 
-						if err != nil {
-							return 0, err
-						}
+	if mask[0]&(1<<3) != 0 {
 
-						val1 = append(val1, value2)
-					}
-				}
+		{
+			var tagVal uint8
+			tagVal, nOff, err = backend.ReadUint8(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+
+			if tagVal == 0 {
+				// Zero tag indicates nil
+				t.Child = nil
+			} else {
+				var value1 Untrusted
+
+				nOff, err = value1.DecodeCodWithLimits(bs[n:], lim)
 				if err != nil {
 					return 0, err
 				}
+				n += nOff
 
-				value0[key1] = val1
+				t.Child = &value1
 			}
 		}
-		*t = SpecialMap(value0)
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Child != nil {
+			value1 := *t.Child
+			var cvalue1 Untrusted
+
+			cvalue1 = value1.CodClone()
+			ct.Child = &cvalue1
+		}
 	}
 
-	// println("SpecialMap:", n)
 	return n, err
 }
 
-func (t SpecialMap) CodEquals(tt SpecialMap) bool {
+func (t Untrusted) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
 
-	{
-		value0 := map[string][]uint8(t)
-		tvalue0 := map[string][]uint8(tt)
+func (t *Untrusted) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
 
-		{
-			if len(value0) != len(tvalue0) {
-				return false
-			}
-			for k1, v1 := range value0 {
-				tv1, ok := tvalue0[k1]
-				if !ok {
-					return false
-				}
+func (t VarintUnion) EncodeCod(bs []byte) []byte {
 
-				{
-					if len(v1) != len(tv1) {
-						return false
-					}
-					for i2 := range v1 {
+	tag := t.Tag()
+	bs = backend.WriteVarUint64(bs, tag)
+	if tag == 0 {
+		// Zero tag indicates nil, so write nothing else
+		return bs
+	}
 
-						if v1[i2] != tv1[i2] {
-							return false
-						}
+	rawVal := t.Get()
+	bs = rawVal.EncodeCod(bs)
 
-					}
-				}
-			}
+	return bs
+}
+
+func (t *VarintUnion) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *VarintUnion) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	var tagVal uint64
+
+	tagVal, nOff, err = backend.ReadVarUint64(bs[n:])
+	if err != nil {
+		return 0, err
+	}
+	n += nOff
+
+	switch tagVal {
+	case 0: // Zero tag indicates nil
+		*t = VarintUnion{}
+		return n, nil
+
+	case 1:
+		var decoded Id
+		nOff, err = decoded.DecodeCodWithLimits(bs[n:], lim)
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+
+		t.SetId(decoded)
+
+	case 300:
+		var decoded subpackage.Vec
+		nOff, err = decoded.DecodeCodWithLimits(bs[n:], lim)
+		if err != nil {
+			return 0, err
 		}
+		n += nOff
+
+		t.SetVec(decoded)
+
+	default:
+		return 0, backend.ErrUnknownUnionType
 	}
-	return true
+
+	// println("VarintUnion:", n)
+	return n, err
+}
+
+func (t VarintUnion) Tag() uint64 {
+	rawVal := t.Get()
+	if rawVal == nil {
+		// Zero tag indicates nil
+		return 0
+	}
+
+	switch rawVal.(type) {
+
+	case Id:
+		return 1
+
+	case subpackage.Vec:
+		return 300
+
+	default:
+		panic(fmt.Sprintf("unknown type placed in union: %T", rawVal))
+	}
+}
+
+func (t VarintUnion) Size() int {
+	return 301
+}
+
+func (t VarintUnion) CodEquals(tt VarintUnion) bool {
+	if t.Tag() != tt.Tag() {
+		return false
+	}
+
+	rawVal := t.Get()
+	switch sv := rawVal.(type) {
+	case nil:
+		return true
+
+	case Id:
+		sv2 := tt.Get().(Id)
+		return sv.CodEquals(sv2)
+
+	case subpackage.Vec:
+		sv2 := tt.Get().(subpackage.Vec)
+		return sv.CodEquals(sv2)
+
+	default:
+		panic(fmt.Sprintf("unknown type placed in union: %T", rawVal))
+	}
+}
+
+func (t VarintUnion) CodClone() VarintUnion {
+	var ct VarintUnion
+
+	rawVal := t.Get()
+	switch sv := rawVal.(type) {
+	case nil:
+
+	case Id:
+		ct.SetId(sv.CodClone())
+
+	case subpackage.Vec:
+		ct.SetVec(sv.CodClone())
+
+	default:
+		panic(fmt.Sprintf("unknown type placed in union: %T", rawVal))
+	}
+	return ct
+}
+
+func (t VarintUnion) EncodeCodDelta(bs []byte, tt VarintUnion) []byte {
+	tag := t.Tag()
+	if tag == 0 || tag != tt.Tag() {
+		// The type changed, so write the full value
+		return t.EncodeCod(bs)
+	}
+
+	bs = backend.WriteVarUint64(bs, tag)
+	switch sv := t.Get().(type) {
+	case Id:
+		bs = sv.EncodeCodDelta(bs, tt.Get().(Id))
+	case subpackage.Vec:
+		bs = sv.EncodeCodDelta(bs, tt.Get().(subpackage.Vec))
+	}
+	return bs
+}
+
+func (t *VarintUnion) DecodeCodDelta(bs []byte, tt VarintUnion) (int, error) {
+	tagVal, n, err := backend.ReadVarUint64(bs)
+	if err != nil {
+		return 0, err
+	}
+	if tagVal == 0 || tagVal != tt.Tag() {
+		return t.DecodeCod(bs)
+	}
+
+	var nOff int
+	switch tagVal {
+	case 1:
+		var decoded Id
+		nOff, err = decoded.DecodeCodDelta(bs[n:], tt.Get().(Id))
+		if err != nil {
+			return 0, err
+		}
+		t.SetId(decoded)
+	case 300:
+		var decoded subpackage.Vec
+		nOff, err = decoded.DecodeCodDelta(bs[n:], tt.Get().(subpackage.Vec))
+		if err != nil {
+			return 0, err
+		}
+		t.SetVec(decoded)
+	}
+	return n + nOff, nil
+}
+
+func (t VarintUnion) CodSize() int {
+	n := backend.SizeVarUint64(t.Tag())
+
+	rawVal := t.Get()
+	switch sv := rawVal.(type) {
+
+	case Id:
+		n += sv.CodSize()
+
+	case subpackage.Vec:
+		n += sv.CodSize()
+
+	}
+	return n
+}
+
+func (t VarintUnion) Get() cod.EncoderDecoder {
+	codUnion := cod.Union(t)
+	rawVal := codUnion.GetRawValue()
+	return rawVal
+
+	// switch rawVal.(type) {
+	// <no value>
+	// default:
+	//    panic("unknown type placed in union")
+	// }
+}
+
+func (t *VarintUnion) Set(v cod.EncoderDecoder) {
+	switch v.(type) {
+	case nil, Id, subpackage.Vec:
+	default:
+		panic(fmt.Sprintf("%T is not a member of union VarintUnion", v))
+	}
+
+	codUnion := cod.Union(*t)
+	codUnion.PutRawValue(v)
+	*t = VarintUnion(codUnion)
+}
+
+func NewVarintUnion(v cod.EncoderDecoder) VarintUnion {
+	var ret VarintUnion
+	ret.Set(v)
+	return ret
+}
+
+func NewVarintUnionFromId(v Id) VarintUnion {
+	var ret VarintUnion
+	ret.SetId(v)
+	return ret
+}
+
+func (t VarintUnion) GetId() (Id, bool) {
+	v, ok := t.Get().(Id)
+	return v, ok
+}
+
+func (t *VarintUnion) SetId(v Id) {
+	codUnion := cod.Union(*t)
+	codUnion.PutRawValue(v)
+	*t = VarintUnion(codUnion)
+}
+
+func NewVarintUnionFromVec(v subpackage.Vec) VarintUnion {
+	var ret VarintUnion
+	ret.SetVec(v)
+	return ret
+}
+
+func (t VarintUnion) GetVec() (subpackage.Vec, bool) {
+	v, ok := t.Get().(subpackage.Vec)
+	return v, ok
+}
+
+func (t *VarintUnion) SetVec(v subpackage.Vec) {
+	codUnion := cod.Union(*t)
+	codUnion.PutRawValue(v)
+	*t = VarintUnion(codUnion)
+}
+
+func (t VarintUnion) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *VarintUnion) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
 }
@@ -0,0 +1,126 @@
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/unitoftime/cod/gen"
+)
+
+func loadTestSchema() gen.Schema {
+	bs, err := os.ReadFile("cod_schema.json")
+	if err != nil { panic(err) }
+	var schema gen.Schema
+	err = json.Unmarshal(bs, &schema)
+	if err != nil { panic(err) }
+	return schema
+}
+
+func findType(schema gen.Schema, name string) *gen.TypeSchema {
+	for i := range schema.Types {
+		if schema.Types[i].Name == name { return &schema.Types[i] }
+	}
+	panic("missing type " + name)
+}
+
+func checkChanges(t *testing.T, changes []gen.Change, expected ...string) {
+	t.Helper()
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %d changes, got %v", len(expected), changes)
+	}
+	for i := range expected {
+		if changes[i].String() != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], changes[i].String())
+		}
+	}
+}
+
+func TestCompareSchemasUnchanged(t *testing.T) {
+	changes := gen.CompareSchemas(loadTestSchema(), loadTestSchema())
+	if len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+}
+
+func TestCompareSchemasStructs(t *testing.T) {
+	old := loadTestSchema()
+	new := loadTestSchema()
+
+	// Swapping fields moves them both
+	person := findType(new, "Person")
+	person.Fields[0], person.Fields[1] = person.Fields[1], person.Fields[0]
+
+	// Fixed and varint integers have different encodings. Varints of the same size are the same, and widening them can only be read by the new version. Narrowing them is breaking
+	fixed := findType(new, "FixedInts")
+	fixed.Fields[1].Encoding.Kind = "varuint32"
+	fixed.Fields[8].Encoding.Kind = "uint"
+	findType(new, "NamedBasics").Fields[0].Encoding.Kind = "varuint64"
+	findType(new, "NamedBasics").Fields[1].Encoding.Elem.Kind = "varuint16"
+
+	// Renames don't change compact structs
+	findType(new, "Id").Fields[0].Name = "Value"
+
+	// Evolvable structs can add fields
+	save := findType(new, "SaveV1")
+	save.Fields = append(save.Fields, gen.FieldSchema{Name: "Gold", Number: 5, Encoding: gen.EncodingSchema{Kind: "varuint64"}})
+
+	checkChanges(t, gen.CompareSchemas(old, new),
+		"breaking: FixedInts: field Id changed from uint32 to varuint32",
+		"compatible: FixedInts: field Varint changed from varuint64 to uint",
+		"compatible: Id: field Val was renamed to Value",
+		"forward-only: NamedBasics: field Tick changed from varuint32 to varuint64",
+		"breaking: NamedBasics: field Ticks changed from []varuint32 to []varuint16",
+		"breaking: Person: field Name moved from position 1 to 2",
+		"breaking: Person: field Age moved from position 2 to 1",
+		"compatible: SaveV1: field Gold (number 5) was added",
+	)
+
+	// Compact structs can't add fields
+	findType(old, "Untrusted").Fields = findType(old, "Untrusted").Fields[:3]
+	checkChanges(t, gen.CompareSchemas(old, loadTestSchema()),
+		"breaking: Untrusted: field Child was added (fields can only be added to evolvable structs)",
+	)
+}
+
+func TestCompareSchemasUnions(t *testing.T) {
+	old := loadTestSchema()
+	new := loadTestSchema()
+
+	// Removing a variant shifts the tags of the ones after it
+	union := findType(new, "MyUnion")
+	union.Variants = []gen.VariantSchema{
+		{Tag: 1, Name: "SpecialMap", Type: "SpecialMap"},
+		{Tag: 2, Name: "Vec", Type: "subpackage.Vec"},
+	}
+
+	// New tags can be added
+	pinned := findType(new, "PinnedUnion")
+	pinned.Variants = append(pinned.Variants, gen.VariantSchema{Tag: 201, Name: "Person", Type: "Person"})
+
+	findType(new, "VarintUnion").TagEncoding = "uint8"
+
+	checkChanges(t, gen.CompareSchemas(old, new),
+		"breaking: MyUnion: variant Id (tag 1) was removed",
+		"breaking: MyUnion: variant SpecialMap moved from tag 2 to 1",
+		"breaking: MyUnion: variant subpackage.Vec moved from tag 3 to 2",
+		"forward-only: PinnedUnion: variant Person was added with tag 201",
+		"breaking: VarintUnion: tag changed from varuint64 to uint8",
+	)
+}
+
+// Forward-only changes only fail the check at the forward-only level (cod compat -strict)
+func TestCompareSchemasForwardOnly(t *testing.T) {
+	old := loadTestSchema()
+	new := loadTestSchema()
+	findType(new, "NamedBasics").Fields[0].Encoding.Kind = "varuint64"
+
+	changes := gen.CompareSchemas(old, new)
+	checkChanges(t, changes, "forward-only: NamedBasics: field Tick changed from varuint32 to varuint64")
+	if gen.HasBreakingChanges(changes) {
+		t.Error("expected no breaking changes")
+	}
+	if !gen.HasChanges(changes, gen.ForwardOnly) {
+		t.Error("expected a forward-only change")
+	}
+}
@@ -0,0 +1,42 @@
+package test
+
+import (
+	"github.com/unitoftime/cod"
+	"github.com/unitoftime/cod/backend"
+)
+
+// A hand written type with only the required methods. The generated code falls back for the others
+type Custom struct {
+	Val uint32
+}
+
+func (t Custom) EncodeCod(bs []byte) []byte {
+	return backend.WriteVarUint32(bs, t.Val)
+}
+
+func (t *Custom) DecodeCod(bs []byte) (int, error) {
+	var n int
+	var err error
+	t.Val, n, err = backend.ReadVarUint32(bs)
+	return n, err
+}
+
+func (t Custom) CodEquals(tt Custom) bool {
+	return t == tt
+}
+
+//cod:struct
+type CustomFields struct {
+	Custom Custom
+	Customs []Custom
+	Union CustomUnion
+}
+
+//cod:union CustomUnionDef
+type CustomUnion cod.Union
+
+//cod:def
+type CustomUnionDef struct {
+	Custom
+	Id
+}
@@ -0,0 +1,55 @@
+package test
+
+import (
+	"testing"
+)
+
+func TestCustomFields(t *testing.T) {
+	d := CustomFields{
+		Custom: Custom{Val: 300},
+		Customs: []Custom{{1}, {1 << 20}},
+		Union: NewCustomUnionFromCustom(Custom{Val: 5}),
+	}
+	checkSize(t, d)
+	checkSize(t, d.Union)
+
+	bs := d.EncodeCod(nil)
+	res := CustomFields{}
+	_, err := res.DecodeCod(bs)
+	if err != nil { panic(err) }
+	if !d.CodEquals(res) {
+		t.Errorf("expected %v, got %v", d, res)
+	}
+
+	c := d.CodClone()
+	if !d.CodEquals(c) {
+		t.Errorf("expected %v, got %v", d, c)
+	}
+	c.Customs[0].Val = 2
+	if d.Customs[0].Val != 1 {
+		t.Error("original was changed by the clone")
+	}
+}
+
+// Hand written types without delta functions are written in full when they change
+func TestCustomDelta(t *testing.T) {
+	base := CustomFields{
+		Custom: Custom{Val: 300},
+		Customs: []Custom{{1}},
+		Union: NewCustomUnionFromCustom(Custom{Val: 5}),
+	}
+	d := base.CodClone()
+	d.Custom.Val = 301
+	d.Union.SetCustom(Custom{Val: 6})
+
+	bs := d.EncodeCodDelta(nil, base)
+	res := CustomFields{}
+	n, err := res.DecodeCodDelta(bs, base)
+	if err != nil { panic(err) }
+	if n != len(bs) {
+		t.Errorf("decoded %d bytes, expected %d", n, len(bs))
+	}
+	if !d.CodEquals(res) {
+		t.Errorf("expected %v, got %v", d, res)
+	}
+}
@@ -0,0 +1,122 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/unitoftime/cod/test/subpackage"
+	"github.com/unitoftime/cod/test/subpackage/blocked"
+)
+
+func deltaTestPerson() Person {
+	return Person{
+		Name: "hello",
+		Age: 5,
+		Id: Id{7},
+		Array: [2]uint16{8, 9},
+		Slice: []uint32{100, 101, 102},
+		DoubleSlice: [][]uint8{{1, 2, 3}, {4, 5, 6}},
+		Map: map[string][]uint64{
+			"a": {1000, 2000, 3000},
+		},
+		MultiMap: map[string]map[uint32][]uint8{
+			"c": {1: {11, 12}},
+		},
+		MyUnion: NewMyUnion(subpackage.Vec{X: 1, Y: 2}),
+		Pointer: &BlockedStruct{
+			Basic: blocked.Basic(1),
+		},
+	}
+}
+
+func TestDeltaUnchanged(t *testing.T) {
+	base := deltaTestPerson()
+	bs := base.EncodeCodDelta(nil, base)
+
+	// Only the 2 byte mask is written
+	if len(bs) != 2 {
+		t.Errorf("expected 2 bytes, got %d", len(bs))
+	}
+
+	res := Person{}
+	n, err := res.DecodeCodDelta(bs, base)
+	if err != nil { panic(err) }
+	if n != len(bs) {
+		t.Errorf("decoded %d bytes, expected %d", n, len(bs))
+	}
+	if !base.CodEquals(res) {
+		t.Errorf("expected %v, got %v", base, res)
+	}
+
+	// Unchanged fields are copied, so changing them must not change the base
+	res.Slice[0] = 0
+	res.Map["a"][0] = 0
+	if base.Slice[0] != 100 || base.Map["a"][0] != 1000 {
+		t.Error("base was changed by the decoded value")
+	}
+}
+
+func TestDeltaChanged(t *testing.T) {
+	base := deltaTestPerson()
+	d := deltaTestPerson()
+	d.Age = 6
+	d.Id = Id{8}
+	d.MultiMap["c"][1] = []uint8{13}
+	d.MyUnion = NewMyUnion(subpackage.Vec{X: 1, Y: 3})
+
+	bs := d.EncodeCodDelta(nil, base)
+	if len(bs) >= len(d.EncodeCod(nil)) {
+		t.Errorf("expected the delta (%d bytes) to be smaller than the full encoding (%d bytes)", len(bs), len(d.EncodeCod(nil)))
+	}
+
+	res := Person{}
+	n, err := res.DecodeCodDelta(bs, base)
+	if err != nil { panic(err) }
+	if n != len(bs) {
+		t.Errorf("decoded %d bytes, expected %d", n, len(bs))
+	}
+	if !d.CodEquals(res) {
+		t.Errorf("expected %v, got %v", d, res)
+	}
+
+	// Decoding into the base itself
+	n, err = base.DecodeCodDelta(bs, base)
+	if err != nil { panic(err) }
+	if n != len(bs) {
+		t.Errorf("decoded %d bytes, expected %d", n, len(bs))
+	}
+	if !d.CodEquals(base) {
+		t.Errorf("expected %v, got %v", d, base)
+	}
+}
+
+func TestDeltaUnionTypeChanged(t *testing.T) {
+	base := NewMyUnion(subpackage.Vec{X: 1, Y: 2})
+	d := NewMyUnion(Id{5})
+
+	bs := d.EncodeCodDelta(nil, base)
+	res := MyUnion{}
+	n, err := res.DecodeCodDelta(bs, base)
+	if err != nil { panic(err) }
+	if n != len(bs) {
+		t.Errorf("decoded %d bytes, expected %d", n, len(bs))
+	}
+	if !d.CodEquals(res) {
+		t.Errorf("expected %v, got %v", d, res)
+	}
+}
+
+func TestDeltaTruncated(t *testing.T) {
+	base := deltaTestPerson()
+	d := deltaTestPerson()
+	d.Name = "changed"
+	d.Slice = append(d.Slice, 103)
+
+	bs := d.EncodeCodDelta(nil, base)
+	for i := 0; i < len(bs); i++ {
+		res := Person{}
+		_, err := res.DecodeCodDelta(bs[:i], base)
+		if err == nil {
+			t.Errorf("expected an error when decoding %d of %d bytes", i, len(bs))
+		}
+	}
+}
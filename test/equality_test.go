@@ -2,6 +2,7 @@ package test
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/unitoftime/cod"
@@ -298,3 +299,36 @@ func TestPersonEqualityNotEqual(t *testing.T) {
 		t.Error("SHOULD HAVE MISMATCHED")
 	}
 }
+
+func TestFloatEquality(t *testing.T) {
+	nan := math.NaN()
+	negZero := math.Copysign(0, -1)
+
+	tests := []struct{
+		a, b Floats
+		equal bool
+	}{
+		{Floats{F32: 1.5, F64: 2.5}, Floats{F32: 1.5, F64: 2.5}, true},
+		{Floats{F32: 1.5}, Floats{F32: 2.5}, false},
+		{Floats{F64: nan}, Floats{F64: nan}, true},
+		{Floats{F32: float32(nan)}, Floats{F32: float32(nan)}, true},
+		{Floats{F64: nan}, Floats{F64: 1}, false},
+		{Floats{Vals: []float64{1, nan}}, Floats{Vals: []float64{1, nan}}, true},
+		{Floats{F64: negZero}, Floats{F64: 0}, false},
+		{Floats{Vals: []float64{negZero}}, Floats{Vals: []float64{0}}, false},
+	}
+	for i, test := range tests {
+		if test.a.CodEquals(test.b) != test.equal {
+			t.Errorf("test %d: expected CodEquals to be %v for %v and %v", i, test.equal, test.a, test.b)
+		}
+	}
+
+	// A decoded value always equals the value that was encoded
+	d := Floats{F32: float32(nan), F64: negZero, Vals: []float64{nan, negZero}}
+	res := Floats{}
+	_, err := res.DecodeCod(d.EncodeCod(nil))
+	if err != nil { panic(err) }
+	if !d.CodEquals(res) {
+		t.Errorf("expected %v, got %v", d, res)
+	}
+}
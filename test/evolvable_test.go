@@ -0,0 +1,107 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/unitoftime/cod/backend"
+	"github.com/unitoftime/cod/test/subpackage"
+)
+
+func TestEvolvableRoundtrip(t *testing.T) {
+	d := SaveV2{
+		Name: "hello",
+		Items: []string{"a", "b"},
+		Pos: subpackage.Vec{X: 1, Y: 2},
+		Gold: 1000,
+		Inventory: map[string]uint8{"sword": 1},
+	}
+
+	bs := d.EncodeCod(nil)
+	if d.CodSize() != len(bs) {
+		t.Errorf("CodSize %d != encoded length %d", d.CodSize(), len(bs))
+	}
+
+	res := SaveV2{}
+	n, err := res.DecodeCod(bs)
+	if err != nil { panic(err) }
+	if n != len(bs) {
+		t.Errorf("decoded %d bytes, expected %d", n, len(bs))
+	}
+	if !d.CodEquals(res) {
+		t.Error("MISMATCH")
+	}
+}
+
+func TestEvolvableNewReadsOld(t *testing.T) {
+	old := SaveV1{
+		Name: "hello",
+		Level: 5,
+		Items: []string{"a", "b"},
+		Pos: subpackage.Vec{X: 1, Y: 2},
+	}
+
+	bs := old.EncodeCod(nil)
+
+	// Fill in the fields to make sure the missing ones are reset
+	res := SaveV2{Gold: 7, Inventory: map[string]uint8{"shield": 1}}
+	n, err := res.DecodeCod(bs)
+	if err != nil { panic(err) }
+	if n != len(bs) {
+		t.Errorf("decoded %d bytes, expected %d", n, len(bs))
+	}
+
+	expected := SaveV2{
+		Name: old.Name,
+		Items: old.Items,
+		Pos: old.Pos,
+	}
+	if !expected.CodEquals(res) {
+		t.Errorf("expected %v, got %v", expected, res)
+	}
+}
+
+func TestEvolvableOldReadsNew(t *testing.T) {
+	d := SaveV2{
+		Name: "hello",
+		Items: []string{"a", "b"},
+		Pos: subpackage.Vec{X: 1, Y: 2},
+		Gold: 1000,
+		Inventory: map[string]uint8{"sword": 1},
+	}
+
+	// Written as if it were nested in a SaveFile, so that the decoder must stop at the end of the evolvable struct
+	bs := d.EncodeCod(nil)
+	bs = backend.WriteVarUint32(bs, 1234)
+
+	res := SaveFile{}
+	n, err := res.DecodeCod(bs)
+	if err != nil { panic(err) }
+	if n != len(bs) {
+		t.Errorf("decoded %d bytes, expected %d", n, len(bs))
+	}
+
+	expected := SaveFile{
+		Save: SaveV1{
+			Name: d.Name,
+			Items: d.Items,
+			Pos: d.Pos,
+		},
+		Checksum: 1234,
+	}
+	if !expected.CodEquals(res) {
+		t.Errorf("expected %v, got %v", expected, res)
+	}
+}
+
+func TestEvolvableTruncated(t *testing.T) {
+	d := SaveV1{Name: "hello", Items: []string{"a"}}
+	bs := d.EncodeCod(nil)
+
+	for i := 0; i < len(bs); i++ {
+		res := SaveV1{}
+		_, err := res.DecodeCod(bs[:i])
+		if err == nil {
+			t.Errorf("expected an error when decoding %d of %d bytes", i, len(bs))
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package test
 
 import (
+	"bytes"
+	"fmt"
 	"testing"
 
 	"github.com/unitoftime/cod/test/subpackage"
@@ -42,6 +44,158 @@ func TestBlockedStruct2(t *testing.T) {
 	t.Log(res)
 }
 
+func TestNamedBasics(t *testing.T) {
+	d := NamedBasics{
+		Tick: 5,
+		Ticks: []Tick{1, 2, 3},
+		Byte: 'b',
+		Rune: 'r',
+		Basics: map[blocked.Basic]Tick{
+			blocked.Basic(1): 100,
+			blocked.Basic(2): 200,
+		},
+		Basic: blocked.Basic(40000),
+	}
+
+	res := NamedBasics{}
+
+	bs := []byte{}
+	bs = d.EncodeCod(bs)
+	_, err := res.DecodeCod(bs)
+	if err != nil { panic(err) }
+
+	if !d.CodEquals(res) {
+		t.Error("MISMATCH")
+	}
+}
+
+func TestFixedInts(t *testing.T) {
+	d := FixedInts{
+		Hash: 0xdeadbeefcafef00d,
+		Id: 7,
+		Offset: -3,
+		Count: -1000,
+		Tick: 9,
+		Coords: [2]int32{-1, 1},
+		Ids: []uint32{1, 2, 3},
+		Lookup: map[uint16]int64{1: -1, 2: -2},
+		Varint: 1,
+	}
+
+	res := FixedInts{}
+
+	bs := []byte{}
+	bs = d.EncodeCod(bs)
+	_, err := res.DecodeCod(bs)
+	if err != nil { panic(err) }
+
+	if !d.CodEquals(res) {
+		t.Error("MISMATCH")
+	}
+
+	// Every integer is fixed width except the length prefixes and the final varint
+	expected := 8 + 4 + 2 + 8 + 4 + (2 * 4) + (1 + 3 * 4) + (1 + 2 * (2 + 8)) + 1
+	if len(bs) != expected {
+		t.Errorf("expected %d bytes, got %d", expected, len(bs))
+	}
+}
+
+func TestPinnedUnion(t *testing.T) {
+	tests := []struct{
+		value PinnedUnion
+		tag byte
+	}{
+		{NewPinnedUnion(Id{Val: 5}), 7},
+		{NewPinnedUnion(SpecialMap{"a": []uint8{1}}), 2},
+		{NewPinnedUnion(subpackage.Vec{X: 1, Y: 2}), 200},
+	}
+
+	for _, test := range tests {
+		bs := test.value.EncodeCod(nil)
+		if bs[0] != test.tag {
+			t.Errorf("expected tag %d, got %d", test.tag, bs[0])
+		}
+
+		res := PinnedUnion{}
+		_, err := res.DecodeCod(bs)
+		if err != nil { panic(err) }
+
+		if !test.value.CodEquals(res) {
+			t.Error("MISMATCH")
+		}
+	}
+}
+
+func TestVarintUnion(t *testing.T) {
+	tests := []struct{
+		value VarintUnion
+		tag []byte
+	}{
+		{VarintUnion{}, []byte{0}},
+		{NewVarintUnion(Id{Val: 5}), []byte{1}},
+		{NewVarintUnion(subpackage.Vec{X: 1, Y: 2}), []byte{0xac, 0x02}}, // 300 as a uvarint
+	}
+
+	for _, test := range tests {
+		bs := test.value.EncodeCod(nil)
+		for i := range test.tag {
+			if bs[i] != test.tag[i] {
+				t.Errorf("expected tag bytes %v, got %v", test.tag, bs[:len(test.tag)])
+				break
+			}
+		}
+
+		res := VarintUnion{}
+		_, err := res.DecodeCod(bs)
+		if err != nil { panic(err) }
+
+		if res.Tag() != test.value.Tag() {
+			t.Errorf("expected tag %d, got %d", test.value.Tag(), res.Tag())
+		}
+		if test.value.CodSize() != len(bs) {
+			t.Errorf("expected size %d, got %d", len(bs), test.value.CodSize())
+		}
+	}
+}
+
+func TestSortedMaps(t *testing.T) {
+	newSortedMaps := func(reverse bool) SortedMaps {
+		d := SortedMaps{
+			Names: make(map[string]uint8),
+			Ticks: make(map[Tick]map[int8]string),
+			Positions: make(map[Id]uint8),
+			Inventory: subpackage.Inventory{Items: make(map[string]uint32), Slots: make(map[subpackage.Vec]string)},
+		}
+		for i := 0; i < 100; i++ {
+			k := i
+			if reverse { k = 99 - i }
+			name := fmt.Sprintf("name%d", k)
+			d.Names[name] = uint8(k)
+			d.Ticks[Tick(k)] = map[int8]string{int8(-k): name, int8(k): name}
+			d.Positions[Id{Val: uint16(k * 7)}] = uint8(k)
+			d.Inventory.Items[name] = uint32(k)
+			d.Inventory.Slots[subpackage.Vec{X: uint64(k), Y: uint64(99 - k)}] = name
+		}
+		return d
+	}
+
+	d := newSortedMaps(false)
+	expected := d.EncodeCod(nil)
+	for i := 0; i < 10; i++ {
+		bs := newSortedMaps(i % 2 == 0).EncodeCod(nil)
+		if !bytes.Equal(expected, bs) {
+			t.Fatal("encoding is not deterministic")
+		}
+	}
+
+	res := SortedMaps{}
+	_, err := res.DecodeCod(expected)
+	if err != nil { panic(err) }
+
+	if !d.CodEquals(res) {
+		t.Error("MISMATCH")
+	}
+}
 
 func TestBlankStruct(t *testing.T) {
 	d := BlankStruct{}
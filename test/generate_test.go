@@ -0,0 +1,289 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/unitoftime/cod/gen"
+)
+
+// The generated files are checked in, so they must match what the generator produces
+func TestGenerateUpToDate(t *testing.T) {
+	for _, dir := range []string{".", "subpackage", "subpackage/blocked"} {
+		files, diags, err := gen.Generate(dir, gen.Config{Fuzz: true, Schema: true})
+		if err != nil { panic(err) }
+		if gen.HasErrors(diags) {
+			t.Fatalf("%s: unexpected diagnostics: %v", dir, diags)
+		}
+
+		for filename, generated := range files {
+			onDisk, err := os.ReadFile(filename)
+			if err != nil { panic(err) }
+			if !bytes.Equal(onDisk, generated) {
+				t.Errorf("%s is out of date", filename)
+			}
+		}
+	}
+}
+
+func TestGenerateDiagnostics(t *testing.T) {
+	dir := t.TempDir()
+	src := `package bad
+
+//cod:strcut
+type Typo struct{}
+
+//cod:struct
+type Bad struct {
+	Any interface{}
+	Missing Undefined
+	Events chan int
+	Callback func()
+	Anon struct{ X int }
+	Lengths [Size]uint8
+	Skipped chan int `+"`cod.skip:\"serdes,equality\"`"+`
+}
+
+//cod:struct
+type Box[T interface{ EncodeCod([]byte) []byte }] struct {
+	Val T
+}
+
+type Codec[T any] interface {
+	EncodeCod([]byte) []byte
+	CodEquals(T) bool
+	CodSize() int
+	CodClone() T
+}
+
+//cod:struct
+type NoPtr[T Codec[T]] struct {
+	Val T
+}
+
+//cod:struct
+type BadPtr[T Codec[T], PT interface{ *T }] struct {
+	Val T
+	Ptr PT
+}
+
+//cod:struct
+type Good struct {
+	Val uint32
+}
+`
+	err := os.WriteFile(filepath.Join(dir, "bad.go"), []byte(src), 0644)
+	if err != nil { panic(err) }
+
+	files, diags, err := gen.Generate(dir, gen.Config{})
+	if err != nil { panic(err) }
+	if len(files) != 0 {
+		t.Errorf("expected no files for a package with errors, got %d", len(files))
+	}
+
+	expected := []string{
+		"bad.go:3:1: error: unknown directive //cod:strcut",
+		"bad.go:8:6: error: unsupported field type interface{}",
+		"bad.go:9:10: error: couldn't resolve field type Undefined: undefined: Undefined",
+		"bad.go:10:9: error: unsupported field type chan int: channels can't be encoded",
+		"bad.go:11:11: error: unsupported field type func(): funcs can't be encoded",
+		"bad.go:12:7: error: unsupported field type struct{X int}: anonymous structs can't be encoded, use a type tagged with //cod:struct",
+		"bad.go:13:11: error: undefined array length Size or missing type constraint",
+		"bad.go:18:10: error: type parameter T of Box must be constrained to cod.Codec[T]",
+		"bad.go:30:12: error: type parameter T of NoPtr needs a pointer type parameter constrained to cod.CodecPtr[T]",
+		"bad.go:35:25: error: type parameter PT of BadPtr must be constrained to cod.CodecPtr[T]",
+		"bad.go:37:6: error: unsupported field type PT: pointer type parameters can't be encoded",
+	}
+	if len(diags) != len(expected) {
+		t.Fatalf("expected %d diagnostics, got %v", len(expected), diags)
+	}
+	for _, e := range expected {
+		found := false
+		for _, d := range diags {
+			if strings.HasSuffix(d.String(), e) { found = true }
+		}
+		if !found {
+			t.Errorf("missing diagnostic %q in %v", e, diags)
+		}
+	}
+}
+
+// Types that type check but can't be generated are reported at the type
+func TestGenerateTypeDiagnostics(t *testing.T) {
+	dir := t.TempDir()
+	src := `package bad
+
+//cod:struct evolvable
+type EvAlias []uint32
+
+//cod:struct
+type Good struct {
+	Val uint32
+}
+`
+	err := os.WriteFile(filepath.Join(dir, "bad.go"), []byte(src), 0644)
+	if err != nil { panic(err) }
+
+	_, diags, err := gen.Generate(dir, gen.Config{})
+	if err != nil { panic(err) }
+	expected := "bad.go:4:6: error: evolvable is only supported on struct types, EvAlias is not a struct"
+	if len(diags) != 1 || !strings.HasSuffix(diags[0].String(), expected) {
+		t.Errorf("expected %q, got %v", expected, diags)
+	}
+}
+
+// Writes the source to a package in its own module that replaces cod with this one (so that its imports can be type checked), and generates it
+func generateModule(t *testing.T, src string) (string, string) {
+	t.Helper()
+	root, err := filepath.Abs("..")
+	if err != nil { panic(err) }
+	dir := t.TempDir()
+	mod := "module gentest\n\ngo 1.23\n\nrequire github.com/unitoftime/cod v0.0.0\n\nreplace github.com/unitoftime/cod => " + root + "\n"
+	err = os.WriteFile(filepath.Join(dir, "go.mod"), []byte(mod), 0644)
+	if err != nil { panic(err) }
+	err = os.WriteFile(filepath.Join(dir, "src.go"), []byte(src), 0644)
+	if err != nil { panic(err) }
+
+	files, diags, err := gen.Generate(dir, gen.Config{})
+	if err != nil { panic(err) }
+	if gen.HasErrors(diags) {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	return dir, string(files[filepath.Join(dir, "cod_gen.go")])
+}
+
+// Only the packages that the generated code refers to are imported, even if a field has the same name as a package
+func TestGenerateImports(t *testing.T) {
+	_, file := generateModule(t, `package imports
+
+import (
+	"github.com/unitoftime/cod/test/subpackage"
+	"github.com/unitoftime/cod/test/subpackage/blocked"
+)
+
+//cod:struct
+type Names struct {
+	subpackage subpackage.Vec
+	fmt uint8
+	Basics []blocked.Basic
+}
+`)
+	if strings.Contains(file, `"github.com/unitoftime/cod/test/subpackage"`) || strings.Contains(file, `"fmt"`) {
+		t.Errorf("expected subpackage and fmt to not be imported:\n%s", file)
+	}
+	if !strings.Contains(file, `"github.com/unitoftime/cod/test/subpackage/blocked"`) {
+		t.Errorf("expected blocked to be imported:\n%s", file)
+	}
+}
+
+// A hand written type that only has the required methods
+const handWrittenSrc = `package custom
+
+import (
+	"github.com/unitoftime/cod"
+	"github.com/unitoftime/cod/backend"
+)
+
+type Custom struct {
+	Val uint32
+}
+
+func (t Custom) EncodeCod(bs []byte) []byte {
+	return backend.WriteVarUint32(bs, t.Val)
+}
+
+func (t *Custom) DecodeCod(bs []byte) (int, error) {
+	var n int
+	var err error
+	t.Val, n, err = backend.ReadVarUint32(bs)
+	return n, err
+}
+
+func (t Custom) CodEquals(tt Custom) bool {
+	return t == tt
+}
+
+//cod:struct
+type Holder struct {
+	Custom Custom
+	Customs []Custom
+	Union CustomUnion
+}
+
+//cod:union CustomUnionDef
+type CustomUnion cod.Union
+
+//cod:def
+type CustomUnionDef struct {
+	Custom
+}
+`
+
+// Hand written types don't need the optional methods, so the generated code falls back when they are missing
+func TestGenerateHandWrittenTypes(t *testing.T) {
+	_, file := generateModule(t, handWrittenSrc)
+
+	expected := []string{
+		"n += len(t.Custom.EncodeCod(nil))",
+		"n += len(sv.EncodeCod(nil))",
+		"ct.Custom = t.Custom\n",
+		"ct.SetCustom(sv)\n",
+		"bs = t.Custom.EncodeCod(bs)",
+		"bs = sv.EncodeCod(bs)",
+		"nOff, err = decoded.DecodeCod(bs[n:])",
+	}
+	for _, e := range expected {
+		if !strings.Contains(file, e) {
+			t.Errorf("expected %q in:\n%s", e, file)
+		}
+	}
+	for _, method := range []string{"CodSize", "CodClone", "EncodeCodDelta", "DecodeCodDelta"} {
+		if strings.Contains(file, "Custom."+method+"(") || strings.Contains(file, "sv."+method+"(") || strings.Contains(file, "decoded."+method+"(") {
+			t.Errorf("expected no calls to the missing %s:\n%s", method, file)
+		}
+	}
+}
+
+func TestGenerateSchema(t *testing.T) {
+	bs, err := os.ReadFile("cod_schema.json")
+	if err != nil { panic(err) }
+	var schema gen.Schema
+	err = json.Unmarshal(bs, &schema)
+	if err != nil { panic(err) }
+
+	types := make(map[string]gen.TypeSchema)
+	for _, ts := range schema.Types {
+		types[ts.Name] = ts
+	}
+
+	fixed := types["FixedInts"]
+	if fixed.Fields[3].Name != "Count" || fixed.Fields[3].Encoding.Kind != "int64" {
+		t.Errorf("expected Count to be a fixed int64, got %+v", fixed.Fields[3])
+	}
+
+	cast := types["BlockedStruct2"].Fields[0].Encoding
+	if cast.Kind != "slice" || cast.Elem.Kind != "varuint64" || cast.Elem.Cast != "uint64" {
+		t.Errorf("expected a slice of uint64 casts, got %+v", cast)
+	}
+
+	flags := types["EntityFlags"]
+	if !flags.Bitpack || flags.Fields[1].Encoding.Kind != "bitfield" || flags.Fields[3].Skip != "serdes" {
+		t.Errorf("unexpected bitpacked fields: %+v", flags.Fields)
+	}
+
+	union := types["PinnedUnion"]
+	tags := []int{}
+	for _, variant := range union.Variants {
+		tags = append(tags, variant.Tag)
+	}
+	if union.TagEncoding != "uint8" || len(tags) != 3 || tags[0] != 2 || tags[1] != 7 || tags[2] != 200 {
+		t.Errorf("unexpected union tags: %+v", union)
+	}
+	if types["PinnedUnionDef"].Kind != "def" {
+		t.Errorf("expected PinnedUnionDef to be a def, got %s", types["PinnedUnionDef"].Kind)
+	}
+}
@@ -0,0 +1,63 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/unitoftime/cod/test/subpackage"
+)
+
+func TestGenericsRoundtrip(t *testing.T) {
+	d := Generics{
+		Opt: Option[subpackage.Vec, *subpackage.Vec]{Val: subpackage.Vec{X: 1, Y: 2}, Ok: true},
+		Pair: Pair[Id, *Id, subpackage.Vec, *subpackage.Vec]{
+			Key: Id{5},
+			Val: subpackage.Vec{X: 3, Y: 4},
+			Vals: []subpackage.Vec{{X: 5, Y: 6}, {X: 7, Y: 8}},
+			Next: Option[subpackage.Vec, *subpackage.Vec]{Val: subpackage.Vec{X: 9, Y: 10}, Ok: true},
+		},
+		Pool: subpackage.Pool[Id, *Id]{
+			Items: []Id{{1}, {2}, {3}},
+			Free: []uint32{1},
+		},
+		Opts: []Option[Id, *Id]{{Val: Id{11}, Ok: true}, {}},
+		List: List[Id, *Id]{{12}, {13}},
+		Option: Option[Id, *Id]{Val: Id{14}, Ok: true},
+	}
+
+	bs := d.EncodeCod(nil)
+	if d.CodSize() != len(bs) {
+		t.Errorf("CodSize %d != encoded length %d", d.CodSize(), len(bs))
+	}
+
+	res := Generics{}
+	n, err := res.DecodeCod(bs)
+	if err != nil { panic(err) }
+	if n != len(bs) {
+		t.Errorf("decoded %d bytes, expected %d", n, len(bs))
+	}
+	if !d.CodEquals(res) {
+		t.Errorf("expected %v, got %v", d, res)
+	}
+
+	res.Pair.Vals[1].X = 100
+	if d.CodEquals(res) {
+		t.Error("MATCH-BUT THEY SHOULDNT")
+	}
+}
+
+// Type parameter fields are decoded in place, so they reuse their memory like any other field
+func TestGenericsDecodeInPlace(t *testing.T) {
+	d := Option[Pooled, *Pooled]{Val: Pooled{Ids: []uint32{1, 2, 3}}, Ok: true}
+	bs := d.EncodeCod(nil)
+
+	res := Option[Pooled, *Pooled]{Val: Pooled{Ids: make([]uint32, 1, 8)}}
+	ids := res.Val.Ids
+	_, err := res.DecodeCod(bs)
+	if err != nil { panic(err) }
+	if !d.CodEquals(res) {
+		t.Errorf("expected %v, got %v", d, res)
+	}
+	if &ids[0] != &res.Val.Ids[0] {
+		t.Error("expected the decoded slice to reuse the existing one")
+	}
+}
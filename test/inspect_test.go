@@ -0,0 +1,126 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/unitoftime/cod/backend"
+	"github.com/unitoftime/cod/gen"
+	"github.com/unitoftime/cod/test/subpackage"
+)
+
+func findLine(lines []gen.InspectLine, path string) (gen.InspectLine, bool) {
+	for _, l := range lines {
+		if l.Path == path && l.Value != "" { return l, true }
+	}
+	return gen.InspectLine{}, false
+}
+
+func TestInspect(t *testing.T) {
+	d := Person{
+		Name: "abc",
+		Id: Id{Val: 300},
+		Map: map[string][]uint64{"k": {9}},
+		MyUnion: NewMyUnionFromVec(subpackage.Vec{X: 1, Y: 2}),
+	}
+	bs := d.EncodeCod(nil)
+
+	lines, n, err := gen.Inspect(".", "Person", bs, gen.Config{})
+	if err != nil { panic(err) }
+	if n != len(bs) {
+		t.Errorf("expected %d bytes, got %d", len(bs), n)
+	}
+
+	expected := []gen.InspectLine{
+		{Offset: 0, Bytes: []byte{3, 'a', 'b', 'c'}, Path: "Person.Name", Value: `"abc"`},
+		{Offset: 5, Bytes: []byte{0xac, 0x02}, Path: "Person.Id.Val", Value: "300"},
+		{Path: `Person.Map["k"][0]`, Bytes: []byte{9}, Value: "9"},
+		{Path: "Person.MyUnion", Bytes: []byte{3}, Value: "tag 3 (subpackage.Vec)"},
+		{Path: "Person.MyUnion.Vec.Y", Bytes: []byte{2}, Value: "2"},
+		{Path: "Person.Pointer", Bytes: []byte{0}, Value: "nil"},
+	}
+	for _, e := range expected {
+		l, ok := findLine(lines, e.Path)
+		if !ok {
+			t.Errorf("missing %s in %v", e.Path, lines)
+			continue
+		}
+		if string(l.Bytes) != string(e.Bytes) || l.Value != e.Value || (e.Offset != 0 && l.Offset != e.Offset) {
+			t.Errorf("expected %+v, got %+v", e, l)
+		}
+	}
+}
+
+// The type arguments of generic types are resolved, including the pointer type arguments
+func TestInspectGenerics(t *testing.T) {
+	d := Generics{
+		Pair: Pair[Id, *Id, subpackage.Vec, *subpackage.Vec]{Key: Id{Val: 300}},
+		Option: Option[Id, *Id]{Val: Id{Val: 5}, Ok: true},
+	}
+	bs := d.EncodeCod(nil)
+
+	lines, n, err := gen.Inspect(".", "Generics", bs, gen.Config{})
+	if err != nil { panic(err) }
+	if n != len(bs) {
+		t.Errorf("expected %d bytes, got %d", len(bs), n)
+	}
+	l, ok := findLine(lines, "Generics.Pair.Key.Val")
+	if !ok || l.Value != "300" {
+		t.Errorf("expected Generics.Pair.Key.Val to be 300 in %v", lines)
+	}
+	l, ok = findLine(lines, "Generics.Option.Val.Val")
+	if !ok || l.Value != "5" {
+		t.Errorf("expected Generics.Option.Val.Val to be 5 in %v", lines)
+	}
+}
+
+func TestInspectErrors(t *testing.T) {
+	bs := Person{Name: "abc", Slice: []uint32{1, 2, 3}}.EncodeCod(nil)
+
+	// Truncated data is reported at the value that couldn't be read
+	lines, _, err := gen.Inspect(".", "Person", bs[:11], gen.Config{})
+	var inspectErr *gen.InspectError
+	if !errors.As(err, &inspectErr) || !errors.Is(err, backend.ErrTruncatedData) {
+		t.Fatalf("expected truncated data, got %v", err)
+	}
+	if inspectErr.Offset != 8 || inspectErr.Path != "Person.Slice" {
+		t.Errorf("expected the error at Person.Slice (offset 8), got %v", inspectErr)
+	}
+	if _, ok := findLine(lines, "Person.Array[1]"); !ok {
+		t.Errorf("expected the lines before the error, got %v", lines)
+	}
+
+	// Types with hand written encoders have no schema
+	bs = ReflectedStruct{Basic: 1}.EncodeCod(nil)
+	_, _, err = gen.Inspect(".", "ReflectedStruct", bs, gen.Config{})
+	if !errors.As(err, &inspectErr) || inspectErr.Path != "ReflectedStruct.Struct" {
+		t.Errorf("expected an error at ReflectedStruct.Struct, got %v", err)
+	}
+
+	// Lengths are bounded by the smallest size of their elements, instead of by the remaining bytes
+	sizes := MinSizes{Blanks: make([]BlankStruct, 5), Hashes: []uint64{1, 2}}
+	bs = sizes.EncodeCod(nil)
+	lines, n, err := gen.Inspect(".", "MinSizes", bs, gen.Config{})
+	if err != nil || n != len(bs) {
+		t.Fatalf("expected %d bytes to be read, got %d: %v", len(bs), n, err)
+	}
+	if l, ok := findLine(lines, "MinSizes.Blanks"); !ok || l.Value != "len 5" {
+		t.Errorf("expected 5 empty structs, got %v", lines)
+	}
+
+	_, _, err = gen.Inspect(".", "MinSizes", bs[:len(bs)-1], gen.Config{})
+	if !errors.As(err, &inspectErr) || !errors.Is(err, backend.ErrTruncatedData) || inspectErr.Path != "MinSizes.Hashes" {
+		t.Errorf("expected truncated data at MinSizes.Hashes, got %v", err)
+	}
+
+	bs = backend.WriteVarUint64(nil, 1 << 40)
+	_, _, err = gen.Inspect(".", "MinSizes", bs, gen.Config{})
+	if !errors.As(err, &inspectErr) || inspectErr.Path != "MinSizes.Blanks" {
+		t.Errorf("expected the length of MinSizes.Blanks to be too long, got %v", err)
+	}
+
+	_, _, err = gen.Inspect(".", "Tick", nil, gen.Config{})
+	if err == nil {
+		t.Error("expected an error for an untagged type")
+	}
+}
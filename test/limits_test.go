@@ -0,0 +1,84 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/unitoftime/cod/backend"
+)
+
+func TestLimitsAllowValidData(t *testing.T) {
+	d := Untrusted{
+		Name: "hello",
+		Tags: []string{"a", "b"},
+		Scores: map[string]uint32{"a": 1},
+		Child: &Untrusted{Name: "child"},
+	}
+	bs := d.EncodeCod(nil)
+
+	res := Untrusted{}
+	lim := backend.NewLimiter(backend.DecodeLimits{
+		MaxSliceLen: 2,
+		MaxMapLen: 1,
+		MaxStringLen: 5,
+		MaxDepth: 2,
+		MaxAlloc: 1000,
+	})
+	n, err := res.DecodeCodWithLimits(bs, lim)
+	if err != nil { panic(err) }
+	if n != len(bs) {
+		t.Errorf("decoded %d bytes, expected %d", n, len(bs))
+	}
+	if !d.CodEquals(res) {
+		t.Errorf("expected %v, got %v", d, res)
+	}
+}
+
+func TestLimitsExceeded(t *testing.T) {
+	// A hostile length prefix, which claims that there is a huge amount of data
+	huge := backend.WriteVarUint64(nil, 1 << 60)
+
+	tests := map[string]struct{
+		bs []byte
+		limits backend.DecodeLimits
+	}{
+		"string": {
+			bs: huge,
+			limits: backend.DecodeLimits{MaxStringLen: 10},
+		},
+		"slice": {
+			bs: append(backend.WriteString(nil, "a"), huge...),
+			limits: backend.DecodeLimits{MaxSliceLen: 10},
+		},
+		"map": {
+			bs: append(backend.WriteString(nil, "a"), append(backend.WriteVarUint64(nil, 0), huge...)...),
+			limits: backend.DecodeLimits{MaxMapLen: 10},
+		},
+		"alloc": {
+			bs: append(backend.WriteString(nil, "a"), huge...),
+			limits: backend.DecodeLimits{MaxAlloc: 1000},
+		},
+		"depth": {
+			bs: Untrusted{Child: &Untrusted{Child: &Untrusted{}}}.EncodeCod(nil),
+			limits: backend.DecodeLimits{MaxDepth: 2},
+		},
+	}
+
+	for name, test := range tests {
+		res := Untrusted{}
+		_, err := res.DecodeCodWithLimits(test.bs, backend.NewLimiter(test.limits))
+		if !errors.Is(err, backend.ErrLimitExceeded) {
+			t.Errorf("%s: expected ErrLimitExceeded, got %v", name, err)
+		}
+	}
+}
+
+func TestHugeStringLength(t *testing.T) {
+	// Without limits, a huge length must still fail instead of overflowing
+	bs := backend.WriteVarUint64(nil, 1 << 63)
+	res := Untrusted{}
+	_, err := res.DecodeCod(bs)
+	if !errors.Is(err, backend.ErrTruncatedData) {
+		t.Errorf("expected ErrTruncatedData, got %v", err)
+	}
+}
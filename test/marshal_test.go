@@ -0,0 +1,52 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/unitoftime/cod"
+	"github.com/unitoftime/cod/backend"
+	"github.com/unitoftime/cod/test/subpackage"
+)
+
+func TestMarshalUnmarshal(t *testing.T) {
+	d := Person{
+		Name: "a",
+		Age: 30,
+		Slice: []uint32{1, 2, 3},
+		MyUnion: NewMyUnionFromVec(subpackage.Vec{X: 1, Y: 2}),
+	}
+
+	bs := cod.Marshal(d)
+	res, err := cod.Unmarshal[Person](bs)
+	if err != nil { panic(err) }
+	if !d.CodEquals(res) {
+		t.Error("MISMATCH")
+	}
+
+	// Appended values are decoded in order
+	bs = cod.AppendMarshal(bs, Id{Val: 7})
+	id, err := cod.Unmarshal[Id](bs[len(cod.Marshal(d)):], cod.Strict())
+	if err != nil { panic(err) }
+	if id.Val != 7 {
+		t.Errorf("expected 7, got %d", id.Val)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	bs := cod.Marshal(Id{Val: 300})
+
+	// Without Strict, trailing data is ignored
+	_, err := cod.Unmarshal[Id](append(bs, 1))
+	if err != nil { panic(err) }
+
+	_, err = cod.Unmarshal[Id](append(bs, 1), cod.Strict())
+	if !errors.Is(err, cod.ErrTrailingData) {
+		t.Errorf("expected ErrTrailingData, got %v", err)
+	}
+
+	_, err = cod.Unmarshal[Id](bs[:1], cod.Strict())
+	if !errors.Is(err, backend.ErrTruncatedData) {
+		t.Errorf("expected ErrTruncatedData, got %v", err)
+	}
+}
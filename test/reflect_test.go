@@ -0,0 +1,255 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/unitoftime/cod"
+	codreflect "github.com/unitoftime/cod/reflect"
+	"github.com/unitoftime/cod/test/subpackage"
+	"github.com/unitoftime/cod/test/subpackage/blocked"
+)
+
+// The types below mirror generated types, but are declared in a test file so they are only encoded with reflection
+type reflectPerson struct {
+	Name string
+	Age uint8
+	Id Id
+	Array [2]uint16
+	Slice []uint32
+	DoubleSlice [][]uint8
+	Map map[string][]uint64
+	MultiMap map[string]map[uint32][]uint8
+	MyUnion MyUnion
+	Pointer *reflectBlockedStruct
+}
+
+type reflectBlockedStruct struct {
+	Basic blocked.Basic `cod.cast:"uint64"`
+}
+
+type reflectReflectedStruct struct {
+	Basic blocked.Basic
+	Struct blocked.Struct
+}
+
+type reflectBlockedStruct2 struct {
+	Basic []blocked.Basic `cod.cast:"uint64"`
+}
+
+type reflectFixedInts struct {
+	Hash uint64 `cod.fixed:"true"`
+	Id uint32 `json:"id" cod.fixed:"true"`
+	Offset int16 `cod.fixed:"true"`
+	Count int `cod.fixed:"true"`
+	Tick Tick `cod.fixed:"true"`
+	Coords [2]int32 `cod.fixed:"true"`
+	Ids []uint32 `cod.fixed:"true"`
+	Lookup map[uint16]int64 `cod.fixed:"true"`
+	Varint uint64
+}
+
+type reflectSortedMaps struct {
+	Names map[string]uint8 `cod.deterministic:"true"`
+	Ticks map[Tick]map[int8]string `cod.deterministic:"true"`
+	Positions map[Id]uint8 `cod.deterministic:"true"`
+	Inventory struct {
+		Items map[string]uint32 `cod.deterministic:"true"`
+		Slots map[subpackage.Vec]string `cod.deterministic:"true"`
+	}
+}
+
+type reflectUntrusted struct {
+	Name string
+	Tags []string
+	Scores map[string]uint32
+	Child *reflectUntrusted
+}
+
+// Skipped and unexported fields
+type reflectId struct {
+	val uint16
+	Cache []string `cod.skip:"serdes"`
+}
+
+// Checks that the reflection codec writes the same bytes as the generated code, and that each can decode the other
+func checkReflectCompat[G cod.Encoder, PG interface{ *G; cod.Decoder }, M any](t *testing.T, generated G, mirror M) {
+	t.Helper()
+
+	bs := generated.EncodeCod(nil)
+	refBs, err := codreflect.Marshal(mirror)
+	if err != nil { panic(err) }
+	if !bytes.Equal(bs, refBs) {
+		t.Errorf("%T: reflection encoded %v, generated encoded %v", mirror, refBs, bs)
+	}
+
+	var m M
+	n, err := codreflect.Unmarshal(bs, &m)
+	if err != nil { panic(err) }
+	if n != len(bs) || !reflect.DeepEqual(mirror, m) {
+		t.Errorf("%T: reflection decoded %d of %d bytes: %+v", mirror, n, len(bs), m)
+	}
+
+	var g G
+	n, err = PG(&g).DecodeCod(refBs)
+	if err != nil { panic(err) }
+	if n != len(refBs) || !bytes.Equal(g.EncodeCod(nil), bs) {
+		t.Errorf("%T: generated decoded %d of %d bytes: %+v", g, n, len(refBs), g)
+	}
+}
+
+func TestReflectCompat(t *testing.T) {
+	checkReflectCompat(t,
+		Person{
+			Name: "a",
+			Age: 30,
+			Id: Id{Val: 300},
+			Array: [2]uint16{1, 1000},
+			Slice: []uint32{1, 2, 3},
+			DoubleSlice: [][]uint8{{1}, {2, 3}},
+			Map: map[string][]uint64{"a": {1 << 40}},
+			MultiMap: map[string]map[uint32][]uint8{"b": {7: {8}}},
+			MyUnion: NewMyUnionFromVec(subpackage.Vec{X: 1, Y: 2}),
+			Pointer: &BlockedStruct{Basic: 5},
+		},
+		reflectPerson{
+			Name: "a",
+			Age: 30,
+			Id: Id{Val: 300},
+			Array: [2]uint16{1, 1000},
+			Slice: []uint32{1, 2, 3},
+			DoubleSlice: [][]uint8{{1}, {2, 3}},
+			Map: map[string][]uint64{"a": {1 << 40}},
+			MultiMap: map[string]map[uint32][]uint8{"b": {7: {8}}},
+			MyUnion: NewMyUnionFromVec(subpackage.Vec{X: 1, Y: 2}),
+			Pointer: &reflectBlockedStruct{Basic: 5},
+		})
+
+	checkReflectCompat(t,
+		ReflectedStruct{Basic: 5, Struct: codreflect.Field[blocked.Struct]{Val: blocked.Struct{X: 1.5, Y: -2}}},
+		reflectReflectedStruct{Basic: 5, Struct: blocked.Struct{X: 1.5, Y: -2}})
+
+	checkReflectCompat(t,
+		BlockedStruct2{Basic: []blocked.Basic{1, 60000}},
+		reflectBlockedStruct2{Basic: []blocked.Basic{1, 60000}})
+
+	checkReflectCompat(t,
+		FixedInts{Hash: 1 << 60, Id: 9, Offset: -3, Count: -1, Tick: 4, Coords: [2]int32{-1, 1}, Ids: []uint32{5}, Lookup: map[uint16]int64{2: -2}, Varint: 300},
+		reflectFixedInts{Hash: 1 << 60, Id: 9, Offset: -3, Count: -1, Tick: 4, Coords: [2]int32{-1, 1}, Ids: []uint32{5}, Lookup: map[uint16]int64{2: -2}, Varint: 300})
+
+	sorted := reflectSortedMaps{
+		Names: map[string]uint8{"c": 3, "a": 1, "b": 2},
+		Ticks: map[Tick]map[int8]string{2: {-1: "x", 1: "y"}, 1: {0: "z"}},
+		Positions: map[Id]uint8{{Val: 300}: 1, {Val: 2}: 2, {Val: 1}: 3},
+	}
+	sorted.Inventory.Items = map[string]uint32{"sword": 1, "axe": 2}
+	sorted.Inventory.Slots = map[subpackage.Vec]string{{X: 2}: "b", {X: 1, Y: 5}: "a"}
+	checkReflectCompat(t,
+		SortedMaps{
+			Names: map[string]uint8{"c": 3, "a": 1, "b": 2},
+			Ticks: map[Tick]map[int8]string{2: {-1: "x", 1: "y"}, 1: {0: "z"}},
+			Positions: map[Id]uint8{{Val: 300}: 1, {Val: 2}: 2, {Val: 1}: 3},
+			Inventory: subpackage.Inventory{Items: map[string]uint32{"sword": 1, "axe": 2}, Slots: map[subpackage.Vec]string{{X: 2}: "b", {X: 1, Y: 5}: "a"}},
+		},
+		sorted)
+
+	checkReflectCompat(t,
+		Untrusted{Name: "a", Tags: []string{"b"}, Scores: map[string]uint32{"d": 4}, Child: &Untrusted{Name: "c"}},
+		// Like the generated code, decoding always makes a map
+		reflectUntrusted{Name: "a", Tags: []string{"b"}, Scores: map[string]uint32{"d": 4}, Child: &reflectUntrusted{Name: "c", Scores: map[string]uint32{}}})
+
+	checkReflectCompat(t, Id{Val: 300}, reflectId{val: 300})
+}
+
+func TestReflectField(t *testing.T) {
+	d := ReflectedStruct{Basic: 3, Struct: codreflect.Field[blocked.Struct]{Val: blocked.Struct{X: 1, Y: 2}}}
+
+	var res ReflectedStruct
+	bs := d.EncodeCod(nil)
+	n, err := res.DecodeCod(bs)
+	if err != nil { panic(err) }
+	if n != len(bs) || n != d.CodSize() {
+		t.Errorf("expected %d bytes, got %d", len(bs), n)
+	}
+	if !d.CodEquals(res) {
+		t.Error("MISMATCH")
+	}
+	if !d.CodEquals(d.CodClone()) {
+		t.Error("clone MISMATCH")
+	}
+}
+
+type reflectFloats struct {
+	F32 float32
+	F64 float64
+	Vals []float64
+	Cache []uint8 `cod.skip:"equality"`
+}
+
+// Field.CodEquals must agree with the generated CodEquals
+func TestReflectFieldEquality(t *testing.T) {
+	nan := math.NaN()
+	negZero := math.Copysign(0, -1)
+
+	tests := []struct{
+		a, b Floats
+	}{
+		{Floats{F32: 1.5, F64: 2.5}, Floats{F32: 1.5, F64: 2.5}},
+		{Floats{F32: 1.5}, Floats{F32: 2.5}},
+		{Floats{F64: nan}, Floats{F64: nan}},
+		{Floats{F32: float32(nan)}, Floats{F32: float32(nan)}},
+		{Floats{F64: nan}, Floats{F64: 1}},
+		{Floats{Vals: []float64{1, nan}}, Floats{Vals: []float64{1, nan}}},
+		{Floats{F64: negZero}, Floats{F64: 0}},
+		{Floats{Vals: []float64{negZero}}, Floats{Vals: []float64{0}}},
+		{Floats{Vals: []float64{}}, Floats{}},
+	}
+	for i, test := range tests {
+		a := codreflect.Field[reflectFloats]{Val: reflectFloats{F32: test.a.F32, F64: test.a.F64, Vals: test.a.Vals}}
+		b := codreflect.Field[reflectFloats]{Val: reflectFloats{F32: test.b.F32, F64: test.b.F64, Vals: test.b.Vals}}
+		b.Val.Cache = []uint8{1}
+		if a.CodEquals(b) != test.a.CodEquals(test.b) {
+			t.Errorf("test %d: expected Field.CodEquals to be %v for %v and %v", i, test.a.CodEquals(test.b), test.a, test.b)
+		}
+	}
+
+	// Types with a CodEquals method are compared with it
+	a := codreflect.Field[map[string]Floats]{Val: map[string]Floats{"a": {F64: nan}}}
+	b := codreflect.Field[map[string]Floats]{Val: map[string]Floats{"a": {F64: nan}}}
+	if !a.CodEquals(b) {
+		t.Errorf("expected %v to equal %v", a, b)
+	}
+	b.Val["a"] = Floats{F64: negZero}
+	if a.CodEquals(b) {
+		t.Errorf("expected %v to not equal %v", a, b)
+	}
+}
+
+func TestReflectErrors(t *testing.T) {
+	_, err := codreflect.Marshal(struct{ C chan int }{})
+	if !errors.Is(err, codreflect.ErrUnsupportedType) {
+		t.Errorf("expected ErrUnsupportedType, got %v", err)
+	}
+
+	_, err = codreflect.Marshal(struct{ A any }{A: 1})
+	if !errors.Is(err, codreflect.ErrUnsupportedType) {
+		t.Errorf("expected ErrUnsupportedType, got %v", err)
+	}
+
+	var id reflectId
+	_, err = codreflect.Unmarshal(nil, id)
+	if !errors.Is(err, codreflect.ErrUnsupportedType) {
+		t.Errorf("expected ErrUnsupportedType, got %v", err)
+	}
+
+	// Truncated data fails like the generated code
+	bs := Person{Name: "abc"}.EncodeCod(nil)
+	var p reflectPerson
+	_, err = codreflect.Unmarshal(bs[:len(bs)-1], &p)
+	if err == nil {
+		t.Error("expected an error for truncated data")
+	}
+}
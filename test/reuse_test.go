@@ -0,0 +1,98 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/unitoftime/cod/test/subpackage"
+)
+
+func TestDecodeResetsSlicesAndMaps(t *testing.T) {
+	d := Pooled{
+		Ids: []uint32{1, 2, 3},
+		Positions: []subpackage.Vec{{X: 1, Y: 2}},
+		Counts: map[uint32]uint16{1: 10, 2: 20},
+		List: IdList{{1}, {2}},
+	}
+	bs := d.EncodeCod(nil)
+
+	res := Pooled{
+		Ids: []uint32{7, 8, 9, 10, 11},
+		Counts: map[uint32]uint16{3: 30},
+		List: IdList{{3}},
+	}
+	for i := 0; i < 3; i++ {
+		_, err := res.DecodeCod(bs)
+		if err != nil { panic(err) }
+		if !d.CodEquals(res) {
+			t.Errorf("decode %d: expected %v, got %v", i, d, res)
+		}
+	}
+	if len(res.Counts) != 2 {
+		t.Errorf("expected the map to be cleared, got %v", res.Counts)
+	}
+}
+
+func TestDecodeReusesCapacity(t *testing.T) {
+	d := Pooled{
+		Ids: []uint32{1, 2, 3},
+		Positions: []subpackage.Vec{{X: 1, Y: 2}},
+		Counts: map[uint32]uint16{1: 10, 2: 20},
+		List: IdList{{1}, {2}},
+		Groups: [][]uint32{{1, 2}, {3, 4, 5}},
+		Lists: []IdList{{{1}}, {{2}, {3}}},
+	}
+	bs := d.EncodeCod(nil)
+
+	res := Pooled{}
+	_, err := res.DecodeCod(bs)
+	if err != nil { panic(err) }
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, err := res.DecodeCod(bs)
+		if err != nil { panic(err) }
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations when decoding into a used value, got %v", allocs)
+	}
+}
+
+func TestEvolvableDecodeReusesCapacity(t *testing.T) {
+	d := PooledSave{
+		Ids: []uint32{1, 2, 3},
+		Groups: [][]uint32{{1, 2}, {3, 4, 5}},
+		Counts: map[uint32]uint16{1: 10, 2: 20},
+		Pos: subpackage.Vec{X: 1, Y: 2},
+	}
+	bs := d.EncodeCod(nil)
+
+	res := PooledSave{}
+	_, err := res.DecodeCod(bs)
+	if err != nil { panic(err) }
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, err := res.DecodeCod(bs)
+		if err != nil { panic(err) }
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations when decoding into a used value, got %v", allocs)
+	}
+	if !d.CodEquals(res) {
+		t.Errorf("expected %v, got %v", d, res)
+	}
+}
+
+func TestDecodePreallocIsBounded(t *testing.T) {
+	// A huge length prefix must not cause a huge allocation before the decode fails
+	bs := Pooled{Ids: make([]uint32, 1)}.EncodeCod(nil)
+	bs[0] = 0xff
+	bs = append(bs[:1], 0xff, 0xff, 0xff, 0x0f)
+
+	res := Pooled{}
+	_, err := res.DecodeCod(bs)
+	if err == nil {
+		t.Error("expected an error")
+	}
+	if cap(res.Ids) > len(bs) {
+		t.Errorf("expected the preallocation to be bounded by the data, got %d", cap(res.Ids))
+	}
+}
@@ -0,0 +1,74 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/unitoftime/cod/test/subpackage"
+	"github.com/unitoftime/cod/test/subpackage/blocked"
+)
+
+func checkSize[A interface{ EncodeCod([]byte) []byte; CodSize() int }](t *testing.T, a A) {
+	t.Helper()
+	bs := a.EncodeCod(nil)
+	if a.CodSize() != len(bs) {
+		t.Errorf("%T: CodSize %d != encoded length %d", a, a.CodSize(), len(bs))
+	}
+}
+
+func TestSizeBasic(t *testing.T) {
+	checkSize(t, BlankStruct{})
+	checkSize(t, Id{Val: 0})
+	checkSize(t, Id{Val: 65535})
+	checkSize(t, BlockedStruct{Basic: blocked.Basic(300)})
+	checkSize(t, BlockedStruct2{Basic: []blocked.Basic{1, 200, 40000}})
+	checkSize(t, MyStruct{Vector: []subpackage.Vec{{X: 1, Y: 1 << 62}}})
+	checkSize(t, NamedBasics{
+		Tick: 1 << 30,
+		Ticks: []Tick{0, 127, 128},
+		Byte: 255,
+		Rune: -1 << 20,
+		Basics: map[blocked.Basic]Tick{5: 5000},
+		Basic: blocked.Basic(300),
+	})
+	checkSize(t, FixedInts{Ids: []uint32{1}, Lookup: map[uint16]int64{1: 1}})
+}
+
+func TestSizeUnion(t *testing.T) {
+	checkSize(t, MyUnion{})
+	checkSize(t, NewMyUnion(Id{Val: 1000}))
+	checkSize(t, NewMyUnion(SpecialMap{"a": []uint8{1, 2, 3}}))
+	checkSize(t, NewMyUnion(subpackage.Vec{X: 1, Y: 2}))
+}
+
+func TestSizePerson(t *testing.T) {
+	d := Person{
+		Name: "hello",
+		Age: 5,
+		Id: Id{7},
+		Array: [2]uint16{8, 9000},
+		Slice: []uint32{100, 101, 1 << 31},
+		DoubleSlice: [][]uint8{[]uint8{1, 2, 3}, []uint8{4, 5, 6}},
+
+		Map: map[string][]uint64{
+			"a": []uint64{1000, 2000, 3000},
+			"b": []uint64{4000, 5000, 6000},
+		},
+		MultiMap: map[string]map[uint32][]uint8{
+			"c": map[uint32][]uint8{
+				1: []uint8{11, 12},
+				2: []uint8{22, 23},
+			},
+		},
+
+		MyUnion: NewMyUnion(Id{8}),
+
+		Pointer: &BlockedStruct{
+			Basic: blocked.Basic(1),
+		},
+	}
+	checkSize(t, d)
+
+	d.Pointer = nil
+	d.MyUnion = MyUnion{}
+	checkSize(t, d)
+}
@@ -0,0 +1,122 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/unitoftime/cod/backend"
+	"github.com/unitoftime/cod/test/subpackage"
+)
+
+func streamTestData() []SaveV2 {
+	data := make([]SaveV2, 0)
+	for i := 0; i < 100; i++ {
+		data = append(data, SaveV2{
+			Name: "hello",
+			Items: []string{"a", "b"},
+			Pos: subpackage.Vec{X: uint64(i), Y: 2},
+			Gold: uint64(i * 1000),
+			Inventory: map[string]uint8{"sword": uint8(i)},
+		})
+	}
+	return data
+}
+
+func TestStreamRoundtrip(t *testing.T) {
+	data := streamTestData()
+
+	buf := new(bytes.Buffer)
+	w := backend.NewWriterSize(buf, 64)
+	for _, d := range data {
+		err := d.EncodeCodTo(w)
+		if err != nil { panic(err) }
+	}
+	err := w.Flush()
+	if err != nil { panic(err) }
+
+	// Read one byte at a time, with a buffer that is smaller than each value, so that the reader has to refill and grow
+	r := backend.NewReaderSize(iotest.OneByteReader(buf), 4)
+	for i, d := range data {
+		res := SaveV2{}
+		err := res.DecodeCodFrom(r)
+		if err != nil { panic(err) }
+		if !d.CodEquals(res) {
+			t.Errorf("value %d: expected %v, got %v", i, d, res)
+		}
+	}
+
+	res := SaveV2{}
+	err = res.DecodeCodFrom(r)
+	if err != io.EOF {
+		t.Errorf("expected io.EOF at the end of the stream, got %v", err)
+	}
+}
+
+func TestStreamUnion(t *testing.T) {
+	d := NewMyUnion(Id{8})
+
+	buf := new(bytes.Buffer)
+	w := backend.NewWriter(buf)
+	err := d.EncodeCodTo(w)
+	if err != nil { panic(err) }
+	err = w.Flush()
+	if err != nil { panic(err) }
+
+	res := MyUnion{}
+	err = res.DecodeCodFrom(backend.NewReader(buf))
+	if err != nil { panic(err) }
+	if !d.CodEquals(res) {
+		t.Errorf("expected %v, got %v", d, res)
+	}
+}
+
+func TestStreamTruncated(t *testing.T) {
+	d := streamTestData()[0]
+	bs := d.EncodeCod(nil)
+
+	for i := 1; i < len(bs); i++ {
+		res := SaveV2{}
+		err := res.DecodeCodFrom(backend.NewReader(bytes.NewReader(bs[:i])))
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("decoding %d of %d bytes: expected io.ErrUnexpectedEOF, got %v", i, len(bs), err)
+		}
+	}
+}
+
+// Returns at most size bytes from each read, like a network connection
+type chunkReader struct {
+	r io.Reader
+	size int
+}
+
+func (c chunkReader) Read(p []byte) (int, error) {
+	if len(p) > c.size { p = p[:c.size] }
+	return c.r.Read(p)
+}
+
+func TestStreamLargeValue(t *testing.T) {
+	d := Pooled{Ids: make([]uint32, 200_000)}
+	for i := range d.Ids {
+		d.Ids[i] = uint32(i)
+	}
+	bs := d.EncodeCod(nil)
+
+	// Every retry decodes from the start of the value, so the reader must grow the buffered data geometrically instead of by one read
+	r := backend.NewReader(chunkReader{bytes.NewReader(bs), 1500})
+	res := Pooled{}
+	decodes := 0
+	err := r.Decode(func(bs []byte) (int, error) {
+		decodes++
+		return res.DecodeCod(bs)
+	})
+	if err != nil { panic(err) }
+	if !d.CodEquals(res) {
+		t.Error("MISMATCH")
+	}
+	if decodes > 20 {
+		t.Errorf("expected a few retries for %d bytes, got %d decodes", len(bs), decodes)
+	}
+}
@@ -2,11 +2,12 @@ package test
 
 import (
 	"github.com/unitoftime/cod"
+	codreflect "github.com/unitoftime/cod/reflect"
 	"github.com/unitoftime/cod/test/subpackage"
 	"github.com/unitoftime/cod/test/subpackage/blocked"
 )
 
-//go:generate go run ../cmd/cod
+//go:generate go run ../cmd/cod -fuzz -schema ./...
 
 // //cod:component
 //cod:struct
@@ -20,10 +21,82 @@ type BlockedStruct2 struct {
 	Basic []blocked.Basic `cod.cast:"uint64"`
 }
 
+// Types without generated methods are wrapped, and encoded with reflection
+//cod:struct
+type ReflectedStruct struct {
+	Basic blocked.Basic
+	Struct codreflect.Field[blocked.Struct]
+}
+
+type Tick uint32
+
+//cod:struct
+type NamedBasics struct {
+	Tick Tick
+	Ticks []Tick
+	Byte byte
+	Rune rune
+	Basics map[blocked.Basic]Tick
+	Basic blocked.Basic // Resolved to its underlying uint16 without a cast
+}
+
+//cod:struct
+type FixedInts struct {
+	Hash uint64 `cod.fixed:"true"`
+	Id uint32 `json:"id" cod.fixed:"true"`
+	Offset int16 `cod.fixed:"true"`
+	Count int `cod.fixed:"true"`
+	Tick Tick `cod.fixed:"true"`
+	Coords [2]int32 `cod.fixed:"true"`
+	Ids []uint32 `cod.fixed:"true"`
+	Lookup map[uint16]int64 `cod.fixed:"true"`
+	Varint uint64
+}
+
+//cod:struct
+type SortedMaps struct {
+	Names map[string]uint8 `cod.deterministic:"true"`
+	Ticks map[Tick]map[int8]string `cod.deterministic:"true"`
+	Positions map[Id]uint8 `cod.deterministic:"true"`
+	Inventory subpackage.Inventory
+}
+
+//cod:struct evolvable
+type SaveV1 struct {
+	Name string
+	Level uint32
+	Items []string
+	Pos subpackage.Vec
+}
+
+// The second version of SaveV1: Level was removed, and Gold was added
+//cod:struct evolvable
+type SaveV2 struct {
+	Name string
+	Items []string `cod.tag:"3"`
+	Pos subpackage.Vec `cod.tag:"4"`
+	Gold uint64 `cod.tag:"5"`
+	Inventory map[string]uint8 `cod.tag:"6"`
+}
+
+// Evolvable structs can be mixed with compact structs
+//cod:struct
+type SaveFile struct {
+	Save SaveV1
+	Checksum uint32
+}
+
 //cod:struct
 type BlankStruct struct {
 }
 
+// Inspected in the inspect tests. Empty structs are encoded with zero bytes, and fixed uint64s with 8
+//cod:struct
+type MinSizes struct {
+	Blanks []BlankStruct
+	Hashes []uint64 `cod.fixed:"true"`
+}
+
 //cod:struct
 type Person struct {
 	Name string
@@ -55,6 +128,36 @@ type MyUnionDef struct {
 	subpackage.Vec
 }
 
+//cod:union PinnedUnionDef
+type PinnedUnion cod.Union
+
+// Variants with a cod.tag keep their wire number even if the def is reordered
+//cod:def
+type PinnedUnionDef struct {
+	Id `cod.tag:"7"`
+	SpecialMap
+	subpackage.Vec `cod.tag:"200"`
+}
+
+// Set returns an error instead of panicking when the value isn't a member
+//cod:union CheckedUnionDef, seterror
+type CheckedUnion cod.Union
+
+//cod:def
+type CheckedUnionDef struct {
+	Id
+	subpackage.Vec
+}
+
+//cod:union VarintUnionDef, varint
+type VarintUnion cod.Union
+
+//cod:def
+type VarintUnionDef struct {
+	Id
+	subpackage.Vec `cod.tag:"300"`
+}
+
 //cod:struct
 type SpecialMap map[string][]uint8
 
@@ -75,3 +178,108 @@ type Id struct {
 // 	Age *uint8
 // 	Id *Id
 // }
+
+type Visible bool
+
+// All bool fields are packed into a 2 byte bitfield
+//cod:struct bitpack
+type EntityFlags struct {
+	Id uint32
+	Alive bool
+	Moving bool
+	Jumping bool
+	Visible Visible
+	Name string
+	Grounded bool
+	Crouching bool
+	Sprinting bool
+	Swimming bool
+	Flying bool
+	Stunned bool
+	Ignored bool `cod.skip:"serdes"`
+	Sleeping bool
+	Mask []bool
+}
+
+// Only flags, so the bitfield is the whole encoding
+//cod:struct bitpack
+type OnlyBools struct {
+	A, B, C bool
+}
+
+// Generic structs must constrain their type parameters to types that have the generated methods, and pass the pointer types to decode them in place
+//cod:struct
+type Option[T cod.Codec[T], PT cod.CodecPtr[T]] struct {
+	Val T
+	Ok bool
+}
+
+//cod:struct
+type Pair[K cod.Codec[K], PK cod.CodecPtr[K], V cod.Codec[V], PV cod.CodecPtr[V]] struct {
+	Key K
+	Val V
+	Vals []V
+	Next Option[V, PV]
+}
+
+//cod:struct
+type List[T cod.Codec[T], PT cod.CodecPtr[T]] []T
+
+//cod:struct
+type Generics struct {
+	Opt Option[subpackage.Vec, *subpackage.Vec]
+	Pair Pair[Id, *Id, subpackage.Vec, *subpackage.Vec]
+	Pool subpackage.Pool[Id, *Id]
+	Opts []Option[Id, *Id]
+	List List[Id, *Id]
+	Option[Id, *Id]
+}
+
+// Decoded from untrusted data in the limits tests
+//cod:struct
+type Untrusted struct {
+	Name string
+	Tags []string
+	Scores map[string]uint32
+	Child *Untrusted
+}
+
+// Decoded repeatedly into the same value in the reuse tests
+//cod:struct
+type Pooled struct {
+	Ids []uint32
+	Positions []subpackage.Vec
+	Counts map[uint32]uint16
+	List IdList
+	Groups [][]uint32
+	Lists []IdList
+}
+
+// Decoded repeatedly into the same value in the reuse tests
+//cod:struct evolvable
+type PooledSave struct {
+	Ids []uint32
+	Groups [][]uint32
+	Counts map[uint32]uint16
+	Pos subpackage.Vec
+}
+
+//cod:struct
+type IdList []Id
+
+// Cloned in the clone tests. The cache isn't compared, so it isn't cloned either
+//cod:struct
+type Snapshot struct {
+	Tick Tick
+	Entities []EntityFlags
+	Names map[uint32]string
+	Cache []uint8 `cod.skip:"equality"`
+}
+
+// CodEquals compares floats so that NaN equals NaN and -0 doesn't equal 0
+//cod:struct
+type Floats struct {
+	F32 float32
+	F64 float64
+	Vals []float64
+}
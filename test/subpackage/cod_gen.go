@@ -5,6 +5,818 @@ import (
 	"github.com/unitoftime/cod/backend"
 )
 
+func (t Inventory) EncodeCod(bs []byte) []byte {
+
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Items)))
+
+		for _, k1 := range backend.SortedKeys(t.Items) {
+			v1 := t.Items[k1]
+
+			bs = backend.WriteString(bs, (k1))
+
+			bs = backend.WriteVarUint32(bs, (v1))
+
+		}
+
+	}
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Slots)))
+
+		bs = backend.AppendSortedEntries(bs, t.Slots, func(bs []byte, k1 Vec, v1 string) []byte {
+
+			bs = k1.EncodeCod(bs)
+			bs = backend.WriteString(bs, (v1))
+
+			return bs
+		})
+
+	}
+	return bs
+}
+
+func (t *Inventory) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *Inventory) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitMap[string, uint32](lim, length)
+		if err != nil {
+			return 0, err
+		}
+
+		if t.Items == nil {
+			t.Items = make(map[string]uint32, backend.PreallocLen(length, len(bs[n:])))
+		} else {
+			clear(t.Items)
+		}
+
+		for i1 := 0; i1 < int(length); i1++ {
+			var key1 string
+			var val1 uint32
+
+			{
+				var decoded string
+				decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				key1 = (decoded)
+			}
+
+			{
+				var decoded uint32
+				decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				val1 = (decoded)
+			}
+
+			if err != nil {
+				return 0, err
+			}
+
+			t.Items[key1] = val1
+		}
+	}
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitMap[Vec, string](lim, length)
+		if err != nil {
+			return 0, err
+		}
+
+		if t.Slots == nil {
+			t.Slots = make(map[Vec]string, backend.PreallocLen(length, len(bs[n:])))
+		} else {
+			clear(t.Slots)
+		}
+
+		for i1 := 0; i1 < int(length); i1++ {
+			var key1 Vec
+			var val1 string
+
+			nOff, err = key1.DecodeCodWithLimits(bs[n:], lim)
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+
+			{
+				var decoded string
+				decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				val1 = (decoded)
+			}
+
+			if err != nil {
+				return 0, err
+			}
+
+			t.Slots[key1] = val1
+		}
+	}
+
+	// println("Inventory:", n)
+	return n, err
+}
+
+func (t Inventory) CodEquals(tt Inventory) bool {
+
+	{
+		if len(t.Items) != len(tt.Items) {
+			return false
+		}
+		for k1, v1 := range t.Items {
+			tv1, ok := tt.Items[k1]
+			if !ok {
+				return false
+			}
+
+			if v1 != tv1 {
+				return false
+			}
+
+		}
+	}
+	{
+		if len(t.Slots) != len(tt.Slots) {
+			return false
+		}
+		for k1, v1 := range t.Slots {
+			tv1, ok := tt.Slots[k1]
+			if !ok {
+				return false
+			}
+
+			if v1 != tv1 {
+				return false
+			}
+
+		}
+	}
+	return true
+}
+
+func (t Inventory) CodClone() Inventory {
+	var ct Inventory
+
+	if t.Items != nil {
+		ct.Items = make(map[string]uint32, len(t.Items))
+		for k1, v1 := range t.Items {
+			var cv1 uint32
+
+			cv1 = v1
+			ct.Items[k1] = cv1
+		}
+	}
+	if t.Slots != nil {
+		ct.Slots = make(map[Vec]string, len(t.Slots))
+		for k1, v1 := range t.Slots {
+			var cv1 string
+
+			cv1 = v1
+			ct.Slots[k1] = cv1
+		}
+	}
+	return ct
+}
+
+func (t Inventory) CodSize() int {
+	n := 0
+
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Items)))
+		for k1, v1 := range t.Items {
+
+			n += backend.SizeString((k1))
+			n += backend.SizeVarUint32((v1))
+		}
+	}
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Slots)))
+		for k1, v1 := range t.Slots {
+
+			n += k1.CodSize()
+			n += backend.SizeString((v1))
+		}
+	}
+	return n
+}
+
+func (t Inventory) EncodeCodDelta(bs []byte, tt Inventory) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 1)...)
+
+	if !func() bool {
+
+		{
+			if len(t.Items) != len(tt.Items) {
+				return false
+			}
+			for k1, v1 := range t.Items {
+				tv1, ok := tt.Items[k1]
+				if !ok {
+					return false
+				}
+
+				if v1 != tv1 {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Items)))
+
+			for _, k1 := range backend.SortedKeys(t.Items) {
+				v1 := t.Items[k1]
+
+				bs = backend.WriteString(bs, (k1))
+
+				bs = backend.WriteVarUint32(bs, (v1))
+
+			}
+
+		}
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Slots) != len(tt.Slots) {
+				return false
+			}
+			for k1, v1 := range t.Slots {
+				tv1, ok := tt.Slots[k1]
+				if !ok {
+					return false
+				}
+
+				if v1 != tv1 {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 1
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Slots)))
+
+			bs = backend.AppendSortedEntries(bs, t.Slots, func(bs []byte, k1 Vec, v1 string) []byte {
+
+				bs = k1.EncodeCod(bs)
+				bs = backend.WriteString(bs, (v1))
+
+				return bs
+			})
+
+		}
+	}
+
+	return bs
+}
+
+func (t *Inventory) DecodeCodDelta(bs []byte, tt Inventory) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 1 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:1]
+	n += 1
+
+	if mask[0]&(1<<0) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitMap[string, uint32](lim, length)
+			if err != nil {
+				return 0, err
+			}
+
+			if t.Items == nil {
+				t.Items = make(map[string]uint32, backend.PreallocLen(length, len(bs[n:])))
+			} else {
+				clear(t.Items)
+			}
+
+			for i1 := 0; i1 < int(length); i1++ {
+				var key1 string
+				var val1 uint32
+
+				{
+					var decoded string
+					decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					key1 = (decoded)
+				}
+
+				{
+					var decoded uint32
+					decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					val1 = (decoded)
+				}
+
+				if err != nil {
+					return 0, err
+				}
+
+				t.Items[key1] = val1
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Items != nil {
+			ct.Items = make(map[string]uint32, len(t.Items))
+			for k1, v1 := range t.Items {
+				var cv1 uint32
+
+				cv1 = v1
+				ct.Items[k1] = cv1
+			}
+		}
+	}
+
+	if mask[0]&(1<<1) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitMap[Vec, string](lim, length)
+			if err != nil {
+				return 0, err
+			}
+
+			if t.Slots == nil {
+				t.Slots = make(map[Vec]string, backend.PreallocLen(length, len(bs[n:])))
+			} else {
+				clear(t.Slots)
+			}
+
+			for i1 := 0; i1 < int(length); i1++ {
+				var key1 Vec
+				var val1 string
+
+				nOff, err = key1.DecodeCodWithLimits(bs[n:], lim)
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+
+				{
+					var decoded string
+					decoded, nOff, err = backend.ReadStringLimited(bs[n:], lim)
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					val1 = (decoded)
+				}
+
+				if err != nil {
+					return 0, err
+				}
+
+				t.Slots[key1] = val1
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Slots != nil {
+			ct.Slots = make(map[Vec]string, len(t.Slots))
+			for k1, v1 := range t.Slots {
+				var cv1 string
+
+				cv1 = v1
+				ct.Slots[k1] = cv1
+			}
+		}
+	}
+
+	return n, err
+}
+
+func (t Inventory) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *Inventory) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
+func (t Pool[T, PT]) EncodeCod(bs []byte) []byte {
+
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Items)))
+		for i1 := range t.Items {
+
+			bs = t.Items[i1].EncodeCod(bs)
+		}
+	}
+	{
+		bs = backend.WriteVarUint64(bs, uint64(len(t.Free)))
+		for i1 := range t.Free {
+
+			bs = backend.WriteVarUint32(bs, (t.Free[i1]))
+
+		}
+	}
+	return bs
+}
+
+func (t *Pool[T, PT]) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *Pool[T, PT]) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
+
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitSlice[T](lim, length)
+		if err != nil {
+			return 0, err
+		}
+		t.Items = backend.ResetSlice(t.Items, length, len(bs[n:]))
+
+		for i1 := 0; i1 < int(length); i1++ {
+			t.Items = backend.ExtendSlice(t.Items)
+
+			nOff, err = PT(&t.Items[i1]).DecodeCodWithLimits(bs[n:], lim)
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	{
+		var length uint64
+		length, nOff, err = backend.ReadVarUint64(bs[n:])
+		if err != nil {
+			return 0, err
+		}
+		n += nOff
+		err = backend.LimitSlice[uint32](lim, length)
+		if err != nil {
+			return 0, err
+		}
+		t.Free = backend.ResetSlice(t.Free, length, len(bs[n:]))
+
+		for i1 := 0; i1 < int(length); i1++ {
+			t.Free = backend.ExtendSlice(t.Free)
+
+			{
+				var decoded uint32
+				decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+				t.Free[i1] = (decoded)
+			}
+
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	// println("Pool[T, PT]:", n)
+	return n, err
+}
+
+func (t Pool[T, PT]) CodEquals(tt Pool[T, PT]) bool {
+
+	{
+		if len(t.Items) != len(tt.Items) {
+			return false
+		}
+		for i1 := range t.Items {
+
+			if !t.Items[i1].CodEquals(tt.Items[i1]) {
+				return false
+			}
+
+		}
+	}
+	{
+		if len(t.Free) != len(tt.Free) {
+			return false
+		}
+		for i1 := range t.Free {
+
+			if t.Free[i1] != tt.Free[i1] {
+				return false
+			}
+
+		}
+	}
+	return true
+}
+
+func (t Pool[T, PT]) CodClone() Pool[T, PT] {
+	var ct Pool[T, PT]
+
+	if t.Items != nil {
+		ct.Items = make([]T, len(t.Items))
+		for i1 := range t.Items {
+
+			ct.Items[i1] = t.Items[i1].CodClone()
+		}
+	}
+	if t.Free != nil {
+		ct.Free = make([]uint32, len(t.Free))
+		for i1 := range t.Free {
+
+			ct.Free[i1] = t.Free[i1]
+		}
+	}
+	return ct
+}
+
+func (t Pool[T, PT]) CodSize() int {
+	n := 0
+
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Items)))
+		for i1 := range t.Items {
+
+			n += t.Items[i1].CodSize()
+		}
+	}
+	{
+		n += backend.SizeVarUint64(uint64(len(t.Free)))
+		for i1 := range t.Free {
+
+			n += backend.SizeVarUint32((t.Free[i1]))
+		}
+	}
+	return n
+}
+
+func (t Pool[T, PT]) EncodeCodDelta(bs []byte, tt Pool[T, PT]) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 1)...)
+
+	if !func() bool {
+
+		{
+			if len(t.Items) != len(tt.Items) {
+				return false
+			}
+			for i1 := range t.Items {
+
+				if !t.Items[i1].CodEquals(tt.Items[i1]) {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Items)))
+			for i1 := range t.Items {
+
+				bs = t.Items[i1].EncodeCod(bs)
+			}
+		}
+	}
+
+	if !func() bool {
+
+		{
+			if len(t.Free) != len(tt.Free) {
+				return false
+			}
+			for i1 := range t.Free {
+
+				if t.Free[i1] != tt.Free[i1] {
+					return false
+				}
+
+			}
+		}
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 1
+
+		{
+			bs = backend.WriteVarUint64(bs, uint64(len(t.Free)))
+			for i1 := range t.Free {
+
+				bs = backend.WriteVarUint32(bs, (t.Free[i1]))
+
+			}
+		}
+	}
+
+	return bs
+}
+
+func (t *Pool[T, PT]) DecodeCodDelta(bs []byte, tt Pool[T, PT]) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 1 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:1]
+	n += 1
+
+	if mask[0]&(1<<0) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[T](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			t.Items = backend.ResetSlice(t.Items, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				t.Items = backend.ExtendSlice(t.Items)
+
+				nOff, err = PT(&t.Items[i1]).DecodeCodWithLimits(bs[n:], lim)
+				if err != nil {
+					return 0, err
+				}
+				n += nOff
+
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Items != nil {
+			ct.Items = make([]T, len(t.Items))
+			for i1 := range t.Items {
+
+				ct.Items[i1] = t.Items[i1].CodClone()
+			}
+		}
+	}
+
+	if mask[0]&(1<<1) != 0 {
+
+		{
+			var length uint64
+			length, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			err = backend.LimitSlice[uint32](lim, length)
+			if err != nil {
+				return 0, err
+			}
+			t.Free = backend.ResetSlice(t.Free, length, len(bs[n:]))
+
+			for i1 := 0; i1 < int(length); i1++ {
+				t.Free = backend.ExtendSlice(t.Free)
+
+				{
+					var decoded uint32
+					decoded, nOff, err = backend.ReadVarUint32(bs[n:])
+					if err != nil {
+						return 0, err
+					}
+					n += nOff
+					t.Free[i1] = (decoded)
+				}
+
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		if t.Free != nil {
+			ct.Free = make([]uint32, len(t.Free))
+			for i1 := range t.Free {
+
+				ct.Free[i1] = t.Free[i1]
+			}
+		}
+	}
+
+	return n, err
+}
+
+func (t Pool[T, PT]) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *Pool[T, PT]) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
+
 func (t Vec) EncodeCod(bs []byte) []byte {
 
 	bs = backend.WriteVarUint64(bs, (t.X))
@@ -15,9 +827,20 @@ func (t Vec) EncodeCod(bs []byte) []byte {
 }
 
 func (t *Vec) DecodeCod(bs []byte) (int, error) {
+	return t.DecodeCodWithLimits(bs, nil)
+}
+
+func (t *Vec) DecodeCodWithLimits(bs []byte, lim *backend.Limiter) (int, error) {
 	var err error
 	var n int
 	var nOff int
+	_ = nOff // Unused if every field is in the bitfield
+
+	err = lim.Enter()
+	if err != nil {
+		return 0, err
+	}
+	defer lim.Exit()
 
 	{
 		var decoded uint64
@@ -55,3 +878,121 @@ func (t Vec) CodEquals(tt Vec) bool {
 
 	return true
 }
+
+func (t Vec) CodClone() Vec {
+	var ct Vec
+
+	ct.X = t.X
+	ct.Y = t.Y
+	return ct
+}
+
+func (t Vec) CodSize() int {
+	n := 0
+
+	n += backend.SizeVarUint64((t.X))
+	n += backend.SizeVarUint64((t.Y))
+	return n
+}
+
+func (t Vec) EncodeCodDelta(bs []byte, tt Vec) []byte {
+	// Each bit of the mask is set if that field changed from the base
+	maskStart := len(bs)
+	bs = append(bs, make([]byte, 1)...)
+
+	if !func() bool {
+
+		if t.X != tt.X {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 0
+
+		bs = backend.WriteVarUint64(bs, (t.X))
+
+	}
+
+	if !func() bool {
+
+		if t.Y != tt.Y {
+			return false
+		}
+
+		return true
+	}() {
+		bs[maskStart+0] |= 1 << 1
+
+		bs = backend.WriteVarUint64(bs, (t.Y))
+
+	}
+
+	return bs
+}
+
+func (t *Vec) DecodeCodDelta(bs []byte, tt Vec) (int, error) {
+	var err error
+	var n int
+	var nOff int
+	_ = nOff
+	var lim *backend.Limiter // Deltas are decoded without limits
+	_ = lim
+
+	if len(bs) < 1 {
+		return 0, backend.ErrTruncatedData
+	}
+	mask := bs[:1]
+	n += 1
+
+	if mask[0]&(1<<0) != 0 {
+
+		{
+			var decoded uint64
+			decoded, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.X = (decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.X = t.X
+	}
+
+	if mask[0]&(1<<1) != 0 {
+
+		{
+			var decoded uint64
+			decoded, nOff, err = backend.ReadVarUint64(bs[n:])
+			if err != nil {
+				return 0, err
+			}
+			n += nOff
+			t.Y = (decoded)
+		}
+
+	} else {
+		// Unchanged, so copy the field from the base
+		ct := t
+		t := &tt
+
+		ct.Y = t.Y
+	}
+
+	return n, err
+}
+
+func (t Vec) EncodeCodTo(w *backend.Writer) error {
+	return w.Encode(t.EncodeCod)
+}
+
+func (t *Vec) DecodeCodFrom(r *backend.Reader) error {
+	// Note: The decode may be retried once more data is read, which is safe because slices and maps are reset on every decode
+	return r.Decode(t.DecodeCod)
+}
@@ -1,10 +1,27 @@
 package subpackage
 
+import "github.com/unitoftime/cod"
+
+// All maps in this package are encoded in sorted key order
+//cod:package deterministic
+
 //cod:struct
 type Vec struct {
 	X, Y uint64
 }
 
+//cod:struct
+type Inventory struct {
+	Items map[string]uint32
+	Slots map[Vec]string // Struct keys are sorted by their encoded bytes
+}
+
+//cod:struct
+type Pool[T cod.Codec[T], PT cod.CodecPtr[T]] struct {
+	Items []T
+	Free []uint32
+}
+
 // func MapsEqual[K, V any](m1, m2 map[K]V) bool {
 // 	if len(m1) != len(m2) { return false }
 // 	for k, v := range m1 {
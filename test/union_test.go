@@ -0,0 +1,95 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/unitoftime/cod/backend"
+	"github.com/unitoftime/cod/test/subpackage"
+)
+
+func TestUnionTypedAccessors(t *testing.T) {
+	u := NewMyUnionFromId(Id{Val: 3})
+
+	id, ok := u.GetId()
+	if !ok || id.Val != 3 {
+		t.Errorf("expected Id 3, got %v %v", id, ok)
+	}
+	_, ok = u.GetVec()
+	if ok {
+		t.Error("union holds an Id, but GetVec succeeded")
+	}
+
+	u.SetVec(subpackage.Vec{X: 1, Y: 2})
+	vec, ok := u.GetVec()
+	if !ok || vec.X != 1 || vec.Y != 2 {
+		t.Errorf("expected Vec, got %v %v", vec, ok)
+	}
+	_, ok = u.GetId()
+	if ok {
+		t.Error("union holds a Vec, but GetId succeeded")
+	}
+
+	res := MyUnion{}
+	_, err := res.DecodeCod(u.EncodeCod(nil))
+	if err != nil { panic(err) }
+	if !u.CodEquals(res) {
+		t.Error("MISMATCH")
+	}
+}
+
+func TestUnionSetPanics(t *testing.T) {
+	u := MyUnion{}
+	u.Set(nil) // Nil is always allowed
+	u.Set(Id{Val: 1})
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected Set to panic on a non-member type")
+		}
+	}()
+	u.Set(BlankStruct{})
+}
+
+func TestUnionSetError(t *testing.T) {
+	u := CheckedUnion{}
+	err := u.Set(Id{Val: 1})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err = u.Set(BlankStruct{})
+	if !errors.Is(err, backend.ErrUnknownUnionType) {
+		t.Errorf("expected ErrUnknownUnionType, got %v", err)
+	}
+
+	// The union must be unchanged after a rejected set
+	id, ok := u.GetId()
+	if !ok || id.Val != 1 {
+		t.Errorf("expected Id 1, got %v %v", id, ok)
+	}
+
+	_, err = NewCheckedUnion(BlankStruct{})
+	if err == nil {
+		t.Error("expected an error from the constructor")
+	}
+}
+
+func TestUnionDecodeNil(t *testing.T) {
+	empty := MyUnion{}
+	bs := empty.EncodeCod(nil)
+
+	// Decoding a nil union consumes its tag and clears the old value
+	res := NewMyUnionFromId(Id{Val: 3})
+	n, err := res.DecodeCod(bs)
+	if err != nil { panic(err) }
+	if n != len(bs) {
+		t.Errorf("expected %d bytes, got %d", len(bs), n)
+	}
+	if res.Get() != nil {
+		t.Errorf("expected nil union, got %v", res.Get())
+	}
+	if !empty.CodEquals(res) {
+		t.Error("MISMATCH")
+	}
+}